@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -47,11 +48,21 @@ func main() {
 		log.Printf("Warning: Failed to clean old reports: %v", err)
 	}
 
+	// Initialize rule cache shared by all report generations
+	ruleCachePath := ""
+	if cfg.RuleCachePersist {
+		ruleCachePath = cfg.ReportStoragePath
+	}
+	ruleCache := report.NewRuleCache(sonarClient, ruleCachePath, cfg.RuleCacheSize, time.Duration(cfg.RuleCacheTTLHours)*time.Hour)
+
 	// Initialize authenticator
 	authenticator := auth.NewAuthenticator(cfg.AdminUsername, cfg.AdminPassword)
 
 	// Initialize handlers
-	apiHandler := handler.NewAPIHandler(sonarClient, storage)
+	apiHandler, err := handler.NewAPIHandler(sonarClient, storage, ruleCache, cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize API handler: %v", err)
+	}
 	webHandler := handler.NewWebHandler(authenticator, sonarClient, storage)
 
 	// Setup Gin
@@ -106,14 +117,54 @@ func main() {
 		// Projects
 		api.GET("/projects", apiHandler.GetProjects)
 		api.GET("/projects/:key/branches", apiHandler.GetBranches)
+		api.GET("/projects/:key/pull-requests", apiHandler.GetPullRequests)
+		api.GET("/metrics", apiHandler.ListMetrics)
+		api.GET("/locales", apiHandler.ListLanguages)
+		api.GET("/formats", apiHandler.ListFormats)
+
+		// Compliance policies and suppressions
+		api.GET("/policies", apiHandler.ListPolicies)
+		api.GET("/suppressions", apiHandler.ListSuppressions)
+
+		// Issue baselines
+		api.GET("/baselines", apiHandler.ListBaselines)
+		api.POST("/baselines", apiHandler.CreateBaseline)
+		api.GET("/baselines/:key", apiHandler.GetBaseline)
+		api.POST("/baselines/:key/refresh", apiHandler.RefreshBaseline)
+		api.DELETE("/baselines/:key", apiHandler.DeleteBaseline)
+
+		// Code ownership
+		api.GET("/projects/:key/codeowners", apiHandler.GetCodeOwners)
+		api.PUT("/projects/:key/codeowners", apiHandler.PutCodeOwners)
+		api.DELETE("/projects/:key/codeowners", apiHandler.DeleteCodeOwners)
+
+		// Report profiles
+		api.GET("/profiles", apiHandler.ListProfiles)
+		api.POST("/profiles", apiHandler.CreateProfile)
+		api.GET("/profiles/:name", apiHandler.GetProfile)
+		api.PUT("/profiles/:name", apiHandler.UpdateProfile)
+		api.DELETE("/profiles/:name", apiHandler.DeleteProfile)
 
 		// Reports
 		api.POST("/reports/generate", apiHandler.GenerateReport)
+		api.POST("/reports/generate-teams", apiHandler.GenerateTeamReports)
 		api.GET("/reports/history", apiHandler.GetHistory)
+		api.GET("/reports/compare", apiHandler.CompareReports)
 		api.GET("/reports/:id/download", apiHandler.DownloadReport)
 		api.GET("/reports/:id/preview", apiHandler.PreviewReport)
+		api.GET("/reports/:id/data", apiHandler.GetReportData)
+		api.GET("/reports/:id/issues", apiHandler.GetReportIssues)
+		api.POST("/reports/:id/render", apiHandler.RerenderReport)
+		api.GET("/reports/:id/policy", apiHandler.EvaluateReportPolicy)
 		api.DELETE("/reports/:id", apiHandler.DeleteReport)
 		api.DELETE("/reports/history", apiHandler.ClearHistory)
+
+		// Report jobs
+		api.GET("/jobs", apiHandler.ListJobs)
+		api.GET("/jobs/:id", apiHandler.GetJob)
+		api.GET("/jobs/:id/events", apiHandler.StreamProgress)
+		api.GET("/jobs/:id/report", apiHandler.GetJobReport)
+		api.POST("/jobs/:id/cancel", apiHandler.CancelJob)
 	}
 
 	// Start server
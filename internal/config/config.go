@@ -23,6 +23,42 @@ type Config struct {
 	// Report Storage
 	ReportStoragePath   string
 	ReportRetentionDays int
+	ReportReuseExisting bool
+
+	// Rule Cache
+	RuleCacheSize     int
+	RuleCacheTTLHours int
+	RuleCachePersist  bool
+
+	// Report Jobs
+	JobWorkers   int
+	JobQueueSize int
+
+	// Compliance policies
+	PolicyFile string
+
+	// Issues hidden from reports
+	SuppressionFile string
+
+	// Code ownership: one CODEOWNERS file per project, named after the
+	// project key (default: <REPORT_STORAGE_PATH>/codeowners)
+	CodeOwnersDir string
+
+	// Secret redaction in messages and code snippets
+	RedactSecrets bool
+	RedactionFile string
+
+	// Issue prioritisation
+	ScoreWeights   string
+	TopIssuesCount int
+
+	// Effort estimates
+	WorkingDayHours int
+
+	// Language and IANA time zone reports are rendered in unless a request
+	// chooses them
+	ReportLanguage string
+	ReportTimezone string
 }
 
 func Load() *Config {
@@ -35,6 +71,22 @@ func Load() *Config {
 		SessionSecret:       getEnv("SESSION_SECRET", "default-secret-key-change-in-production"),
 		ReportStoragePath:   getEnv("REPORT_STORAGE_PATH", "./reports"),
 		ReportRetentionDays: getEnvInt("REPORT_RETENTION_DAYS", 30),
+		ReportReuseExisting: getEnvBool("REPORT_REUSE_EXISTING", false),
+		RuleCacheSize:       getEnvInt("RULE_CACHE_SIZE", 5000),
+		RuleCacheTTLHours:   getEnvInt("RULE_CACHE_TTL_HOURS", 168),
+		RuleCachePersist:    getEnvBool("RULE_CACHE_PERSIST", true),
+		JobWorkers:          getEnvInt("JOB_WORKERS", 2),
+		JobQueueSize:        getEnvInt("JOB_QUEUE_SIZE", 50),
+		PolicyFile:          getEnv("POLICY_FILE", ""),
+		SuppressionFile:     getEnv("SUPPRESSION_FILE", ""),
+		CodeOwnersDir:       getEnv("CODEOWNERS_DIR", ""),
+		RedactSecrets:       getEnvBool("REDACT_SECRETS", true),
+		RedactionFile:       getEnv("REDACTION_FILE", ""),
+		ScoreWeights:        getEnv("SCORE_WEIGHTS", ""),
+		TopIssuesCount:      getEnvInt("TOP_ISSUES_COUNT", 10),
+		WorkingDayHours:     getEnvInt("WORKING_DAY_HOURS", 8),
+		ReportLanguage:      getEnv("REPORT_LANGUAGE", "en"),
+		ReportTimezone:      getEnv("REPORT_TIMEZONE", "UTC"),
 	}
 }
 
@@ -53,3 +105,12 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"sonarqube-report-generator/internal/report"
+)
+
+// BaselineRequest is the request body for creating a baseline
+type BaselineRequest struct {
+	ProjectKey string `json:"projectKey" binding:"required"`
+	Branch     string `json:"branch"` // empty for the main branch
+}
+
+// ListBaselines returns all baselines without their fingerprints
+func (h *APIHandler) ListBaselines(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"baselines": h.baselines.List()})
+}
+
+// GetBaseline returns the baseline of a project branch (?branch=, empty for
+// the main branch) including its fingerprints
+func (h *APIHandler) GetBaseline(c *gin.Context) {
+	projectKey := c.Param("key")
+	baseline, ok := h.baselines.Get(projectKey, h.baselineBranch(projectKey, c.Query("branch")))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "baseline not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"baseline": baseline})
+}
+
+// CreateBaseline freezes the open issues of a project branch into a new baseline
+func (h *APIHandler) CreateBaseline(c *gin.Context) {
+	var req BaselineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Fail before capturing the issues; storing the baseline checks again
+	branch := h.baselineBranch(req.ProjectKey, req.Branch)
+	if _, ok := h.baselines.Get(req.ProjectKey, branch); ok {
+		c.JSON(http.StatusConflict, gin.H{"error": report.ErrBaselineExists.Error()})
+		return
+	}
+
+	h.captureBaseline(c, req.ProjectKey, branch, false)
+}
+
+// RefreshBaseline replaces the issues of an existing baseline with the
+// current open issues of its project branch
+func (h *APIHandler) RefreshBaseline(c *gin.Context) {
+	projectKey := c.Param("key")
+	branch := h.baselineBranch(projectKey, c.Query("branch"))
+	if _, ok := h.baselines.Get(projectKey, branch); !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "baseline not found"})
+		return
+	}
+
+	h.captureBaseline(c, projectKey, branch, true)
+}
+
+// DeleteBaseline deletes the baseline of a project branch
+func (h *APIHandler) DeleteBaseline(c *gin.Context) {
+	projectKey := c.Param("key")
+	if err := h.baselines.Delete(projectKey, h.baselineBranch(projectKey, c.Query("branch"))); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// baselineBranch returns the branch a baseline is stored under. The main
+// branch is stored as empty, whether it is requested by name or not.
+func (h *APIHandler) baselineBranch(projectKey, branch string) string {
+	if branch != "" && branch == h.generator.MainBranch(projectKey) {
+		return ""
+	}
+	return branch
+}
+
+// captureBaseline captures and stores the baseline of a project branch,
+// replacing its existing one or creating a new one
+func (h *APIHandler) captureBaseline(c *gin.Context, projectKey, branch string, replace bool) {
+	baseline, err := h.generator.CaptureBaseline(c.Request.Context(), projectKey, branch)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Another request may have created or deleted the baseline meanwhile
+	switch err := h.baselines.Put(baseline, replace); {
+	case errors.Is(err, report.ErrBaselineExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, report.ErrBaselineNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if !replace {
+		status = http.StatusCreated
+	}
+	summary := *baseline
+	summary.Fingerprints = nil
+	c.JSON(status, gin.H{"success": true, "baseline": summary})
+}
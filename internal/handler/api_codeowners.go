@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"sonarqube-report-generator/internal/job"
+	"sonarqube-report-generator/internal/report"
+)
+
+// maxCodeOwnersSize is the largest CODEOWNERS file accepted by upload
+const maxCodeOwnersSize = 1 << 20
+
+// TeamReportsRequest is the request body for generating one report per team:
+// the options of a single report plus the teams (default: all owners in the
+// project's CODEOWNERS)
+type TeamReportsRequest struct {
+	GenerateRequest
+	Teams []string `json:"teams,omitempty"`
+}
+
+// GetCodeOwners returns the CODEOWNERS of a project and the teams it names
+func (h *APIHandler) GetCodeOwners(c *gin.Context) {
+	owners, err := h.codeOwners.Get(c.Param("key"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, report.ErrInvalidProjectKey) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if owners == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "CODEOWNERS not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"projectKey": c.Param("key"),
+		"teams":      owners.Teams(),
+		"content":    owners.Content(),
+	})
+}
+
+// PutCodeOwners stores the CODEOWNERS of a project, uploaded as a "file"
+// form field or sent as the raw request body
+func (h *APIHandler) PutCodeOwners(c *gin.Context) {
+	var body io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer f.Close()
+		body = f
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(body, maxCodeOwnersSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(raw) > maxCodeOwnersSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "CODEOWNERS file is too large"})
+		return
+	}
+
+	owners, err := h.codeOwners.Put(c.Param("key"), string(raw))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "teams": owners.Teams()})
+}
+
+// DeleteCodeOwners removes the CODEOWNERS of a project
+func (h *APIHandler) DeleteCodeOwners(c *gin.Context) {
+	if err := h.codeOwners.Delete(c.Param("key")); err != nil {
+		status := http.StatusNotFound
+		if errors.Is(err, report.ErrInvalidProjectKey) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// GenerateTeamReports queues one report per team, each limited to the files
+// the team owns. All requests are validated, and the queue checked for room
+// for all of them, before any job is queued.
+func (h *APIHandler) GenerateTeamReports(c *gin.Context) {
+	var req TeamReportsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	teams := req.Teams
+	if len(teams) == 0 {
+		owners, err := h.codeOwners.Get(req.ProjectKey)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if owners == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "project has no CODEOWNERS"})
+			return
+		}
+		teams = owners.Teams()
+	}
+	if len(teams) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CODEOWNERS names no teams"})
+		return
+	}
+
+	type teamGeneration struct {
+		req         GenerateRequest
+		analysisKey string
+	}
+	generations := make([]teamGeneration, 0, len(teams))
+	for _, team := range teams {
+		r := req.GenerateRequest
+		r.Team = team
+		analysisKey, err := h.prepareGeneration(&r)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": team + ": " + err.Error()})
+			return
+		}
+		generations = append(generations, teamGeneration{r, analysisKey})
+	}
+
+	reuse := h.reuseExisting
+	if req.ReuseExisting != nil {
+		reuse = *req.ReuseExisting
+	}
+
+	// Queue all teams' jobs or none, so a full queue never leaves a partial set
+	results := make([]gin.H, len(generations))
+	var submissions []job.Submission
+	var submitted []int
+	for i, g := range generations {
+		key := g.req.generationKey(g.analysisKey)
+		if reuse && g.analysisKey != "" {
+			if record, ok := h.storage.FindByGenerationKey(key); ok {
+				results[i] = gin.H{"team": g.req.Team, "reused": true, "report": record}
+				continue
+			}
+		}
+		submissions = append(submissions, job.Submission{Key: key, ProjectKey: g.req.ProjectKey, Branch: g.req.Branch, Format: g.req.Format, Request: g.req})
+		submitted = append(submitted, i)
+	}
+
+	jobs, err := h.jobs.SubmitAll(submissions)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, job.ErrQueueFull) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	for n, i := range submitted {
+		results[i] = gin.H{"team": generations[i].req.Team, "deduplicated": jobs[n].Deduplicated, "job": jobs[n].Job}
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"success": true, "reports": results})
+}
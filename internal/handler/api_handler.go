@@ -1,14 +1,22 @@
 package handler
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"sonarqube-report-generator/internal/config"
+	"sonarqube-report-generator/internal/job"
 	"sonarqube-report-generator/internal/report"
 	"sonarqube-report-generator/internal/sonarqube"
 )
@@ -18,19 +26,100 @@ type APIHandler struct {
 	sonarClient *sonarqube.Client
 	generator   *report.Generator
 	storage     *report.Storage
-	mdGen       *report.MarkdownGenerator
-	pdfGen      *report.PDFGenerator
+	progress    *ProgressHub
+	jobs        *job.Manager
+	policies    *report.PolicySet
+	baselines   *report.BaselineStore
+	codeOwners  *report.CodeOwnersStore
+	profiles    *report.ProfileStore
+
+	suppressions *report.SuppressionList
+	redactor     *report.Redactor
+
+	reuseExisting bool                // default for GenerateRequest.ReuseExisting
+	scoreWeights  report.ScoreWeights // default for GenerateRequest.ScoreWeights
+	topIssues     int                 // default for GenerateRequest.TopIssues
+	dayHours      int                 // default for GenerateRequest.WorkingDayHours
+	language      string              // default for GenerateRequest.Language
+	timezone      string              // default for GenerateRequest.Timezone
 }
 
-// NewAPIHandler creates a new API handler
-func NewAPIHandler(client *sonarqube.Client, storage *report.Storage) *APIHandler {
-	return &APIHandler{
+// NewAPIHandler creates a new API handler and starts its report job workers
+func NewAPIHandler(client *sonarqube.Client, storage *report.Storage, rules *report.RuleCache, cfg *config.Config) (*APIHandler, error) {
+	h := &APIHandler{
 		sonarClient: client,
-		generator:   report.NewGenerator(client),
+		generator:   report.NewGenerator(client, rules),
 		storage:     storage,
-		mdGen:       report.NewMarkdownGenerator(),
-		pdfGen:      report.NewPDFGenerator(),
+		progress:    NewProgressHub(),
+
+		reuseExisting: cfg.ReportReuseExisting,
+		topIssues:     cfg.TopIssuesCount,
+		dayHours:      cfg.WorkingDayHours,
+		language:      cfg.ReportLanguage,
+		timezone:      cfg.ReportTimezone,
+	}
+
+	if err := report.ValidateLanguage(cfg.ReportLanguage); err != nil {
+		return nil, fmt.Errorf("invalid REPORT_LANGUAGE: %w", err)
+	}
+	if err := report.ValidateTimezone(cfg.ReportTimezone); err != nil {
+		return nil, fmt.Errorf("invalid REPORT_TIMEZONE: %w", err)
+	}
+
+	weights, err := report.ParseScoreWeights(cfg.ScoreWeights)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCORE_WEIGHTS: %w", err)
+	}
+	h.scoreWeights = weights
+
+	policies, err := report.LoadPolicies(cfg.PolicyFile)
+	if err != nil {
+		return nil, err
+	}
+	h.policies = policies
+
+	suppressions, err := report.LoadSuppressions(cfg.SuppressionFile)
+	if err != nil {
+		return nil, err
+	}
+	h.suppressions = suppressions
+
+	redactor, err := report.LoadRedactor(cfg.RedactSecrets, cfg.RedactionFile)
+	if err != nil {
+		return nil, err
 	}
+	h.redactor = redactor
+
+	baselines, err := report.NewBaselineStore(storage.BasePath())
+	if err != nil {
+		return nil, err
+	}
+	h.baselines = baselines
+
+	profiles, err := report.NewProfileStore(storage.BasePath())
+	if err != nil {
+		return nil, err
+	}
+	h.profiles = profiles
+
+	codeOwnersDir := cfg.CodeOwnersDir
+	if codeOwnersDir == "" {
+		codeOwnersDir = filepath.Join(storage.BasePath(), "codeowners")
+	}
+	codeOwners, err := report.NewCodeOwnersStore(codeOwnersDir)
+	if err != nil {
+		return nil, err
+	}
+	h.codeOwners = codeOwners
+
+	jobs, err := job.NewManager(storage.BasePath(), cfg.JobWorkers, cfg.JobQueueSize, h.runGeneration, h.progress.Reporter)
+	if err != nil {
+		return nil, err
+	}
+	h.jobs = jobs
+	h.jobs.Start()
+
+	return h, nil
 }
 
 // HealthCheck returns the health status
@@ -69,16 +158,233 @@ func (h *APIHandler) GetBranches(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"branches": branches})
 }
 
+// GetPullRequests returns analysed pull requests for a project
+func (h *APIHandler) GetPullRequests(c *gin.Context) {
+	projectKey := c.Param("key")
+	if projectKey == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "project key is required"})
+		return
+	}
+
+	pullRequests, err := h.sonarClient.GetPullRequests(projectKey)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pullRequests": pullRequests})
+}
+
+// ListMetrics returns the metrics that can be selected for reports
+func (h *APIHandler) ListMetrics(c *gin.Context) {
+	metrics, err := h.generator.Metrics().List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"metrics": metrics})
+}
+
+// ListLanguages returns the languages reports can be rendered in and the
+// default language and time zone
+func (h *APIHandler) ListLanguages(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"languages": report.Languages(), "default": h.language, "defaultTimezone": h.timezone})
+}
+
+// ListFormats returns the formats reports can be rendered in and the default
+func (h *APIHandler) ListFormats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"formats": report.Formats(), "default": report.FormatMarkdown})
+}
+
 // GenerateRequest is the request body for generating reports
 type GenerateRequest struct {
-	ProjectKey          string `json:"projectKey" binding:"required"`
-	Branch              string `json:"branch"`
-	Format              string `json:"format"`              // md or pdf
-	IncludeCodeSnippets *bool  `json:"includeCodeSnippets"` // include code snippets in report (default: true)
-	IncludeHowToFix     *bool  `json:"includeHowToFix"`     // include how to fix in report (default: true)
+	ProjectKey             string `json:"projectKey" binding:"required"`
+	Branch                 string `json:"branch"`
+	Format                 string `json:"format"`                 // see GET /api/v1/formats (default: md)
+	IncludeCodeSnippets    *bool  `json:"includeCodeSnippets"`    // include code snippets in report (default: true)
+	IncludeHowToFix        *bool  `json:"includeHowToFix"`        // include how to fix in report (default: true)
+	MaxSnippetsPerSeverity int    `json:"maxSnippetsPerSeverity"` // issues per severity enriched with snippets (default: 10, at most 50)
+	ReuseExisting          *bool  `json:"reuseExisting"`          // return a stored report if nothing was analyzed since (default: REPORT_REUSE_EXISTING)
+
+	// CompareBranch turns the report into a side-by-side comparison of Branch
+	// against this base branch ("pr:<id>" for a pull request, empty for none)
+	CompareBranch string `json:"compareBranch,omitempty"`
+
+	// AsOf reconstructs the report for a past date (YYYY-MM-DD or a date-time)
+	// or analysis key from SonarQube's history
+	AsOf string `json:"asOf,omitempty"`
+
+	// Activity turns the report into an activity report of the issues opened
+	// and resolved during a period
+	Activity *ActivityRequest `json:"activity,omitempty"`
+
+	// Policy names the compliance policy to evaluate (default: the policy
+	// matching the project, if any)
+	Policy string `json:"policy,omitempty"`
+
+	// ScoreWeights weigh the factors of the issue risk score, factors left
+	// out weigh nothing (default: SCORE_WEIGHTS). TopIssues is the number of
+	// top scored issues to list (default: TOP_ISSUES_COUNT).
+	ScoreWeights *report.ScoreWeights `json:"scoreWeights,omitempty"`
+	TopIssues    int                  `json:"topIssues,omitempty"`
+
+	// WorkingDayHours is the length of a working day for remediation effort
+	// estimates (default: WORKING_DAY_HOURS)
+	WorkingDayHours int `json:"workingDayHours,omitempty"`
+
+	// Baseline separates the issues accepted in the project branch's
+	// baseline from the new ones
+	Baseline bool `json:"baseline,omitempty"`
+
+	// Team limits the report to the files the team owns according to the
+	// project's CODEOWNERS
+	Team string `json:"team,omitempty"`
+
+	// Metrics are extra metric keys listed with their values in the report
+	Metrics []string `json:"metrics,omitempty"`
+
+	// Profile names a report profile whose options fill those left unset
+	Profile string `json:"profile,omitempty"`
+
+	// Language is the language the report is rendered in (default:
+	// REPORT_LANGUAGE). SonarQube's own texts, such as issue messages and
+	// rule names, stay as SonarQube returns them.
+	Language string `json:"language,omitempty"`
+
+	// Timezone is the IANA time zone report times are shown in, and in which
+	// activity periods and as-of dates are whole days (default:
+	// REPORT_TIMEZONE)
+	Timezone string `json:"timezone,omitempty"`
+
+	// Sections lists the sections to include (default: all), Severities and
+	// Types keep only the issues of these severities and types, and
+	// Groupings lists the breakdowns of the remediation plan (default: all)
+	Sections   []string `json:"sections,omitempty"`
+	Severities []string `json:"severities,omitempty"`
+	Types      []string `json:"types,omitempty"`
+	Groupings  []string `json:"groupings,omitempty"`
+}
+
+// ActivityRequest selects the period of an activity report: a named period
+// ending on To (default today) or an explicit From/To range of dates
+type ActivityRequest struct {
+	Period string `json:"period,omitempty"` // week or month
+	From   string `json:"from,omitempty"`   // YYYY-MM-DD
+	To     string `json:"to,omitempty"`     // YYYY-MM-DD
+}
+
+// activityPeriod resolves the requested activity period, nil if none
+func (r GenerateRequest) activityPeriod() (*report.ActivityPeriod, error) {
+	if r.Activity == nil {
+		return nil, nil
+	}
+	period, err := report.ParseActivityPeriod(r.Activity.Period, r.Activity.From, r.Activity.To, time.Now(), report.LoadTimezone(r.Timezone))
+	if err != nil {
+		return nil, err
+	}
+	return &period, nil
+}
+
+// generateOptions converts the request into generator options with defaults applied
+func (r GenerateRequest) generateOptions() report.GenerateOptions {
+	options := report.GenerateOptions{
+		IncludeCodeSnippets:    true,
+		IncludeHowToFix:        true,
+		MaxSnippetsPerSeverity: r.MaxSnippetsPerSeverity,
+	}
+	if r.IncludeCodeSnippets != nil {
+		options.IncludeCodeSnippets = *r.IncludeCodeSnippets
+	}
+	if r.IncludeHowToFix != nil {
+		options.IncludeHowToFix = *r.IncludeHowToFix
+	}
+	if options.MaxSnippetsPerSeverity <= 0 {
+		options.MaxSnippetsPerSeverity = report.DefaultMaxSnippetsPerSeverity
+	}
+	options.AsOf = r.AsOf
+	options.ScoreWeights = r.ScoreWeights
+	options.TopIssues = r.TopIssues
+	options.WorkingDayHours = r.WorkingDayHours
+	options.Metrics = r.Metrics
+	options.Profile = r.Profile
+	options.Language = r.Language
+	options.Timezone = r.Timezone
+	options.Sections = r.Sections
+	options.Severities = r.Severities
+	options.Types = r.Types
+	options.Groupings = r.Groupings
+	// The period was validated when the request was accepted
+	options.Activity, _ = r.activityPeriod()
+	return options
+}
+
+// applyProfile fills the options left unset with those of a profile. Its
+// sections, filters and metrics only apply to reports of one branch's open
+// issues.
+func (r *GenerateRequest) applyProfile(p *report.ReportProfile) {
+	if r.Format == "" {
+		r.Format = p.Format
+	}
+	if r.IncludeCodeSnippets == nil {
+		r.IncludeCodeSnippets = p.IncludeCodeSnippets
+	}
+	if r.IncludeHowToFix == nil {
+		r.IncludeHowToFix = p.IncludeHowToFix
+	}
+	if r.MaxSnippetsPerSeverity == 0 {
+		r.MaxSnippetsPerSeverity = p.MaxSnippetsPerSeverity
+	}
+	if r.TopIssues == 0 {
+		r.TopIssues = p.TopIssues
+	}
+	if r.Language == "" {
+		r.Language = p.Language
+	}
+	if r.Timezone == "" {
+		r.Timezone = p.Timezone
+	}
+	if r.CompareBranch != "" || r.Activity != nil {
+		return
+	}
+	if len(r.Sections) == 0 {
+		r.Sections = p.Sections
+	}
+	if len(r.Severities) == 0 {
+		r.Severities = p.Severities
+	}
+	if len(r.Types) == 0 {
+		r.Types = p.Types
+	}
+	if len(r.Groupings) == 0 {
+		r.Groupings = p.Groupings
+	}
+	if len(r.Metrics) == 0 {
+		r.Metrics = p.Metrics
+	}
 }
 
-// GenerateReport generates a report
+// generationKey identifies identical generations: same project, branch,
+// format and options against the same analysis
+func (r GenerateRequest) generationKey(analysisKey string) string {
+	// Resolve defaults so explicit and implicit values produce the same key,
+	// and drop fields that do not change the output
+	options := r.generateOptions()
+	r.IncludeCodeSnippets = &options.IncludeCodeSnippets
+	r.IncludeHowToFix = &options.IncludeHowToFix
+	r.MaxSnippetsPerSeverity = options.MaxSnippetsPerSeverity
+	r.ReuseExisting = nil
+
+	raw, _ := json.Marshal(struct {
+		Request     GenerateRequest `json:"request"`
+		AnalysisKey string          `json:"analysisKey"`
+	}{r, analysisKey})
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:16])
+}
+
+// GenerateReport queues a report generation job and returns it immediately.
+// With ?wait=true the request blocks until the report is ready.
 func (h *APIHandler) GenerateReport(c *gin.Context) {
 	var req GenerateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -86,61 +392,473 @@ func (h *APIHandler) GenerateReport(c *gin.Context) {
 		return
 	}
 
+	analysisKey, err := h.prepareGeneration(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	key := req.generationKey(analysisKey)
+
+	reuse := h.reuseExisting
+	if req.ReuseExisting != nil {
+		reuse = *req.ReuseExisting
+	}
+	if reuse && analysisKey != "" {
+		if record, ok := h.storage.FindByGenerationKey(key); ok {
+			c.JSON(http.StatusOK, gin.H{
+				"success": true,
+				"reused":  true,
+				"report":  record,
+			})
+			return
+		}
+	}
+
+	j, deduplicated, err := h.jobs.Submit(key, req.ProjectKey, req.Branch, req.Format, req)
+	if err != nil {
+		if errors.Is(err, job.ErrQueueFull) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("wait") != "true" {
+		c.JSON(http.StatusAccepted, gin.H{
+			"success":      true,
+			"deduplicated": deduplicated,
+			"job":          j,
+		})
+		return
+	}
+
+	j, data, err := h.jobs.Wait(c.Request.Context(), j.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if j.Status != job.StatusSucceeded {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": j.Error, "job": j})
+		return
+	}
+
+	record, err := h.storage.GetRecord(j.ReportID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":      true,
+		"deduplicated": deduplicated,
+		"job":          j,
+		"report":       record,
+		"data":         data,
+	})
+}
+
+// prepareGeneration validates a generation request, pins its defaults and
+// returns the analysis its generation key is based on (empty if unknown)
+func (h *APIHandler) prepareGeneration(req *GenerateRequest) (string, error) {
+	// Pin the profile's options into the request, so that later changes to
+	// the profile do not affect queued jobs
+	if req.Profile != "" {
+		profile, ok := h.profiles.Get(req.Profile)
+		if !ok {
+			return "", fmt.Errorf("unknown profile %q", req.Profile)
+		}
+		req.applyProfile(profile)
+	}
+
 	// Default format
 	if req.Format == "" {
-		req.Format = "md"
+		req.Format = report.FormatMarkdown
 	}
 
 	// Validate format
-	if req.Format != "md" && req.Format != "pdf" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be 'md' or 'pdf'"})
-		return
+	if err := report.ValidateFormat(req.Format); err != nil {
+		return "", err
 	}
 
-	// Set default options
-	options := report.GenerateOptions{
-		IncludeCodeSnippets: true,
-		IncludeHowToFix:     true,
+	if err := report.ValidateSnippetLimit(req.MaxSnippetsPerSeverity); err != nil {
+		return "", err
 	}
-	if req.IncludeCodeSnippets != nil {
-		options.IncludeCodeSnippets = *req.IncludeCodeSnippets
+
+	// Pin the language and time zone; dates in the request are read in the
+	// time zone
+	if req.Language == "" {
+		req.Language = h.language
 	}
-	if req.IncludeHowToFix != nil {
-		options.IncludeHowToFix = *req.IncludeHowToFix
+	if err := report.ValidateLanguage(req.Language); err != nil {
+		return "", err
+	}
+	if req.Timezone == "" {
+		req.Timezone = h.timezone
+	}
+	if err := report.ValidateTimezone(req.Timezone); err != nil {
+		return "", err
+	}
+
+	if req.CompareBranch != "" && req.CompareBranch == req.Branch {
+		return "", errors.New("compareBranch must differ from branch")
+	}
+
+	if req.Policy != "" {
+		if req.CompareBranch != "" || req.Activity != nil {
+			return "", errors.New("policy cannot be combined with compareBranch or activity")
+		}
+		if _, ok := h.policies.Find(req.Policy); !ok {
+			return "", fmt.Errorf("unknown policy %q", req.Policy)
+		}
+	}
+
+	if req.Activity != nil {
+		if req.CompareBranch != "" || req.AsOf != "" {
+			return "", errors.New("activity cannot be combined with compareBranch or asOf")
+		}
+		period, err := req.activityPeriod()
+		if err != nil {
+			return "", err
+		}
+		// Pin named periods to dates so the job and generation key do not
+		// depend on when they are evaluated
+		req.Activity = &ActivityRequest{
+			From: period.From.Format("2006-01-02"),
+			To:   period.To.Format("2006-01-02"),
+		}
+	}
+
+	var baseline *report.Baseline
+	if req.Baseline {
+		if req.CompareBranch != "" || req.Activity != nil {
+			return "", errors.New("baseline cannot be combined with compareBranch or activity")
+		}
+		b, ok := h.baselines.Get(req.ProjectKey, h.baselineBranch(req.ProjectKey, req.Branch))
+		if !ok {
+			return "", errors.New("no baseline for this project branch")
+		}
+		baseline = b
+	}
+
+	// Ownership applies to reports of one branch's open issues
+	var owners *report.CodeOwners
+	if req.CompareBranch == "" && req.Activity == nil {
+		o, err := h.codeOwners.Get(req.ProjectKey)
+		if err != nil {
+			return "", err
+		}
+		owners = o
+	}
+	if req.Team != "" {
+		if req.CompareBranch != "" || req.Activity != nil {
+			return "", errors.New("team cannot be combined with compareBranch or activity")
+		}
+		if owners == nil {
+			return "", errors.New("project has no CODEOWNERS")
+		}
+		if !containsString(owners.Teams(), req.Team) {
+			return "", fmt.Errorf("team %q owns no paths in the project's CODEOWNERS", req.Team)
+		}
+	}
+
+	if len(req.Metrics) > 0 {
+		if req.CompareBranch != "" || req.Activity != nil {
+			return "", errors.New("metrics cannot be combined with compareBranch or activity")
+		}
+		if err := h.generator.Metrics().Validate(req.Metrics); err != nil {
+			return "", err
+		}
+	}
+
+	if len(req.Sections) > 0 || len(req.Severities) > 0 || len(req.Types) > 0 || len(req.Groupings) > 0 {
+		if req.CompareBranch != "" || req.Activity != nil {
+			return "", errors.New("sections, severities, types and groupings cannot be combined with compareBranch or activity")
+		}
+		if err := report.ValidateSelection(req.Sections, req.Severities, req.Types, req.Groupings); err != nil {
+			return "", err
+		}
+	}
+
+	if req.AsOf != "" {
+		if req.CompareBranch != "" {
+			return "", errors.New("asOf cannot be combined with compareBranch")
+		}
+		if at, ok := report.ParseAsOf(req.AsOf, report.LoadTimezone(req.Timezone)); ok && at.After(time.Now()) {
+			return "", errors.New("asOf must not be in the future")
+		}
+	}
+
+	// Pin scoring and effort defaults so the job and generation key do not depend on
+	// the configuration when they run
+	if req.ScoreWeights != nil {
+		if err := req.ScoreWeights.Validate(); err != nil {
+			return "", err
+		}
+	} else {
+		weights := h.scoreWeights
+		req.ScoreWeights = &weights
+	}
+	if req.TopIssues < 0 {
+		return "", errors.New("topIssues must not be negative")
+	}
+	if req.TopIssues == 0 {
+		req.TopIssues = h.topIssues
+	}
+	if req.WorkingDayHours < 0 || req.WorkingDayHours > 24 {
+		return "", errors.New("workingDayHours must be between 1 and 24")
+	}
+	if req.WorkingDayHours == 0 {
+		req.WorkingDayHours = h.dayHours
+	}
+
+	// Identical requests against the same analysis share one generation
+	analysisKey := h.latestAnalysisKey(req.ProjectKey, req.Branch)
+	if req.AsOf != "" {
+		analysisKey = historicalAnalysisKey(req.AsOf)
+	} else if req.CompareBranch != "" {
+		if baseKey := h.latestAnalysisKey(req.ProjectKey, req.CompareBranch); analysisKey != "" && baseKey != "" {
+			analysisKey = comparisonAnalysisKey(baseKey, analysisKey)
+		} else {
+			analysisKey = ""
+		}
+	}
+	if baseline != nil && analysisKey != "" {
+		analysisKey = baselineAnalysisKey(analysisKey, baseline)
+	}
+	if owners != nil && analysisKey != "" {
+		analysisKey = ownersAnalysisKey(analysisKey, owners)
+	}
+	if analysisKey != "" {
+		analysisKey = h.maskingAnalysisKey(req.ProjectKey, analysisKey)
+	}
+	return analysisKey, nil
+}
+
+// latestAnalysisKey returns the key of the latest analysis of a branch, or
+// an empty string if it cannot be determined
+func (h *APIHandler) latestAnalysisKey(projectKey, branch string) string {
+	if analyses, err := h.sonarClient.GetAnalyses(projectKey, branch, 1); err == nil && len(analyses) > 0 {
+		return analyses[0].Key
+	}
+	return ""
+}
+
+// policyFor returns the named policy, or the policy matching the project when
+// no name is given
+func (h *APIHandler) policyFor(name, projectKey string) (*report.Policy, bool) {
+	if name != "" {
+		return h.policies.Find(name)
+	}
+	return h.policies.ForProject(projectKey)
+}
+
+// historicalAnalysisKey stands in for the analysis of historical reports, which
+// do not change when new analyses are made
+func historicalAnalysisKey(asOf string) string {
+	return "asof:" + asOf
+}
+
+// baselineAnalysisKey adds the baseline version to the analysis a report was
+// built from, so that refreshing the baseline changes the generation key
+func baselineAnalysisKey(analysisKey string, baseline *report.Baseline) string {
+	return analysisKey + "@baseline:" + baseline.Version()
+}
+
+// ownersAnalysisKey adds the CODEOWNERS version to the analysis a report was
+// built from, so that changing the owners changes the generation key
+func ownersAnalysisKey(analysisKey string, owners *report.CodeOwners) string {
+	return analysisKey + "@owners:" + owners.Version()
+}
+
+// maskingAnalysisKey adds the versions of the redaction patterns and of the
+// project's suppressions in force to the analysis a report was built from,
+// so that changing either, or a suppression expiring, changes the generation key
+func (h *APIHandler) maskingAnalysisKey(projectKey, analysisKey string) string {
+	return analysisKey + "@redaction:" + h.redactor.Version() + "@suppressions:" + h.suppressions.Version(projectKey, time.Now())
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// comparisonAnalysisKey combines the analyses both sides of a branch comparison were built from
+func comparisonAnalysisKey(base, head string) string {
+	return base + ".." + head
+}
+
+// runGeneration is the job body: it generates, renders and saves a report
+func (h *APIHandler) runGeneration(ctx context.Context, j job.Job, progress report.ProgressFunc) (*report.ReportRecord, *report.ReportData, error) {
+	var req GenerateRequest
+	if err := json.Unmarshal(j.Request, &req); err != nil {
+		return nil, nil, fmt.Errorf("invalid job request: %w", err)
+	}
+
+	options := req.generateOptions()
+	options.Progress = progress
+	options.Suppressions = h.suppressions
+	options.Redactor = h.redactor
+
+	if req.CompareBranch != "" {
+		return h.runComparison(ctx, req, options)
+	}
+
+	if req.Baseline {
+		baseline, ok := h.baselines.Get(req.ProjectKey, h.baselineBranch(req.ProjectKey, req.Branch))
+		if !ok {
+			return nil, nil, fmt.Errorf("baseline of %s was deleted", req.ProjectKey)
+		}
+		options.Baseline = baseline
+	}
+
+	if req.Activity == nil {
+		owners, err := h.codeOwners.Get(req.ProjectKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		if owners == nil && req.Team != "" {
+			return nil, nil, fmt.Errorf("CODEOWNERS of %s was deleted", req.ProjectKey)
+		}
+		options.CodeOwners = owners
+		options.Team = req.Team
+
+		// Activity reports carry no metrics or open issues to check
+		if policy, ok := h.policyFor(req.Policy, req.ProjectKey); ok {
+			options.Policy = policy
+		}
 	}
 
 	// Generate report data
-	data, err := h.generator.Generate(req.ProjectKey, req.Branch, options)
+	data, err := h.generator.GenerateContext(ctx, req.ProjectKey, req.Branch, options)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		return nil, nil, err
 	}
 
 	// Generate content based on format
-	var content []byte
-	switch req.Format {
-	case "pdf":
-		content, err = h.pdfGen.Generate(data)
-	default:
-		content, err = h.mdGen.Generate(data)
+	progress.ReportMessage(report.PhaseRendering, "rendering "+strings.ToUpper(req.Format))
+	content, err := render(data, req.Format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Do not save a report nobody is waiting for anymore
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
 	}
 
+	// Save report, keyed on the analysis it was actually built from
+	analysisKey := data.AnalysisKey
+	if req.AsOf != "" {
+		analysisKey = historicalAnalysisKey(req.AsOf)
+	}
+	if options.Baseline != nil {
+		analysisKey = baselineAnalysisKey(analysisKey, options.Baseline)
+	}
+	if options.CodeOwners != nil {
+		analysisKey = ownersAnalysisKey(analysisKey, options.CodeOwners)
+	}
+	analysisKey = h.maskingAnalysisKey(req.ProjectKey, analysisKey)
+	progress.Report(report.PhaseSaving, 0, 0)
+	record, err := h.storage.Save(data, content, req.Format, report.SaveOptions{
+		GenerationKey: req.generationKey(analysisKey),
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, nil, err
+	}
+
+	return record, data, nil
+}
+
+// runComparison generates both branches of a comparison report in parallel,
+// renders the side-by-side report and saves it with both data snapshots
+func (h *APIHandler) runComparison(ctx context.Context, req GenerateRequest, options report.GenerateOptions) (*report.ReportRecord, *report.ReportData, error) {
+	base, head, err := h.generator.GenerateBranches(ctx, req.ProjectKey, req.CompareBranch, req.Branch, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	options.Progress.ReportMessage(report.PhaseRendering, "rendering "+strings.ToUpper(req.Format)+" comparison")
+	content, err := renderDiff(report.CompareBranches(base, head), req.Format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	options.Progress.Report(report.PhaseSaving, 0, 0)
+	record, err := h.storage.Save(head, content, req.Format, report.SaveOptions{
+		GenerationKey: req.generationKey(h.maskingAnalysisKey(req.ProjectKey, comparisonAnalysisKey(base.AnalysisKey, head.AnalysisKey))),
+		CompareWith:   base,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return record, head, nil
+}
+
+// renderDiff renders a comparison in the given format
+func renderDiff(diff *report.ReportDiff, format string) ([]byte, error) {
+	renderer, ok := report.RendererFor(format)
+	if !ok {
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	return renderer.GenerateDiff(diff)
+}
+
+// render renders report data in the given format
+func render(data *report.ReportData, format string) ([]byte, error) {
+	renderer, ok := report.RendererFor(format)
+	if !ok {
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	return renderer.Generate(data)
+}
+
+// StreamProgress streams the progress of a report job as Server-Sent Events
+func (h *APIHandler) StreamProgress(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "job id is required"})
 		return
 	}
 
-	// Save report
-	record, err := h.storage.Save(data, content, req.Format)
+	j, err := h.jobs.Get(id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"report":  record,
-		"data":    data,
+	// Disable proxy buffering so events arrive immediately
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	// The stream of a finished job may be cleaned up already, so its final
+	// state comes from the job itself
+	if j.Status.IsFinished() {
+		c.SSEvent("progress", finalProgress(j))
+		return
+	}
+
+	// A job finishing from here on leaves its final update in the stream
+	updates, unsubscribe := h.progress.Subscribe(id)
+	defer unsubscribe()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case p := <-updates:
+			c.SSEvent("progress", p)
+			return !p.IsFinal()
+		}
 	})
 }
 
@@ -165,12 +883,9 @@ func (h *APIHandler) DownloadReport(c *gin.Context) {
 	}
 
 	// Determine content type
-	var contentType string
-	switch record.Format {
-	case "pdf":
-		contentType = "application/pdf"
-	default:
-		contentType = "text/markdown"
+	contentType := "application/octet-stream"
+	if renderer, ok := report.RendererFor(record.Format); ok {
+		contentType = renderer.Format().ContentType
 	}
 
 	c.Header("Content-Disposition", "attachment; filename="+record.FileName)
@@ -192,8 +907,8 @@ func (h *APIHandler) PreviewReport(c *gin.Context) {
 		return
 	}
 
-	// For markdown, read and return content
-	if record.Format == "md" {
+	// For previewable formats, read and return content
+	if isPreviewable(record) {
 		content, err := filepath.Abs(record.FilePath)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -203,11 +918,21 @@ func (h *APIHandler) PreviewReport(c *gin.Context) {
 		return
 	}
 
-	// For PDF, just return metadata
-	c.JSON(http.StatusOK, gin.H{
-		"record":  record,
-		"message": "PDF preview not supported, please download",
-	})
+	// Other formats are previewed as markdown rendered from the stored data
+	if record.SnapshotPath == "" {
+		c.JSON(http.StatusOK, gin.H{
+			"record":  record,
+			"message": strings.ToUpper(record.Format) + " preview not supported, please download",
+		})
+		return
+	}
+
+	content, err := previewMarkdown(h.storage, record)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", content)
 }
 
 // DeleteReport deletes a report
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"sonarqube-report-generator/internal/job"
+	"sonarqube-report-generator/internal/report"
+)
+
+// ListJobs returns all report generation jobs
+func (h *APIHandler) ListJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"jobs": h.jobs.List()})
+}
+
+// GetJob returns the status of a report generation job
+func (h *APIHandler) GetJob(c *gin.Context) {
+	j, err := h.jobs.Get(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": j})
+}
+
+// finalProgress is the last progress update of a finished job
+func finalProgress(j *job.Job) report.Progress {
+	if j.Progress != nil && j.Progress.IsFinal() {
+		return *j.Progress
+	}
+	switch j.Status {
+	case job.StatusSucceeded:
+		return report.NewProgress(report.PhaseDone, 0, 0)
+	case job.StatusCancelled:
+		return report.NewProgress(report.PhaseCancelled, 0, 0)
+	default:
+		return report.Progress{Phase: report.PhaseFailed, Message: j.Error}
+	}
+}
+
+// CancelJob cancels a queued or running job
+func (h *APIHandler) CancelJob(c *gin.Context) {
+	err := h.jobs.Cancel(c.Param("id"))
+	switch {
+	case errors.Is(err, job.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, job.ErrFinished):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// GetJobReport returns the report record produced by a finished job
+func (h *APIHandler) GetJobReport(c *gin.Context) {
+	j, err := h.jobs.Get(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if j.Status != job.StatusSucceeded {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "job has not produced a report",
+			"job":   j,
+		})
+		return
+	}
+
+	record, err := h.storage.GetRecord(j.ReportID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"report": record})
+}
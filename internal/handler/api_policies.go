@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListPolicies returns the compliance policies loaded from the policy file
+func (h *APIHandler) ListPolicies(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"policies": h.policies.Policies})
+}
+
+// ListSuppressions returns the suppression list and whether each entry has
+// expired; ?project= keeps the entries applying to one project
+func (h *APIHandler) ListSuppressions(c *gin.Context) {
+	now := time.Now()
+	project := c.Query("project")
+	suppressions := make([]gin.H, 0, len(h.suppressions.Suppressions))
+	for i := range h.suppressions.Suppressions {
+		s := &h.suppressions.Suppressions[i]
+		if project != "" && !s.AppliesTo(project) {
+			continue
+		}
+		suppressions = append(suppressions, gin.H{"suppression": s, "expired": s.Expired(now)})
+	}
+	c.JSON(http.StatusOK, gin.H{"suppressions": suppressions})
+}
+
+// EvaluateReportPolicy evaluates a stored report against a compliance policy:
+// the one named by ?name, or the policy matching the report's project
+func (h *APIHandler) EvaluateReportPolicy(c *gin.Context) {
+	snapshot, ok := h.loadSnapshot(c)
+	if !ok {
+		return
+	}
+
+	if snapshot.Data.Activity != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "activity reports cannot be evaluated against a policy"})
+		return
+	}
+
+	name := c.Query("name")
+	policy, ok := h.policyFor(name, snapshot.Data.ProjectKey)
+	if !ok {
+		if name != "" {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("unknown policy %q", name)})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "no policy applies to project " + snapshot.Data.ProjectKey})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"policy": policy.Evaluate(snapshot.Data)})
+}
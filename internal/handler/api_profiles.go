@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"sonarqube-report-generator/internal/report"
+)
+
+// ListProfiles returns the built-in and saved report profiles
+func (h *APIHandler) ListProfiles(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"profiles": h.profiles.List()})
+}
+
+// GetProfile returns a report profile
+func (h *APIHandler) GetProfile(c *gin.Context) {
+	profile, ok := h.profiles.Get(c.Param("name"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "profile not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"profile": profile})
+}
+
+// CreateProfile saves a new report profile
+func (h *APIHandler) CreateProfile(c *gin.Context) {
+	var profile report.ReportProfile
+	if err := c.ShouldBindJSON(&profile); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok := h.profiles.Get(profile.Name); ok {
+		c.JSON(http.StatusConflict, gin.H{"error": "profile already exists, update it instead"})
+		return
+	}
+
+	h.saveProfile(c, &profile, http.StatusCreated)
+}
+
+// UpdateProfile replaces a saved report profile
+func (h *APIHandler) UpdateProfile(c *gin.Context) {
+	var profile report.ReportProfile
+	if err := c.ShouldBindJSON(&profile); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	profile.Name = c.Param("name")
+
+	existing, ok := h.profiles.Get(profile.Name)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "profile not found"})
+		return
+	}
+	if existing.BuiltIn {
+		c.JSON(http.StatusForbidden, gin.H{"error": "built-in profiles cannot be changed"})
+		return
+	}
+
+	h.saveProfile(c, &profile, http.StatusOK)
+}
+
+// DeleteProfile deletes a saved report profile
+func (h *APIHandler) DeleteProfile(c *gin.Context) {
+	existing, ok := h.profiles.Get(c.Param("name"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "profile not found"})
+		return
+	}
+	if existing.BuiltIn {
+		c.JSON(http.StatusForbidden, gin.H{"error": "built-in profiles cannot be deleted"})
+		return
+	}
+
+	if err := h.profiles.Delete(existing.Name); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// saveProfile validates and stores a profile, including its metrics against
+// those of the server
+func (h *APIHandler) saveProfile(c *gin.Context, profile *report.ReportProfile, status int) {
+	if err := profile.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(profile.Metrics) > 0 {
+		if err := h.generator.Metrics().Validate(profile.Metrics); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := h.profiles.Put(profile); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, gin.H{"success": true, "profile": profile})
+}
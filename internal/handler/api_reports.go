@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"sonarqube-report-generator/internal/report"
+)
+
+// GetReportData returns the stored data snapshot a report was rendered from
+func (h *APIHandler) GetReportData(c *gin.Context) {
+	snapshot, ok := h.loadSnapshot(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// GetReportIssues returns issues from a stored report, filtered by the
+// severity, type, rule and component query parameters
+func (h *APIHandler) GetReportIssues(c *gin.Context) {
+	snapshot, ok := h.loadSnapshot(c)
+	if !ok {
+		return
+	}
+
+	issues := snapshot.Data.FilterIssues(report.IssueFilter{
+		Severity:  c.Query("severity"),
+		Type:      c.Query("type"),
+		Rule:      c.Query("rule"),
+		Component: c.Query("component"),
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"reportId": snapshot.ReportID,
+		"total":    len(issues),
+		"issues":   issues,
+	})
+}
+
+// CompareReports compares two stored reports of the same project. The base
+// and head query parameters are report IDs; format selects json (default)
+// or a report format for a rendered delta report, and language and timezone
+// the language and time zone it is rendered in (default: those of the head
+// report).
+func (h *APIHandler) CompareReports(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	renderer, ok := report.RendererFor(format)
+	if !ok && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or one of " + strings.Join(report.FormatNames(), ", ")})
+		return
+	}
+
+	language := c.Query("language")
+	if language != "" {
+		if err := report.ValidateLanguage(language); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	timezone := c.Query("timezone")
+	if timezone != "" {
+		if err := report.ValidateTimezone(timezone); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	baseID, headID := c.Query("base"), c.Query("head")
+	if baseID == "" || headID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "base and head report ids are required"})
+		return
+	}
+
+	base, ok := h.loadSnapshotByID(c, baseID)
+	if !ok {
+		return
+	}
+	head, ok := h.loadSnapshotByID(c, headID)
+	if !ok {
+		return
+	}
+
+	if base.Data.ProjectKey != head.Data.ProjectKey {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reports belong to different projects"})
+		return
+	}
+	if base.Data.Activity != nil || head.Data.Activity != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "activity reports cannot be compared"})
+		return
+	}
+
+	diff := report.CompareReports(base, head)
+	if language != "" {
+		diff.Language = language
+	}
+	if timezone != "" {
+		diff.Timezone = timezone
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, diff)
+		return
+	}
+
+	content, err := renderer.GenerateDiff(diff)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to render delta report: %v", err)})
+		return
+	}
+
+	fileName := fmt.Sprintf("%s-delta-%s-%s.%s", diff.ProjectKey, baseID, headID, renderer.Format().Extension)
+	c.Header("Content-Disposition", "attachment; filename="+fileName)
+	c.Data(http.StatusOK, renderer.Format().ContentType, content)
+}
+
+// RerenderRequest is the body of a re-render request
+type RerenderRequest struct {
+	Format   string `json:"format" binding:"required"` // see GET /api/v1/formats
+	Language string `json:"language,omitempty"`        // default: that of the stored report
+	Timezone string `json:"timezone,omitempty"`        // default: that of the stored report
+}
+
+// RerenderReport renders a stored report into another format from its data
+// snapshot, without fetching anything from SonarQube. The new report records
+// the original as its source.
+func (h *APIHandler) RerenderReport(c *gin.Context) {
+	var req RerenderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := report.ValidateFormat(req.Format); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Language != "" {
+		if err := report.ValidateLanguage(req.Language); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if req.Timezone != "" {
+		if err := report.ValidateTimezone(req.Timezone); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	snapshot, ok := h.loadSnapshot(c)
+	if !ok {
+		return
+	}
+	if req.Language != "" {
+		snapshot.Data.Language = req.Language
+	}
+	if req.Timezone != "" {
+		snapshot.Data.Timezone = req.Timezone
+	}
+
+	opts := report.SaveOptions{SourceID: snapshot.ReportID}
+
+	// Branch comparisons are rendered again from both sides
+	var base *report.Snapshot
+	if source, err := h.storage.GetRecord(snapshot.ReportID); err == nil && source.Kind == report.ReportKindComparison {
+		if base, err = h.storage.LoadBaseSnapshot(source.ID); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		opts.CompareWith = base.Data
+	}
+
+	var content []byte
+	var err error
+	if base != nil {
+		content, err = renderDiff(report.CompareBranches(base.Data, snapshot.Data), req.Format)
+	} else {
+		content, err = render(snapshot.Data, req.Format)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to render report: %v", err)})
+		return
+	}
+
+	record, err := h.storage.Save(snapshot.Data, content, req.Format, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"report":  record,
+	})
+}
+
+// loadSnapshot loads the snapshot of the report in the id parameter,
+// writing an error response when it is not available
+func (h *APIHandler) loadSnapshot(c *gin.Context) (*report.Snapshot, bool) {
+	return h.loadSnapshotByID(c, c.Param("id"))
+}
+
+// loadSnapshotByID loads the snapshot of a report, writing an error response
+// when it is not available
+func (h *APIHandler) loadSnapshotByID(c *gin.Context, id string) (*report.Snapshot, bool) {
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "report id is required"})
+		return nil, false
+	}
+
+	if _, err := h.storage.GetRecord(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	snapshot, err := h.storage.LoadSnapshot(id)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	return snapshot, true
+}
+
+// isPreviewable reports whether the file of a report can be previewed as it is
+func isPreviewable(record *report.ReportRecord) bool {
+	renderer, ok := report.RendererFor(record.Format)
+	return ok && renderer.Format().Previewable
+}
+
+// canPreview reports whether a report can be previewed, from its file or
+// from its data snapshot
+func canPreview(record *report.ReportRecord) bool {
+	return isPreviewable(record) || record.SnapshotPath != ""
+}
+
+// previewMarkdown returns markdown for previewing a stored report. Reports in
+// other formats are rendered from their data snapshot.
+func previewMarkdown(storage *report.Storage, record *report.ReportRecord) ([]byte, error) {
+	if isPreviewable(record) {
+		return os.ReadFile(record.FilePath)
+	}
+
+	snapshot, err := storage.LoadSnapshot(record.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.Kind == report.ReportKindComparison {
+		base, err := storage.LoadBaseSnapshot(record.ID)
+		if err != nil {
+			return nil, err
+		}
+		return renderDiff(report.CompareBranches(base.Data, snapshot.Data), report.FormatMarkdown)
+	}
+
+	return render(snapshot.Data, report.FormatMarkdown)
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"sync"
+	"time"
+
+	"sonarqube-report-generator/internal/report"
+)
+
+// progressRetention is how long a finished stream keeps its last event for late subscribers
+const progressRetention = 5 * time.Minute
+
+// ProgressHub fans out generation progress to Server-Sent Events subscribers
+type ProgressHub struct {
+	mu      sync.Mutex
+	streams map[string]*progressStream
+}
+
+// progressStream holds the latest update and subscribers of one generation
+type progressStream struct {
+	last        *report.Progress
+	sides       map[string]report.Progress // latest update per side of a comparison
+	updatedAt   time.Time
+	finished    bool
+	finishedAt  time.Time
+	subscribers map[chan report.Progress]struct{}
+}
+
+// NewProgressHub creates a new progress hub
+func NewProgressHub() *ProgressHub {
+	hub := &ProgressHub{
+		streams: make(map[string]*progressStream),
+	}
+
+	// Start cleanup goroutine
+	go hub.cleanup()
+
+	return hub
+}
+
+// Reporter returns a progress callback publishing to the given stream ID.
+// An empty ID returns nil so generation runs without progress reporting.
+func (h *ProgressHub) Reporter(id string) report.ProgressFunc {
+	if id == "" {
+		return nil
+	}
+	return func(p report.Progress) {
+		h.Publish(id, p)
+	}
+}
+
+// Publish sends a progress update to all subscribers of a stream
+func (h *ProgressHub) Publish(id string, p report.Progress) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stream := h.stream(id)
+	if stream.finished {
+		return
+	}
+
+	// Workers report concurrently, so never move backwards within a phase.
+	// The sides of a comparison progress independently of each other.
+	if prev, ok := stream.sides[p.Side]; ok && prev.Phase == p.Phase && p.Done < prev.Done {
+		return
+	}
+	stream.sides[p.Side] = p
+	stream.last = &p
+	stream.updatedAt = time.Now()
+
+	if p.IsFinal() {
+		stream.finished = true
+		stream.finishedAt = time.Now()
+	}
+
+	for ch := range stream.subscribers {
+		// Slow subscribers skip stale updates rather than block the generator,
+		// so the latest (and final) update always gets through
+		select {
+		case ch <- p:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- p
+		}
+	}
+}
+
+// Subscribe returns a channel of progress updates for a stream and an
+// unsubscribe function. The latest update, if any, is delivered first.
+func (h *ProgressHub) Subscribe(id string) (<-chan report.Progress, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stream := h.stream(id)
+	ch := make(chan report.Progress, 16)
+	if stream.last != nil {
+		ch <- *stream.last
+	}
+	stream.subscribers[ch] = struct{}{}
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(stream.subscribers, ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// stream returns the stream for an ID, creating it if needed. Caller holds mu.
+func (h *ProgressHub) stream(id string) *progressStream {
+	stream, ok := h.streams[id]
+	if !ok {
+		stream = &progressStream{
+			sides:       make(map[string]report.Progress),
+			updatedAt:   time.Now(),
+			subscribers: make(map[chan report.Progress]struct{}),
+		}
+		h.streams[id] = stream
+	}
+	return stream
+}
+
+// cleanup removes finished streams and abandoned streams periodically
+func (h *ProgressHub) cleanup() {
+	ticker := time.NewTicker(1 * time.Minute)
+	for range ticker.C {
+		h.mu.Lock()
+		now := time.Now()
+		for id, stream := range h.streams {
+			if len(stream.subscribers) > 0 {
+				continue
+			}
+			if stream.finished && now.Sub(stream.finishedAt) > progressRetention {
+				delete(h.streams, id)
+			} else if !stream.finished && now.Sub(stream.updatedAt) > progressRetention {
+				delete(h.streams, id)
+			}
+		}
+		h.mu.Unlock()
+	}
+}
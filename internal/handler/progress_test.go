@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"testing"
+
+	"sonarqube-report-generator/internal/report"
+)
+
+func TestProgressHubPublish(t *testing.T) {
+	side := func(name, phase string, done int) report.Progress {
+		p := report.NewProgress(phase, done, 10)
+		p.Side = name
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		updates []report.Progress
+		want    []int // Done of the updates delivered, in order
+	}{
+		{
+			name: "forwards within a phase",
+			updates: []report.Progress{
+				report.NewProgress(report.PhaseSnippets, 1, 10),
+				report.NewProgress(report.PhaseSnippets, 2, 10),
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "drops backwards within a phase",
+			updates: []report.Progress{
+				report.NewProgress(report.PhaseSnippets, 3, 10),
+				report.NewProgress(report.PhaseSnippets, 2, 10),
+				report.NewProgress(report.PhaseSnippets, 4, 10),
+			},
+			want: []int{3, 4},
+		},
+		{
+			name: "new phase starts over",
+			updates: []report.Progress{
+				report.NewProgress(report.PhaseIssues, 8, 10),
+				report.NewProgress(report.PhaseSnippets, 1, 10),
+			},
+			want: []int{8, 1},
+		},
+		{
+			name: "sides progress independently",
+			updates: []report.Progress{
+				side("base", report.PhaseSnippets, 5),
+				side("head", report.PhaseSnippets, 1),
+				side("base", report.PhaseSnippets, 6),
+				side("head", report.PhaseSnippets, 2),
+				side("head", report.PhaseSnippets, 1),
+			},
+			want: []int{5, 1, 6, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := &ProgressHub{streams: make(map[string]*progressStream)}
+			updates, unsubscribe := hub.Subscribe("job")
+			defer unsubscribe()
+
+			for _, p := range tt.updates {
+				hub.Publish("job", p)
+			}
+
+			var got []int
+			for len(updates) > 0 {
+				got = append(got, (<-updates).Done)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("delivered %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("delivered %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestProgressHubFinished(t *testing.T) {
+	hub := &ProgressHub{streams: make(map[string]*progressStream)}
+	hub.Publish("job", report.NewProgress(report.PhaseDone, 0, 0))
+	hub.Publish("job", report.NewProgress(report.PhaseSnippets, 1, 10))
+
+	// Late subscribers get the final update and nothing after it
+	updates, unsubscribe := hub.Subscribe("job")
+	defer unsubscribe()
+	if p := <-updates; p.Phase != report.PhaseDone {
+		t.Fatalf("first update = %s, want %s", p.Phase, report.PhaseDone)
+	}
+	if len(updates) != 0 {
+		t.Fatalf("%d updates after the final one", len(updates))
+	}
+}
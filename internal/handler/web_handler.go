@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"html/template"
 	"net/http"
-	"os"
 
 	"github.com/gin-gonic/gin"
 
@@ -118,19 +117,19 @@ func (h *WebHandler) PreviewReportPage(c *gin.Context) {
 		return
 	}
 
-	// Only support markdown preview
-	if record.Format != "md" {
+	// Reports in other formats are previewed from their stored data
+	if !canPreview(record) {
 		c.HTML(http.StatusBadRequest, "preview.html", gin.H{
-			"error": "Preview is only available for Markdown reports. Please download the PDF instead.",
+			"error": "Preview is not available for this report. Please download it instead.",
 		})
 		return
 	}
 
 	// Read markdown content
-	content, err := os.ReadFile(record.FilePath)
+	content, err := previewMarkdown(h.storage, record)
 	if err != nil {
 		c.HTML(http.StatusInternalServerError, "preview.html", gin.H{
-			"error": "Failed to read report file: " + err.Error(),
+			"error": "Failed to read report: " + err.Error(),
 		})
 		return
 	}
@@ -147,8 +146,8 @@ func (h *WebHandler) PreviewReportPage(c *gin.Context) {
 		return
 	}
 
-	// Format generated date
-	generatedAt := record.GeneratedAt.Format("Jan 2, 2006 at 3:04 PM")
+	// Format the generation time as the report does
+	generatedAt := report.NewLocale(record.Language, record.Timezone).Time(record.GeneratedAt)
 
 	// Render preview template
 	// markdownJSON is already a JSON string (with quotes), so we use it directly
@@ -181,19 +180,19 @@ func (h *WebHandler) ShareReportPage(c *gin.Context) {
 		return
 	}
 
-	// Only support markdown preview
-	if record.Format != "md" {
+	// Reports in other formats are previewed from their stored data
+	if !canPreview(record) {
 		c.HTML(http.StatusBadRequest, "share.html", gin.H{
-			"error": "Share is only available for Markdown reports. Please download the PDF instead.",
+			"error": "Share is not available for this report. Please download it instead.",
 		})
 		return
 	}
 
 	// Read markdown content
-	content, err := os.ReadFile(record.FilePath)
+	content, err := previewMarkdown(h.storage, record)
 	if err != nil {
 		c.HTML(http.StatusInternalServerError, "share.html", gin.H{
-			"error": "Failed to read report file: " + err.Error(),
+			"error": "Failed to read report: " + err.Error(),
 		})
 		return
 	}
@@ -210,8 +209,8 @@ func (h *WebHandler) ShareReportPage(c *gin.Context) {
 		return
 	}
 
-	// Format generated date
-	generatedAt := record.GeneratedAt.Format("Jan 2, 2006 at 3:04 PM")
+	// Format the generation time as the report does
+	generatedAt := report.NewLocale(record.Language, record.Timezone).Time(record.GeneratedAt)
 
 	// Render share template
 	c.HTML(http.StatusOK, "share.html", gin.H{
@@ -0,0 +1,538 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sonarqube-report-generator/internal/report"
+)
+
+const (
+	jobsFileName = "jobs.json"
+
+	// maxFinishedJobs bounds how many finished jobs are kept in history
+	maxFinishedJobs = 200
+)
+
+var (
+	// ErrQueueFull is returned when no more jobs can be queued
+	ErrQueueFull = errors.New("job queue is full, try again later")
+	// ErrNotFound is returned for unknown job IDs
+	ErrNotFound = errors.New("job not found")
+	// ErrFinished is returned when cancelling a job that already finished
+	ErrFinished = errors.New("job already finished")
+)
+
+// RunFunc performs the work of a job and returns the saved report with its data
+type RunFunc func(ctx context.Context, job Job, progress report.ProgressFunc) (*report.ReportRecord, *report.ReportData, error)
+
+// ReporterFunc returns an additional progress callback for a job, e.g. to
+// publish updates to live subscribers. It may return nil.
+type ReporterFunc func(jobID string) report.ProgressFunc
+
+// Manager queues report generation jobs and runs them on a bounded worker pool
+type Manager struct {
+	filePath string
+	workers  int
+	run      RunFunc
+	reporter ReporterFunc
+
+	queue chan string
+	saves chan struct{} // signals the saver that jobs changed
+
+	mu      sync.Mutex
+	jobs    map[string]*Job
+	cancels map[string]context.CancelFunc
+	done    map[string]chan struct{}
+	results map[string]*report.ReportData
+}
+
+// NewManager creates a job manager persisting its jobs under storagePath.
+// Jobs that were queued or running when the server stopped are queued again.
+func NewManager(storagePath string, workers, queueSize int, run RunFunc, reporter ReporterFunc) (*Manager, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 1 {
+		queueSize = 1
+	}
+
+	m := &Manager{
+		filePath: filepath.Join(storagePath, jobsFileName),
+		workers:  workers,
+		run:      run,
+		reporter: reporter,
+		queue:    make(chan string, queueSize),
+		saves:    make(chan struct{}, 1),
+		jobs:     make(map[string]*Job),
+		cancels:  make(map[string]context.CancelFunc),
+		done:     make(map[string]chan struct{}),
+		results:  make(map[string]*report.ReportData),
+	}
+
+	if err := m.load(); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to load jobs: %w", err)
+	}
+
+	go m.saver()
+
+	return m, nil
+}
+
+// Start launches the workers and requeues jobs restored from disk
+func (m *Manager) Start() {
+	for w := 0; w < m.workers; w++ {
+		go m.worker()
+	}
+
+	m.mu.Lock()
+	var pending []*Job
+	for _, j := range m.jobs {
+		if !j.Status.IsFinished() {
+			j.Status = StatusQueued
+			j.StartedAt = nil
+			j.Progress = progressPtr(report.NewProgress(report.PhaseQueued, 0, 0))
+			m.done[j.ID] = make(chan struct{})
+			pending = append(pending, j)
+		}
+	}
+	sort.Slice(pending, func(i, k int) bool {
+		return pending[i].CreatedAt.Before(pending[k].CreatedAt)
+	})
+	m.mu.Unlock()
+
+	if len(pending) > 0 {
+		log.Printf("Requeueing %d report jobs", len(pending))
+		// Restored jobs may exceed the queue size, so feed them in the background
+		go func() {
+			for _, j := range pending {
+				m.queue <- j.ID
+			}
+		}()
+	}
+}
+
+// Submit queues a new job. request is stored so the job can be replayed after a restart.
+// If a queued or running job has the same non-empty key, that job is returned
+// instead and the second return value is true.
+func (m *Manager) Submit(key, projectKey, branch, format string, request interface{}) (*Job, bool, error) {
+	submitted, err := m.SubmitAll([]Submission{{Key: key, ProjectKey: projectKey, Branch: branch, Format: format, Request: request}})
+	if err != nil {
+		return nil, false, err
+	}
+	return submitted[0].Job, submitted[0].Deduplicated, nil
+}
+
+// Submission is a job to queue with SubmitAll
+type Submission struct {
+	Key        string
+	ProjectKey string
+	Branch     string
+	Format     string
+	Request    interface{}
+}
+
+// Submitted is the job a submission was queued as, or coalesced with
+type Submitted struct {
+	Job          *Job
+	Deduplicated bool
+}
+
+// SubmitAll queues several jobs at once, like Submit. Either all of them are
+// queued or, when the queue has no room for all, none is and ErrQueueFull is
+// returned.
+func (m *Manager) SubmitAll(submissions []Submission) ([]Submitted, error) {
+	raws := make([][]byte, len(submissions))
+	for i, sub := range submissions {
+		raw, err := json.Marshal(sub.Request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode job request: %w", err)
+		}
+		raws[i] = raw
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Coalesce with identical generations already in flight or earlier in
+	// the batch, and count the jobs that need room in the queue
+	batchKeys := make(map[string]bool)
+	needed := 0
+	for _, sub := range submissions {
+		if sub.Key != "" {
+			if batchKeys[sub.Key] || m.inFlightLocked(sub.Key) != nil {
+				continue
+			}
+			batchKeys[sub.Key] = true
+		}
+		needed++
+	}
+	if needed > cap(m.queue)-len(m.queue) {
+		return nil, ErrQueueFull
+	}
+
+	submitted := make([]Submitted, len(submissions))
+	for i, sub := range submissions {
+		if sub.Key != "" {
+			if j := m.inFlightLocked(sub.Key); j != nil {
+				copied := *j
+				submitted[i] = Submitted{Job: &copied, Deduplicated: true}
+				continue
+			}
+		}
+
+		j := &Job{
+			ID:         uuid.New().String()[:8],
+			Status:     StatusQueued,
+			ProjectKey: sub.ProjectKey,
+			Branch:     sub.Branch,
+			Format:     sub.Format,
+			Request:    raws[i],
+			Key:        sub.Key,
+			Progress:   progressPtr(report.NewProgress(report.PhaseQueued, 0, 0)),
+			CreatedAt:  time.Now(),
+		}
+		m.jobs[j.ID] = j
+		m.done[j.ID] = make(chan struct{})
+
+		select {
+		case m.queue <- j.ID:
+		default:
+			// Restored jobs fed in the background took the room counted
+			// above; the job is accepted and waits for a slot
+			go func(id string) { m.queue <- id }(j.ID)
+		}
+
+		copied := *j
+		submitted[i] = Submitted{Job: &copied}
+	}
+	m.saveLocked()
+
+	return submitted, nil
+}
+
+// inFlightLocked returns the queued or running job with key, nil if none
+func (m *Manager) inFlightLocked(key string) *Job {
+	for _, j := range m.jobs {
+		if j.Key == key && !j.Status.IsFinished() {
+			return j
+		}
+	}
+	return nil
+}
+
+// Get returns a copy of a job
+func (m *Manager) Get(id string) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	copied := *j
+	return &copied, nil
+}
+
+// List returns all jobs, newest first
+func (m *Manager) List() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]Job, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		result = append(result, *j)
+	}
+	sort.Slice(result, func(i, k int) bool {
+		return result[i].CreatedAt.After(result[k].CreatedAt)
+	})
+	return result
+}
+
+// Cancel stops a queued or running job
+func (m *Manager) Cancel(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if j.Status.IsFinished() {
+		return ErrFinished
+	}
+
+	if cancel, running := m.cancels[id]; running {
+		// The worker records the final state once the run returns
+		cancel()
+		return nil
+	}
+
+	// Still queued: the worker skips it when dequeued
+	m.finishLocked(j, StatusCancelled, nil, nil)
+	return nil
+}
+
+// Wait blocks until a job finishes or ctx is done. The report data is only
+// available for jobs that finished since the server started.
+func (m *Manager) Wait(ctx context.Context, id string) (*Job, *report.ReportData, error) {
+	m.mu.Lock()
+	done, ok := m.done[id]
+	_, exists := m.jobs[id]
+	m.mu.Unlock()
+
+	if !exists {
+		return nil, nil, ErrNotFound
+	}
+
+	if ok {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *m.jobs[id]
+	return &copied, m.results[id], nil
+}
+
+// worker runs queued jobs until the process exits
+func (m *Manager) worker() {
+	for id := range m.queue {
+		m.runJob(id)
+	}
+}
+
+// runJob executes a single job and records its outcome
+func (m *Manager) runJob(id string) {
+	m.mu.Lock()
+	j, ok := m.jobs[id]
+	if !ok || j.Status != StatusQueued {
+		// Cancelled while waiting in the queue
+		m.mu.Unlock()
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	now := time.Now()
+	j.Status = StatusRunning
+	j.StartedAt = &now
+	m.cancels[id] = cancel
+	m.saveLocked()
+	snapshot := *j
+	m.mu.Unlock()
+
+	record, data, err := m.runSafely(ctx, snapshot, m.progressFunc(id))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.cancels, id)
+
+	switch {
+	case ctx.Err() != nil:
+		m.finishLocked(j, StatusCancelled, nil, nil)
+	case err != nil:
+		m.finishLocked(j, StatusFailed, err, nil)
+	default:
+		j.ReportID = record.ID
+		m.finishLocked(j, StatusSucceeded, nil, data)
+	}
+}
+
+// runSafely runs a job, turning a panic into an error so that it fails the
+// job rather than the server
+func (m *Manager) runSafely(ctx context.Context, j Job, progress report.ProgressFunc) (record *report.ReportRecord, data *report.ReportData, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Report job %s panicked: %v\n%s", j.ID, r, debug.Stack())
+			record, data, err = nil, nil, fmt.Errorf("report generation failed unexpectedly: %v", r)
+		}
+	}()
+	return m.run(ctx, j, progress)
+}
+
+// progressFunc records progress on the job and forwards it to the reporter
+func (m *Manager) progressFunc(id string) report.ProgressFunc {
+	var forward report.ProgressFunc
+	if m.reporter != nil {
+		forward = m.reporter(id)
+	}
+
+	return func(p report.Progress) {
+		m.mu.Lock()
+		if j, ok := m.jobs[id]; ok {
+			j.Progress = progressPtr(p)
+		}
+		m.mu.Unlock()
+
+		if forward != nil {
+			forward(p)
+		}
+	}
+}
+
+// finishLocked moves a job to a terminal state. Caller holds mu.
+func (m *Manager) finishLocked(j *Job, status Status, err error, data *report.ReportData) {
+	now := time.Now()
+	j.Status = status
+	j.FinishedAt = &now
+
+	var final report.Progress
+	switch status {
+	case StatusSucceeded:
+		final = report.NewProgress(report.PhaseDone, 0, 0)
+		m.results[j.ID] = data
+	case StatusCancelled:
+		final = report.NewProgress(report.PhaseCancelled, 0, 0)
+	default:
+		j.Error = err.Error()
+		final = report.Progress{Phase: report.PhaseFailed, Message: j.Error}
+	}
+	j.Progress = progressPtr(final)
+
+	if m.reporter != nil {
+		if forward := m.reporter(j.ID); forward != nil {
+			forward(final)
+		}
+	}
+
+	if done, ok := m.done[j.ID]; ok {
+		close(done)
+		delete(m.done, j.ID)
+	}
+
+	m.pruneLocked()
+	m.saveLocked()
+}
+
+// pruneLocked drops the oldest finished jobs beyond maxFinishedJobs. Caller holds mu.
+func (m *Manager) pruneLocked() {
+	var finished []*Job
+	for _, j := range m.jobs {
+		if j.Status.IsFinished() {
+			finished = append(finished, j)
+		}
+	}
+	if len(finished) <= maxFinishedJobs {
+		return
+	}
+
+	sort.Slice(finished, func(i, k int) bool {
+		return finished[i].CreatedAt.After(finished[k].CreatedAt)
+	})
+	for _, j := range finished[maxFinishedJobs:] {
+		delete(m.jobs, j.ID)
+		delete(m.results, j.ID)
+	}
+}
+
+// saveLocked asks the saver to persist the jobs. Caller holds mu.
+func (m *Manager) saveLocked() {
+	select {
+	case m.saves <- struct{}{}:
+	default:
+		// A save is pending already and will see this change
+	}
+}
+
+// saver persists the jobs whenever they change, one save at a time and
+// without holding mu while writing
+func (m *Manager) saver() {
+	for range m.saves {
+		m.save()
+	}
+}
+
+// save writes all jobs to disk
+func (m *Manager) save() {
+	m.mu.Lock()
+	jobs := make([]*Job, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		jobs = append(jobs, j)
+	}
+	sort.Slice(jobs, func(i, k int) bool {
+		return jobs[i].CreatedAt.After(jobs[k].CreatedAt)
+	})
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	m.mu.Unlock()
+
+	if err != nil {
+		log.Printf("Warning: Failed to encode jobs: %v", err)
+		return
+	}
+
+	if err := writeFileAtomic(m.filePath, data); err != nil {
+		log.Printf("Warning: Failed to save jobs: %v", err)
+	}
+}
+
+// writeFileAtomic replaces a file through a temporary file in the same
+// directory, so that a crash never leaves it half written
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// load reads persisted jobs. A file that cannot be parsed is moved aside so
+// that the server starts with an empty job history.
+func (m *Manager) load() error {
+	data, err := os.ReadFile(m.filePath)
+	if err != nil {
+		return err
+	}
+
+	var jobs []*Job
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		corrupt := fmt.Sprintf("%s.corrupt-%s", m.filePath, time.Now().Format("20060102-150405"))
+		if renameErr := os.Rename(m.filePath, corrupt); renameErr != nil {
+			return fmt.Errorf("failed to parse %s: %v, and to move it aside: %w", jobsFileName, err, renameErr)
+		}
+		log.Printf("Warning: Failed to parse %s, moved it to %s: %v", jobsFileName, corrupt, err)
+		return nil
+	}
+
+	for _, j := range jobs {
+		m.jobs[j.ID] = j
+	}
+	return nil
+}
+
+func progressPtr(p report.Progress) *report.Progress {
+	return &p
+}
@@ -0,0 +1,188 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"sonarqube-report-generator/internal/report"
+)
+
+func TestNewManagerLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string // of jobs.json, none when empty
+		wantJobs int
+		aside    bool // the file is moved aside
+	}{
+		{name: "no file"},
+		{name: "jobs", contents: `[{"id": "a", "status": "succeeded"}, {"id": "b", "status": "failed"}]`, wantJobs: 2},
+		{name: "truncated", contents: `[{"id": "a", "stat`, aside: true},
+		{name: "not json", contents: "\x00\x00", aside: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, jobsFileName)
+			if tt.contents != "" {
+				if err := os.WriteFile(path, []byte(tt.contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			m, err := NewManager(dir, 1, 1, nil, nil)
+			if err != nil {
+				t.Fatalf("NewManager() = %v", err)
+			}
+			if got := len(m.List()); got != tt.wantJobs {
+				t.Fatalf("%d jobs, want %d", got, tt.wantJobs)
+			}
+
+			corrupt, _ := filepath.Glob(path + ".corrupt-*")
+			if tt.aside != (len(corrupt) == 1) {
+				t.Fatalf("moved aside: %v, want %v", corrupt, tt.aside)
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, jobsFileName)
+
+	for _, contents := range []string{"first", "second"} {
+		if err := writeFileAtomic(path, []byte(contents)); err != nil {
+			t.Fatalf("writeFileAtomic() = %v", err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != contents {
+			t.Fatalf("file contains %q, want %q", got, contents)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("%d files left in the directory, want 1", len(entries))
+	}
+}
+
+func TestRunJobOutcome(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       RunFunc
+		want      Status
+		wantError string
+	}{
+		{
+			name: "succeeds",
+			run: func(context.Context, Job, report.ProgressFunc) (*report.ReportRecord, *report.ReportData, error) {
+				return &report.ReportRecord{ID: "r"}, &report.ReportData{}, nil
+			},
+			want: StatusSucceeded,
+		},
+		{
+			name: "fails",
+			run: func(context.Context, Job, report.ProgressFunc) (*report.ReportRecord, *report.ReportData, error) {
+				return nil, nil, errors.New("sonarqube is down")
+			},
+			want:      StatusFailed,
+			wantError: "sonarqube is down",
+		},
+		{
+			name: "panics",
+			run: func(context.Context, Job, report.ProgressFunc) (*report.ReportRecord, *report.ReportData, error) {
+				var data *report.ReportData
+				_ = data.ProjectKey
+				return nil, nil, nil
+			},
+			want:      StatusFailed,
+			wantError: "failed unexpectedly",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The saver may still be writing when the test ends, so the
+			// directory is removed without failing the test
+			dir, err := os.MkdirTemp("", "jobs")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			m, err := NewManager(dir, 1, 1, tt.run, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.Start()
+
+			j, _, err := m.Submit("", "proj", "", "md", struct{}{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			done, _, err := m.Wait(ctx, j.ID)
+			if err != nil {
+				t.Fatalf("Wait() = %v", err)
+			}
+
+			if done.Status != tt.want {
+				t.Fatalf("status = %s, want %s", done.Status, tt.want)
+			}
+			if !strings.Contains(done.Error, tt.wantError) {
+				t.Fatalf("error = %q, want it to contain %q", done.Error, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestSubmitAll(t *testing.T) {
+	dir, err := os.MkdirTemp("", "jobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Without workers the queue keeps what is submitted
+	m, err := NewManager(dir, 1, 3, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := func(key string) Submission {
+		return Submission{Key: key, ProjectKey: "proj", Format: "md", Request: struct{}{}}
+	}
+
+	if _, _, err := m.Submit("a", "proj", "", "md", struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a is in flight and c repeats within the batch: two need room
+	submitted, err := m.SubmitAll([]Submission{sub("a"), sub("b"), sub("c"), sub("c")})
+	if err != nil {
+		t.Fatalf("SubmitAll() = %v", err)
+	}
+	dedup := []bool{true, false, false, true}
+	for i, s := range submitted {
+		if s.Deduplicated != dedup[i] {
+			t.Errorf("submission %d deduplicated = %v, want %v", i, s.Deduplicated, dedup[i])
+		}
+	}
+	if submitted[3].Job.ID != submitted[2].Job.ID {
+		t.Error("repeated key was queued twice")
+	}
+
+	// The queue is full: nothing of a batch is queued
+	if _, err := m.SubmitAll([]Submission{sub("a"), sub("d")}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("SubmitAll() = %v, want ErrQueueFull", err)
+	}
+	if jobs := m.List(); len(jobs) != 3 {
+		t.Fatalf("%d jobs after a rejected batch, want 3", len(jobs))
+	}
+}
@@ -0,0 +1,41 @@
+package job
+
+import (
+	"encoding/json"
+	"time"
+
+	"sonarqube-report-generator/internal/report"
+)
+
+// Status is the lifecycle state of a job
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// IsFinished reports whether the job reached a terminal state
+func (s Status) IsFinished() bool {
+	return s == StatusSucceeded || s == StatusFailed || s == StatusCancelled
+}
+
+// Job represents a queued or finished report generation
+type Job struct {
+	ID         string           `json:"id"`
+	Status     Status           `json:"status"`
+	ProjectKey string           `json:"projectKey"`
+	Branch     string           `json:"branch"`
+	Format     string           `json:"format"`
+	Request    json.RawMessage  `json:"request"`       // original generation request, replayed after a restart
+	Key        string           `json:"key,omitempty"` // identical requests share the same key
+	Progress   *report.Progress `json:"progress,omitempty"`
+	Error      string           `json:"error,omitempty"`
+	ReportID   string           `json:"reportId,omitempty"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	StartedAt  *time.Time       `json:"startedAt,omitempty"`
+	FinishedAt *time.Time       `json:"finishedAt,omitempty"`
+}
@@ -0,0 +1,224 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// Named activity periods
+const (
+	ActivityPeriodWeek  = "week"
+	ActivityPeriodMonth = "month"
+)
+
+// ActivityPeriod is the date range of an activity report
+type ActivityPeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+// ParseActivityPeriod resolves a named period (week or month, ending on to) or
+// an explicit from/to range of dates (YYYY-MM-DD). Periods cover whole days in
+// the given time zone; to defaults to the current day.
+func ParseActivityPeriod(period, from, to string, now time.Time, zone *time.Location) (ActivityPeriod, error) {
+	y, m, d := now.In(zone).Date()
+	end := time.Date(y, m, d, 0, 0, 0, 0, zone)
+	if to != "" {
+		t, err := time.ParseInLocation("2006-01-02", to, zone)
+		if err != nil {
+			return ActivityPeriod{}, fmt.Errorf("invalid to date %q, expected YYYY-MM-DD", to)
+		}
+		end = t
+	}
+
+	var start time.Time
+	switch {
+	case period == ActivityPeriodWeek:
+		start = end.AddDate(0, 0, -6)
+	case period == ActivityPeriodMonth:
+		start = monthBefore(end.AddDate(0, 0, 1))
+	case period != "":
+		return ActivityPeriod{}, fmt.Errorf("invalid period %q, expected %q or %q", period, ActivityPeriodWeek, ActivityPeriodMonth)
+	case from == "":
+		return ActivityPeriod{}, fmt.Errorf("a period or a from date is required")
+	default:
+		t, err := time.ParseInLocation("2006-01-02", from, zone)
+		if err != nil {
+			return ActivityPeriod{}, fmt.Errorf("invalid from date %q, expected YYYY-MM-DD", from)
+		}
+		start = t
+	}
+
+	if start.After(end) {
+		return ActivityPeriod{}, fmt.Errorf("from date must not be after to date")
+	}
+	return ActivityPeriod{From: start, To: endOfDay(end)}, nil
+}
+
+// monthBefore returns the same day a month before t. A day the earlier month
+// does not have, such as March 30 for February, becomes the first of t's
+// month rather than overflowing into it.
+func monthBefore(t time.Time) time.Time {
+	first := time.Date(t.Year(), t.Month()-1, 1, 0, 0, 0, 0, t.Location())
+	if t.Day() > first.AddDate(0, 1, -1).Day() {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return first.AddDate(0, 0, t.Day()-1)
+}
+
+// Contains reports whether a time falls within the period
+func (p ActivityPeriod) Contains(t time.Time) bool {
+	return !t.Before(p.From) && !t.After(p.To)
+}
+
+// ActivityReport summarises the issues opened and resolved during a period
+type ActivityReport struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// Opened lists issues created in the period; StillOpen counts those not
+	// resolved since
+	Opened    []IssueItem `json:"opened"`
+	StillOpen int         `json:"stillOpen"`
+
+	// Issues resolved in the period, by resolution
+	Fixed         []IssueItem `json:"fixed"`
+	FalsePositive []IssueItem `json:"falsePositive"`
+	WontFix       []IssueItem `json:"wontFix"`
+
+	// Remediation effort in minutes of the issues opened and resolved
+	DebtAdded   int `json:"debtAdded"`
+	DebtRemoved int `json:"debtRemoved"`
+
+	// Truncated is set when the period has more issues than can be fetched,
+	// so the lists and counts only cover the first of them
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// NetDebtChange is the remediation effort in minutes added by the period
+func (a *ActivityReport) NetDebtChange() int {
+	return a.DebtAdded - a.DebtRemoved
+}
+
+// generateActivity builds an activity report of the issues opened and resolved
+// during a period. SonarQube cannot filter issues by resolution date, so
+// resolved issues are read most recently updated first, up to the first one
+// last updated before the period, and filtered here.
+func (g *Generator) generateActivity(ctx context.Context, projectKey, projectName, branch string, options GenerateOptions) (*ReportData, error) {
+	progress := options.Progress
+	period := *options.Activity
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	progress.ReportMessage(PhaseIssues, "fetching issues opened in the period")
+	opened, openedTotal, err := g.client.SearchIssues(sonarqube.IssueQuery{
+		ProjectKey:    projectKey,
+		Branch:        branch,
+		CreatedAfter:  period.From,
+		CreatedBefore: period.To,
+	}, maxHistoryIssues, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opened issues: %w", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	progress.ReportMessage(PhaseIssues, "fetching issues resolved in the period")
+	// An issue is last updated no earlier than it was resolved
+	updatedBefore := func(issue sonarqube.Issue) bool {
+		return !issue.UpdateDate.IsZero() && issue.UpdateDate.Before(period.From)
+	}
+	resolvedOnly := true
+	resolved, resolvedTotal, err := g.client.SearchIssues(sonarqube.IssueQuery{
+		ProjectKey:    projectKey,
+		Branch:        branch,
+		Resolved:      &resolvedOnly,
+		CreatedBefore: period.To,
+		Sort:          sonarqube.IssueSortUpdateDate,
+		Descending:    true,
+		Until:         updatedBefore,
+	}, maxHistoryIssues, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resolved issues: %w", err)
+	}
+
+	activity := &ActivityReport{From: period.From, To: period.To}
+	resolvedComplete := len(resolved) >= resolvedTotal || (len(resolved) > 0 && updatedBefore(resolved[len(resolved)-1]))
+	if len(opened) < openedTotal || !resolvedComplete {
+		log.Printf("Warning: Activity report for %s covers %d of %d opened and %d of %d resolved issues", projectKey, len(opened), openedTotal, len(resolved), resolvedTotal)
+		activity.Truncated = true
+	}
+	for _, issue := range opened {
+		if issue.CreationDate.IsZero() || !period.Contains(issue.CreationDate.Time) {
+			continue
+		}
+		activity.Opened = append(activity.Opened, newIssueItem(issue))
+		activity.DebtAdded += effortMinutes(issue.Effort)
+		if issue.Resolution == "" {
+			activity.StillOpen++
+		}
+	}
+
+	for _, issue := range resolved {
+		at, ok := resolvedAt(issue)
+		if !ok || !period.Contains(at) {
+			continue
+		}
+		item := newIssueItem(issue)
+		switch issue.Resolution {
+		case "FALSE-POSITIVE":
+			activity.FalsePositive = append(activity.FalsePositive, item)
+		case "WONTFIX":
+			activity.WontFix = append(activity.WontFix, item)
+		default: // FIXED, REMOVED
+			activity.Fixed = append(activity.Fixed, item)
+		}
+		activity.DebtRemoved += effortMinutes(issue.Effort)
+	}
+
+	red := options.Redactor.begin()
+	for _, list := range [][]IssueItem{activity.Opened, activity.Fixed, activity.FalsePositive, activity.WontFix} {
+		sortIssuesBySeverity(list)
+		red.items(list)
+	}
+
+	reportData := &ReportData{
+		ProjectKey:  projectKey,
+		ProjectName: projectName,
+		Branch:      branch,
+		GeneratedAt: time.Now(),
+		Activity:    activity,
+		Redactions:  red.info(),
+		Language:    options.Language,
+		Timezone:    options.Timezone,
+
+		WorkingDayHours: workingDayHours(options.WorkingDayHours),
+	}
+
+	// The report reflects the state as of the last analysis in the period
+	if analyses, err := g.client.GetAnalysesUntil(projectKey, branch, period.To, 1); err == nil && len(analyses) > 0 {
+		reportData.AnalysisDate = analyses[0].Date
+		reportData.AnalysisKey = analyses[0].Key
+	}
+
+	return reportData, nil
+}
+
+// resolvedAt returns when an issue was resolved. Resolved issues that are not
+// closed yet have no close date, so their last update is used.
+func resolvedAt(issue sonarqube.Issue) (time.Time, bool) {
+	closed := issue.CloseDate
+	if closed.IsZero() && issue.Resolution != "" {
+		closed = issue.UpdateDate
+	}
+	if closed.IsZero() {
+		return time.Time{}, false
+	}
+	return closed.Time, true
+}
@@ -0,0 +1,142 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+func TestParseActivityPeriod(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+	day := func(s string) time.Time {
+		d, _ := time.ParseInLocation("2006-01-02", s, time.UTC)
+		return d
+	}
+
+	tests := []struct {
+		name     string
+		period   string
+		from, to string
+		wantFrom string
+		wantTo   string
+		wantErr  string
+	}{
+		{name: "week to today", period: "week", wantFrom: "2026-10-12", wantTo: "2026-10-18"},
+		{name: "month mid-month", period: "month", to: "2026-03-15", wantFrom: "2026-02-16", wantTo: "2026-03-15"},
+		{name: "month to end of month", period: "month", to: "2026-03-31", wantFrom: "2026-03-01", wantTo: "2026-03-31"},
+		{name: "month to March 30", period: "month", to: "2026-03-30", wantFrom: "2026-03-01", wantTo: "2026-03-30"},
+		{name: "month to March 29", period: "month", to: "2026-03-29", wantFrom: "2026-03-01", wantTo: "2026-03-29"},
+		{name: "month to March 27", period: "month", to: "2026-03-27", wantFrom: "2026-02-28", wantTo: "2026-03-27"},
+		{name: "month in leap year", period: "month", to: "2028-03-28", wantFrom: "2028-02-29", wantTo: "2028-03-28"},
+		{name: "month to May 30", period: "month", to: "2026-05-30", wantFrom: "2026-05-01", wantTo: "2026-05-30"},
+		{name: "month across the year", period: "month", to: "2026-01-10", wantFrom: "2025-12-11", wantTo: "2026-01-10"},
+		{name: "range", from: "2026-09-01", to: "2026-09-30", wantFrom: "2026-09-01", wantTo: "2026-09-30"},
+		{name: "single day", from: "2026-09-01", to: "2026-09-01", wantFrom: "2026-09-01", wantTo: "2026-09-01"},
+		{name: "unknown period", period: "year", wantErr: "invalid period"},
+		{name: "nothing", wantErr: "a period or a from date is required"},
+		{name: "bad to", period: "week", to: "18/10/2026", wantErr: "invalid to date"},
+		{name: "reversed", from: "2026-10-02", to: "2026-10-01", wantErr: "must not be after"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseActivityPeriod(tt.period, tt.from, tt.to, now, time.UTC)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseActivityPeriod() = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseActivityPeriod() = %v", err)
+			}
+			if !got.From.Equal(day(tt.wantFrom)) {
+				t.Errorf("from = %s, want %s", got.From.Format("2006-01-02"), tt.wantFrom)
+			}
+			if !got.To.Equal(endOfDay(day(tt.wantTo))) {
+				t.Errorf("to = %s, want end of %s", got.To, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestGenerateActivityResolvedPaging(t *testing.T) {
+	period := ActivityPeriod{
+		From: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		To:   endOfDay(time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)),
+	}
+
+	tests := []struct {
+		name          string
+		inPeriod      int // resolved issues last updated in the period, newest first
+		afterPeriod   int // resolved issues updated after the period, listed before
+		wantPages     int32
+		wantFixed     int
+		wantTruncated bool
+	}{
+		{name: "stops at the period start", inPeriod: 250, wantPages: 3, wantFixed: 250},
+		{name: "skips later updates", afterPeriod: 120, inPeriod: 30, wantPages: 2, wantFixed: 30},
+		{name: "more than can be fetched", inPeriod: maxHistoryIssues + 100, wantPages: maxHistoryIssues / 100, wantFixed: maxHistoryIssues, wantTruncated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const total = 5000
+			var pages int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query()
+				if r.URL.Path != "/api/issues/search" || q.Get("resolved") != "true" {
+					w.Write([]byte(`{"total": 0, "paging": {"total": 0}, "issues": []}`))
+					return
+				}
+				if q.Get("s") != sonarqube.IssueSortUpdateDate || q.Get("asc") != "false" {
+					t.Errorf("resolved issues sorted by %q, asc=%q", q.Get("s"), q.Get("asc"))
+				}
+				atomic.AddInt32(&pages, 1)
+
+				page, _ := strconv.Atoi(q.Get("p"))
+				size, _ := strconv.Atoi(q.Get("ps"))
+				var issues []map[string]interface{}
+				for i := (page - 1) * size; i < page*size && i < total; i++ {
+					updated := period.To.Add(time.Duration(tt.afterPeriod-i) * time.Minute)
+					if i >= tt.afterPeriod+tt.inPeriod {
+						updated = period.From.Add(-time.Duration(i) * time.Minute)
+					}
+					date := updated.Format(sonarqube.DateTimeFormat)
+					issues = append(issues, map[string]interface{}{
+						"key": "i" + strconv.Itoa(i), "rule": "go:S1", "severity": "MAJOR", "component": "proj:a.go",
+						"status": "CLOSED", "resolution": "FIXED", "effort": "5min",
+						"creationDate": "2026-01-01T00:00:00+0000", "updateDate": date, "closeDate": date,
+					})
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"total": total, "paging": map[string]int{"total": total}, "issues": issues})
+			}))
+			defer server.Close()
+
+			g := NewGenerator(sonarqube.NewClient(server.URL, "token"), nil)
+			data, err := g.generateActivity(context.Background(), "proj", "Project", "", GenerateOptions{Activity: &period})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := atomic.LoadInt32(&pages); got != tt.wantPages {
+				t.Errorf("fetched %d pages of resolved issues, want %d", got, tt.wantPages)
+			}
+			if got := len(data.Activity.Fixed); got != tt.wantFixed {
+				t.Errorf("fixed = %d, want %d", got, tt.wantFixed)
+			}
+			if data.Activity.DebtRemoved != 5*tt.wantFixed {
+				t.Errorf("debt removed = %d, want %d", data.Activity.DebtRemoved, 5*tt.wantFixed)
+			}
+			if data.Activity.Truncated != tt.wantTruncated {
+				t.Errorf("truncated = %v, want %v", data.Activity.Truncated, tt.wantTruncated)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+const (
+	baselinesFileName = "baselines.json"
+
+	// maxBaselineIssues is the most issues a baseline can hold, the limit of
+	// SonarQube's issue search
+	maxBaselineIssues = 10000
+)
+
+var (
+	// ErrBaselineExists is returned when creating a baseline a project branch already has
+	ErrBaselineExists = errors.New("baseline already exists, refresh it instead")
+	// ErrBaselineNotFound is returned for a project branch without baseline
+	ErrBaselineNotFound = errors.New("baseline not found")
+)
+
+// Baseline is a frozen set of accepted issues of a project branch. Issues are
+// identified by fingerprint rather than key so that they still match when
+// SonarQube re-creates them or their line moves.
+type Baseline struct {
+	ProjectKey  string    `json:"projectKey"`
+	Branch      string    `json:"branch"` // empty for the main branch
+	CreatedAt   time.Time `json:"createdAt"`
+	RefreshedAt time.Time `json:"refreshedAt"`
+	AnalysisKey string    `json:"analysisKey,omitempty"`
+	Issues      int       `json:"issues"`
+
+	// Fingerprints counts the baseline issues per fingerprint; identical
+	// issues in one file share a fingerprint
+	Fingerprints map[string]int `json:"fingerprints,omitempty"`
+}
+
+// Version identifies the contents of a baseline, which change when it is refreshed
+func (b *Baseline) Version() string {
+	return b.RefreshedAt.UTC().Format(time.RFC3339Nano)
+}
+
+// BaselineInfo describes the baseline a report was compared with. The counts
+// cover all open issues in the report's scope, including those not listed.
+type BaselineInfo struct {
+	CreatedAt      time.Time `json:"createdAt"`
+	RefreshedAt    time.Time `json:"refreshedAt"`
+	BaselineIssues int       `json:"baselineIssues"` // open issues accepted in the baseline
+	NewIssues      int       `json:"newIssues"`      // open issues not in the baseline
+
+	// NewBySeverity and BaselineBySeverity count the new and the accepted
+	// issues per severity
+	NewBySeverity      map[string]int `json:"newBySeverity"`
+	BaselineBySeverity map[string]int `json:"baselineBySeverity"`
+
+	// Truncated is set when the project has more open issues than can be
+	// fetched, so the counts only cover the first of them
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Severities returns the severities with new or accepted issues, most severe first
+func (b *BaselineInfo) Severities() []string {
+	var severities []string
+	for sev := range b.NewBySeverity {
+		severities = append(severities, sev)
+	}
+	for sev := range b.BaselineBySeverity {
+		if _, ok := b.NewBySeverity[sev]; !ok {
+			severities = append(severities, sev)
+		}
+	}
+	sort.Slice(severities, func(i, j int) bool {
+		return SeverityOrder(severities[i]) < SeverityOrder(severities[j])
+	})
+	return severities
+}
+
+// matchBaseline matches open issues against the baseline and returns the
+// keys of the accepted ones. Each fingerprint matches as many issues as the
+// baseline held, in line order. complete tells whether issues are all open
+// issues of the project.
+func matchBaseline(baseline *Baseline, issues []sonarqube.Issue, complete bool) (*BaselineInfo, map[string]bool) {
+	info := &BaselineInfo{
+		CreatedAt:          baseline.CreatedAt,
+		RefreshedAt:        baseline.RefreshedAt,
+		NewBySeverity:      make(map[string]int),
+		BaselineBySeverity: make(map[string]int),
+		Truncated:          !complete,
+	}
+
+	order := make([]int, len(issues))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return issues[order[a]].Line < issues[order[b]].Line
+	})
+
+	remaining := make(map[string]int, len(baseline.Fingerprints))
+	for fp, n := range baseline.Fingerprints {
+		remaining[fp] = n
+	}
+	accepted := make(map[string]bool)
+	for _, i := range order {
+		item := newIssueItem(issues[i])
+		if fp := item.Fingerprint; remaining[fp] > 0 {
+			remaining[fp]--
+			accepted[item.Key] = true
+			info.BaselineIssues++
+			info.BaselineBySeverity[item.Severity]++
+			continue
+		}
+		info.NewIssues++
+		info.NewBySeverity[item.Severity]++
+	}
+
+	return info, accepted
+}
+
+// NewIssues returns the issues of the report that are not in its baseline,
+// most severe first
+func (d *ReportData) NewIssues() []IssueItem {
+	var issues []IssueItem
+	for _, sev := range GetSortedSeverities(d.IssuesBySeverity) {
+		for _, issue := range d.IssuesBySeverity[sev] {
+			if !issue.InBaseline {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
+}
+
+// CaptureBaseline freezes the open issues of a project branch into a baseline
+func (g *Generator) CaptureBaseline(ctx context.Context, projectKey, branch string) (*Baseline, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	unresolved := false
+	issues, total, err := g.client.SearchIssues(sonarqube.IssueQuery{
+		ProjectKey: projectKey,
+		Branch:     branch,
+		Resolved:   &unresolved,
+	}, maxBaselineIssues, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues: %w", err)
+	}
+	if total > len(issues) {
+		return nil, fmt.Errorf("project has %d open issues, more than the %d a baseline can hold", total, maxBaselineIssues)
+	}
+
+	now := time.Now()
+	baseline := &Baseline{
+		ProjectKey:   projectKey,
+		Branch:       branch,
+		CreatedAt:    now,
+		RefreshedAt:  now,
+		Issues:       len(issues),
+		Fingerprints: make(map[string]int),
+	}
+	for _, issue := range issues {
+		baseline.Fingerprints[newIssueItem(issue).Fingerprint]++
+	}
+
+	if analyses, err := g.client.GetAnalyses(projectKey, branch, 1); err == nil && len(analyses) > 0 {
+		baseline.AnalysisKey = analyses[0].Key
+	}
+
+	return baseline, nil
+}
+
+// BaselineStore keeps the baselines of all project branches in a file
+type BaselineStore struct {
+	filePath string
+
+	mu        sync.RWMutex
+	baselines map[string]*Baseline
+}
+
+// NewBaselineStore creates a baseline store persisted inside storagePath
+func NewBaselineStore(storagePath string) (*BaselineStore, error) {
+	s := &BaselineStore{
+		filePath:  filepath.Join(storagePath, baselinesFileName),
+		baselines: make(map[string]*Baseline),
+	}
+
+	raw, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read baselines: %w", err)
+	}
+
+	var baselines []*Baseline
+	if err := json.Unmarshal(raw, &baselines); err != nil {
+		return nil, fmt.Errorf("failed to parse baselines: %w", err)
+	}
+	for _, b := range baselines {
+		s.baselines[baselineKey(b.ProjectKey, b.Branch)] = b
+	}
+
+	return s, nil
+}
+
+func baselineKey(projectKey, branch string) string {
+	return projectKey + "\x00" + branch
+}
+
+// Get returns the baseline of a project branch
+func (s *BaselineStore) Get(projectKey, branch string) (*Baseline, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b, ok := s.baselines[baselineKey(projectKey, branch)]
+	return b, ok
+}
+
+// List returns all baselines without their fingerprints, sorted by project and branch
+func (s *BaselineStore) List() []Baseline {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]Baseline, 0, len(s.baselines))
+	for _, b := range s.baselines {
+		summary := *b
+		summary.Fingerprints = nil
+		list = append(list, summary)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ProjectKey != list[j].ProjectKey {
+			return list[i].ProjectKey < list[j].ProjectKey
+		}
+		return list[i].Branch < list[j].Branch
+	})
+	return list
+}
+
+// Put stores a baseline. A new baseline fails with ErrBaselineExists when
+// the project branch already has one; a replacing baseline fails with
+// ErrBaselineNotFound when it has none, and keeps the creation date of the
+// one it replaces.
+func (s *BaselineStore) Put(b *Baseline, replace bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := baselineKey(b.ProjectKey, b.Branch)
+	old, ok := s.baselines[key]
+	switch {
+	case ok && !replace:
+		return ErrBaselineExists
+	case !ok && replace:
+		return ErrBaselineNotFound
+	case ok:
+		b.CreatedAt = old.CreatedAt
+	}
+	s.baselines[key] = b
+	return s.save()
+}
+
+// Delete removes the baseline of a project branch
+func (s *BaselineStore) Delete(projectKey, branch string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := baselineKey(projectKey, branch)
+	if _, ok := s.baselines[key]; !ok {
+		return ErrBaselineNotFound
+	}
+	delete(s.baselines, key)
+	return s.save()
+}
+
+func (s *BaselineStore) save() error {
+	baselines := make([]*Baseline, 0, len(s.baselines))
+	for _, b := range s.baselines {
+		baselines = append(baselines, b)
+	}
+
+	raw, err := json.Marshal(baselines)
+	if err != nil {
+		return fmt.Errorf("failed to encode baselines: %w", err)
+	}
+	if err := s.write(raw); err != nil {
+		return fmt.Errorf("failed to write baselines: %w", err)
+	}
+	return nil
+}
+
+// write replaces the baselines file through a temporary file, so a crash
+// never leaves a truncated file
+func (s *BaselineStore) write(raw []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.filePath), baselinesFileName+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.filePath)
+}
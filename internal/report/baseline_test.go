@@ -0,0 +1,125 @@
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+func TestMatchBaseline(t *testing.T) {
+	issue := func(key, severity, message string, line int) sonarqube.Issue {
+		return sonarqube.Issue{Key: key, Rule: "java:S1", Component: "proj:src/A.java", Severity: severity, Message: message, Line: line}
+	}
+	fingerprint := func(i sonarqube.Issue) string { return newIssueItem(i).Fingerprint }
+
+	accepted := issue("old", "MAJOR", "accepted", 10)
+	duplicate := issue("dup-1", "MINOR", "duplicate", 30)
+	baseline := &Baseline{Fingerprints: map[string]int{
+		fingerprint(accepted):  1,
+		fingerprint(duplicate): 1,
+	}}
+
+	issues := []sonarqube.Issue{
+		issue("new", "BLOCKER", "new", 5),
+		issue("dup-2", "MINOR", "duplicate", 40),
+		duplicate,
+		issue("moved", "MAJOR", "accepted", 99), // key and line changed
+	}
+	info, keys := matchBaseline(baseline, issues, true)
+
+	if info.BaselineIssues != 2 || info.NewIssues != 2 {
+		t.Fatalf("baseline = %d, new = %d, want 2 and 2", info.BaselineIssues, info.NewIssues)
+	}
+	// The baseline held one duplicate, so only the first in line order matches
+	for key, want := range map[string]bool{"new": false, "dup-1": true, "dup-2": false, "moved": true} {
+		if keys[key] != want {
+			t.Errorf("accepted[%s] = %v, want %v", key, keys[key], want)
+		}
+	}
+	if info.NewBySeverity["BLOCKER"] != 1 || info.NewBySeverity["MINOR"] != 1 {
+		t.Errorf("new by severity = %v", info.NewBySeverity)
+	}
+	if info.BaselineBySeverity["MAJOR"] != 1 || info.BaselineBySeverity["MINOR"] != 1 {
+		t.Errorf("baseline by severity = %v", info.BaselineBySeverity)
+	}
+	if got := info.Severities(); len(got) != 3 || got[0] != "BLOCKER" || got[1] != "MAJOR" || got[2] != "MINOR" {
+		t.Errorf("severities = %v", got)
+	}
+	if info.Truncated {
+		t.Error("truncated with all issues")
+	}
+
+	if info, _ := matchBaseline(baseline, issues[:1], false); !info.Truncated {
+		t.Error("not truncated with some issues")
+	}
+}
+
+func TestBaselineStorePut(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewBaselineStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := store.Put(&Baseline{ProjectKey: "p"}, true); !errors.Is(err, ErrBaselineNotFound) {
+		t.Fatalf("replacing a missing baseline = %v", err)
+	}
+
+	// Concurrent creates of the same project branch: exactly one wins
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- store.Put(&Baseline{ProjectKey: "p", CreatedAt: created, Issues: 1}, false)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	stored := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			stored++
+		case !errors.Is(err, ErrBaselineExists):
+			t.Fatalf("create = %v", err)
+		}
+	}
+	if stored != 1 {
+		t.Fatalf("%d concurrent creates succeeded, want 1", stored)
+	}
+
+	if err := store.Put(&Baseline{ProjectKey: "p", CreatedAt: time.Now(), Issues: 2}, true); err != nil {
+		t.Fatalf("refresh = %v", err)
+	}
+
+	reopened, err := NewBaselineStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, ok := reopened.Get("p", "")
+	if !ok || b.Issues != 2 || !b.CreatedAt.Equal(created) {
+		t.Fatalf("reloaded baseline = %+v, %v", b, ok)
+	}
+
+	if err := reopened.Delete("p", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Delete("p", ""); !errors.Is(err, ErrBaselineNotFound) {
+		t.Fatalf("second delete = %v", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if e.Name() != baselinesFileName {
+			t.Errorf("left %s behind", filepath.Join(dir, e.Name()))
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package report
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// UnownedLabel names the owner of issues no CODEOWNERS rule matches
+const UnownedLabel = "(unowned)"
+
+// CodeOwners maps file paths to their owning teams, parsed from a file in
+// GitHub/GitLab CODEOWNERS format
+type CodeOwners struct {
+	content string
+	rules   []codeOwnersRule
+}
+
+type codeOwnersRule struct {
+	pattern string
+	re      *regexp.Regexp
+	owners  []string // empty for paths explicitly left without owner
+}
+
+// ParseCodeOwners parses a CODEOWNERS file. Each line holds a path pattern
+// followed by its owners; for a path the last matching line wins. Comments,
+// blank lines and GitLab section headers are skipped.
+func ParseCodeOwners(content string) (*CodeOwners, error) {
+	c := &CodeOwners{content: content}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+
+		fields := strings.Fields(line)
+		pattern := fields[0]
+		if pattern == "/" || strings.HasPrefix(pattern, "!") {
+			return nil, fmt.Errorf("line %d: unsupported pattern %q", lineNo, pattern)
+		}
+		re, err := regexp.Compile(codeOwnersPattern(pattern))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNo, pattern, err)
+		}
+		c.rules = append(c.rules, codeOwnersRule{pattern: pattern, re: re, owners: fields[1:]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CODEOWNERS: %w", err)
+	}
+
+	return c, nil
+}
+
+// codeOwnersPattern converts a CODEOWNERS pattern into a regular expression.
+// As in .gitignore, a pattern with a leading or inner slash is relative to the
+// project root, others match at any depth, and a pattern matching a
+// directory covers everything below it.
+func codeOwnersPattern(pattern string) string {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(.*/)?")
+	}
+	b.WriteString(globPattern(pattern))
+	if dirOnly {
+		b.WriteString("/.*$")
+	} else {
+		b.WriteString("(/.*)?$")
+	}
+	return b.String()
+}
+
+// Owners returns the owners of a file path, nil when it has none
+func (c *CodeOwners) Owners(path string) []string {
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].re.MatchString(path) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// Owns reports whether a team owns a file path
+func (c *CodeOwners) Owns(team, path string) bool {
+	for _, owner := range c.Owners(path) {
+		if owner == team {
+			return true
+		}
+	}
+	return false
+}
+
+// Teams returns all owners named in the file, sorted
+func (c *CodeOwners) Teams() []string {
+	seen := make(map[string]bool)
+	teams := []string{}
+	for _, rule := range c.rules {
+		for _, owner := range rule.owners {
+			if !seen[owner] {
+				seen[owner] = true
+				teams = append(teams, owner)
+			}
+		}
+	}
+	sort.Strings(teams)
+	return teams
+}
+
+// Content returns the file the owners were parsed from
+func (c *CodeOwners) Content() string {
+	return c.content
+}
+
+// Version identifies the contents of the file, which change the ownership
+// of past reports
+func (c *CodeOwners) Version() string {
+	sum := sha256.Sum256([]byte(c.content))
+	return hex.EncodeToString(sum[:8])
+}
+
+// filterTeam keeps the issues in files owned by a team
+func (c *CodeOwners) filterTeam(issues []sonarqube.Issue, team string) []sonarqube.Issue {
+	kept := make([]sonarqube.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if c.Owns(team, extractFileName(issue.Component)) {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// filterTeamHotspots keeps the hotspots in files owned by a team
+func (c *CodeOwners) filterTeamHotspots(hotspots []sonarqube.Hotspot, team string) []sonarqube.Hotspot {
+	kept := make([]sonarqube.Hotspot, 0, len(hotspots))
+	for _, hotspot := range hotspots {
+		if c.Owns(team, extractFileName(hotspot.Component)) {
+			kept = append(kept, hotspot)
+		}
+	}
+	return kept
+}
+
+// OwnerSummary counts the issues of one owner. An issue in a file with
+// several owners counts for each of them.
+type OwnerSummary struct {
+	Owner         string         `json:"owner"`
+	Issues        int            `json:"issues"`
+	BySeverity    map[string]int `json:"bySeverity"`
+	EffortMinutes int            `json:"effortMinutes"`
+}
+
+// assignOwners sets the owners of each issue item and summarises the issues
+// per owner, most issues first
+func assignOwners(owners *CodeOwners, items []IssueItem) []OwnerSummary {
+	byOwner := make(map[string]*OwnerSummary)
+	add := func(owner string, item IssueItem) {
+		s, ok := byOwner[owner]
+		if !ok {
+			s = &OwnerSummary{Owner: owner, BySeverity: make(map[string]int)}
+			byOwner[owner] = s
+		}
+		s.Issues++
+		s.BySeverity[item.Severity]++
+		s.EffortMinutes += item.EffortMinutes
+	}
+
+	for i := range items {
+		items[i].Owners = owners.Owners(items[i].Component)
+		if len(items[i].Owners) == 0 {
+			add(UnownedLabel, items[i])
+			continue
+		}
+		for _, owner := range items[i].Owners {
+			add(owner, items[i])
+		}
+	}
+
+	summaries := make([]OwnerSummary, 0, len(byOwner))
+	for _, s := range byOwner {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Issues != summaries[j].Issues {
+			return summaries[i].Issues > summaries[j].Issues
+		}
+		return summaries[i].Owner < summaries[j].Owner
+	})
+	return summaries
+}
+
+// CodeOwnersStore keeps one CODEOWNERS file per project in a directory, so
+// operators can also provide them by dropping files there
+type CodeOwnersStore struct {
+	dir string
+}
+
+// NewCodeOwnersStore creates a CODEOWNERS store in dir
+func NewCodeOwnersStore(dir string) (*CodeOwnersStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create CODEOWNERS directory: %w", err)
+	}
+	return &CodeOwnersStore{dir: dir}, nil
+}
+
+// codeOwnersFileSuffix ends the name of every CODEOWNERS file in the store
+const codeOwnersFileSuffix = ".codeowners"
+
+// ErrInvalidProjectKey is returned for project keys that cannot name a file
+var ErrInvalidProjectKey = errors.New("invalid project key")
+
+// path returns the file of a project: its key, path-escaped so that every
+// key has a file of its own inside the directory, and the file suffix
+func (s *CodeOwnersStore) path(projectKey string) (string, error) {
+	if projectKey == "" || projectKey == "." || projectKey == ".." {
+		return "", ErrInvalidProjectKey
+	}
+	return filepath.Join(s.dir, url.PathEscape(projectKey)+codeOwnersFileSuffix), nil
+}
+
+// Get returns the CODEOWNERS of a project, nil if it has none
+func (s *CodeOwnersStore) Get(projectKey string) (*CodeOwners, error) {
+	path, err := s.path(projectKey)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read CODEOWNERS: %w", err)
+	}
+
+	owners, err := ParseCodeOwners(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CODEOWNERS of project %s: %w", projectKey, err)
+	}
+	return owners, nil
+}
+
+// Put validates and stores the CODEOWNERS of a project
+func (s *CodeOwnersStore) Put(projectKey, content string) (*CodeOwners, error) {
+	path, err := s.path(projectKey)
+	if err != nil {
+		return nil, err
+	}
+	owners, err := ParseCodeOwners(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write CODEOWNERS: %w", err)
+	}
+	return owners, nil
+}
+
+// Delete removes the CODEOWNERS of a project
+func (s *CodeOwnersStore) Delete(projectKey string) error {
+	path, err := s.path(projectKey)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("CODEOWNERS not found")
+		}
+		return fmt.Errorf("failed to delete CODEOWNERS: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCodeOwnersPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "*.go", path: "main.go", want: true},
+		{pattern: "*.go", path: "internal/report/diff.go", want: true},
+		{pattern: "*.go", path: "main.gox", want: false},
+		{pattern: "docs", path: "a/docs/readme.md", want: true},
+		{pattern: "/docs/", path: "docs/readme.md", want: true},
+		{pattern: "/docs/", path: "docs/api/index.md", want: true},
+		{pattern: "/docs/", path: "a/docs/readme.md", want: false},
+		{pattern: "/docs/", path: "docs", want: false},
+		{pattern: "src/app", path: "src/app/main.go", want: true},
+		{pattern: "src/app", path: "src/app", want: true},
+		{pattern: "src/app", path: "lib/src/app/main.go", want: false},
+		{pattern: "src/app", path: "src/application.go", want: false},
+		{pattern: "/build/*.js", path: "build/app.js", want: true},
+		{pattern: "/build/*.js", path: "build/x/app.js", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			re := regexp.MustCompile(codeOwnersPattern(tt.pattern))
+			if got := re.MatchString(tt.path); got != tt.want {
+				t.Fatalf("%q matches %q = %v, want %v (regex %s)", tt.pattern, tt.path, got, tt.want, re)
+			}
+		})
+	}
+}
+
+func TestParseCodeOwners(t *testing.T) {
+	content := `# Default owners
+* @platform
+
+[Backend]
+*.go @backend @platform # reviewed by both
+/internal/report/ @reporting
+
+^[Optional docs]
+/docs/ @writers
+/docs/generated/
+`
+	owners, err := ParseCodeOwners(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "README.md", want: "@platform"},
+		{path: "cmd/server/main.go", want: "@backend @platform"},
+		{path: "internal/report/diff.go", want: "@reporting"},
+		{path: "docs/usage.md", want: "@writers"},
+		{path: "docs/generated/api.md", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := strings.Join(owners.Owners(tt.path), " "); got != tt.want {
+				t.Fatalf("Owners(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	if got := strings.Join(owners.Teams(), " "); got != "@backend @platform @reporting @writers" {
+		t.Errorf("Teams() = %q", got)
+	}
+	if !owners.Owns("@platform", "main.go") || owners.Owns("@writers", "main.go") {
+		t.Error("Owns does not follow Owners")
+	}
+}
+
+func TestParseCodeOwnersErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "root", content: "/ @platform", wantErr: `line 1: unsupported pattern "/"`},
+		{name: "negation", content: "* @platform\n!vendor/ @nobody", wantErr: `line 2: unsupported pattern "!vendor/"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCodeOwners(tt.content)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParseCodeOwners() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCodeOwnersStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "codeowners")
+	store, err := NewCodeOwnersStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Keys that only differ in characters a file name cannot hold keep
+	// files of their own
+	keys := []string{"a/b", "a_b", "a%2Fb", "org:app", "..."}
+	for _, key := range keys {
+		if _, err := store.Put(key, "* @"+key); err != nil {
+			t.Fatalf("Put(%q) = %v", key, err)
+		}
+	}
+	for _, key := range keys {
+		owners, err := store.Get(key)
+		if err != nil || owners == nil {
+			t.Fatalf("Get(%q) = %v, %v", key, owners, err)
+		}
+		if got := strings.Join(owners.Owners("main.go"), " "); got != "@"+key {
+			t.Errorf("owners of %q = %q", key, got)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(keys) {
+		t.Errorf("%d files for %d projects", len(entries), len(keys))
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), codeOwnersFileSuffix) {
+			t.Errorf("unexpected entry %s", e.Name())
+		}
+	}
+
+	if err := store.Delete("a/b"); err != nil {
+		t.Fatal(err)
+	}
+	if owners, _ := store.Get("a_b"); owners == nil {
+		t.Error("deleting a/b removed a_b")
+	}
+
+	for _, key := range []string{"", ".", ".."} {
+		if _, err := store.Put(key, "* @x"); !errors.Is(err, ErrInvalidProjectKey) {
+			t.Errorf("Put(%q) = %v, want ErrInvalidProjectKey", key, err)
+		}
+		if _, err := store.Get(key); !errors.Is(err, ErrInvalidProjectKey) {
+			t.Errorf("Get(%q) = %v, want ErrInvalidProjectKey", key, err)
+		}
+		if err := store.Delete(key); !errors.Is(err, ErrInvalidProjectKey) {
+			t.Errorf("Delete(%q) = %v, want ErrInvalidProjectKey", key, err)
+		}
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("store directory: %v", err)
+	}
+}
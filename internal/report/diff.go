@@ -0,0 +1,378 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// ReportDiff describes what changed between two stored reports
+type ReportDiff struct {
+	Kind        string    `json:"kind"` // reports or branches
+	ProjectKey  string    `json:"projectKey"`
+	ProjectName string    `json:"projectName"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	Language    string    `json:"language,omitempty"` // language the comparison is rendered in
+	Timezone    string    `json:"timezone,omitempty"` // time zone the comparison is rendered in
+
+	Base DiffSide `json:"base"`
+	Head DiffSide `json:"head"`
+
+	QualityGate      QualityGateTransition `json:"qualityGate"`
+	ConditionChanges []ConditionChange     `json:"conditionChanges"`
+	MetricDeltas     []MetricDelta         `json:"metricDeltas"`
+
+	NewIssues       []IssueItem      `json:"newIssues"`
+	FixedIssues     []IssueItem      `json:"fixedIssues"`
+	SeverityChanges []SeverityChange `json:"severityChanges"`
+	UnchangedIssues int              `json:"unchangedIssues"`
+
+	// MatchedByFingerprint counts issues with different keys on both sides
+	// that were matched by rule, file and code
+	MatchedByFingerprint int `json:"matchedByFingerprint"`
+}
+
+// DiffSide identifies one of the compared reports
+type DiffSide struct {
+	ReportID          string             `json:"reportId"`
+	Branch            string             `json:"branch"`
+	GeneratedAt       time.Time          `json:"generatedAt"`
+	AnalysisKey       string             `json:"analysisKey,omitempty"`
+	AnalysisDate      sonarqube.DateTime `json:"analysisDate,omitempty"`
+	QualityGateStatus string             `json:"qualityGateStatus"`
+	TotalIssues       int                `json:"totalIssues"`
+}
+
+// QualityGateTransition is the quality gate status before and after
+type QualityGateTransition struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Changed bool   `json:"changed"`
+}
+
+// ConditionChange is a quality gate condition whose status or value changed
+type ConditionChange struct {
+	Metric     string `json:"metric"`
+	FromStatus string `json:"fromStatus,omitempty"`
+	ToStatus   string `json:"toStatus,omitempty"`
+	FromValue  string `json:"fromValue,omitempty"`
+	ToValue    string `json:"toValue,omitempty"`
+}
+
+// MetricDelta is the change of a single metric
+type MetricDelta struct {
+	Metric string `json:"metric"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Delta  string `json:"delta,omitempty"` // signed difference for numeric metrics
+	Trend  string `json:"trend"`           // better, worse or unchanged
+}
+
+// SeverityChange is an issue present in both reports with a different severity
+type SeverityChange struct {
+	Issue        IssueItem `json:"issue"`
+	FromSeverity string    `json:"fromSeverity"`
+	ToSeverity   string    `json:"toSeverity"`
+}
+
+const (
+	// DiffKindReports compares two stored reports over time
+	DiffKindReports = "reports"
+	// DiffKindBranches compares two branches or pull requests side by side
+	DiffKindBranches = "branches"
+)
+
+const (
+	TrendBetter    = "better"
+	TrendWorse     = "worse"
+	TrendUnchanged = "unchanged"
+)
+
+// CompareReports computes the changes from base to head
+func CompareReports(base, head *Snapshot) *ReportDiff {
+	return compare(DiffKindReports, base, head)
+}
+
+// CompareBranches compares the data of two branches of the same project.
+// Issues only in head are reported as new, issues only in base as fixed.
+func CompareBranches(base, head *ReportData) *ReportDiff {
+	return compare(DiffKindBranches, &Snapshot{Data: base}, &Snapshot{Data: head})
+}
+
+func compare(kind string, base, head *Snapshot) *ReportDiff {
+	diff := &ReportDiff{
+		Kind:        kind,
+		ProjectKey:  head.Data.ProjectKey,
+		ProjectName: head.Data.ProjectName,
+		GeneratedAt: time.Now(),
+		Language:    head.Data.Language,
+		Timezone:    head.Data.Timezone,
+		Base:        diffSide(base),
+		Head:        diffSide(head),
+		QualityGate: QualityGateTransition{
+			From:    base.Data.QualityGateStatus,
+			To:      head.Data.QualityGateStatus,
+			Changed: base.Data.QualityGateStatus != head.Data.QualityGateStatus,
+		},
+		ConditionChanges: compareConditions(base.Data.QualityGateConditions, head.Data.QualityGateConditions),
+		MetricDeltas:     compareMetrics(base.Data.Metrics, head.Data.Metrics, head.Data.WorkingDayHours),
+		NewIssues:        []IssueItem{},
+		FixedIssues:      []IssueItem{},
+		SeverityChanges:  []SeverityChange{},
+	}
+
+	compareIssues(diff, allIssues(base.Data), allIssues(head.Data))
+	return diff
+}
+
+func diffSide(s *Snapshot) DiffSide {
+	return DiffSide{
+		ReportID:          s.ReportID,
+		Branch:            s.Data.Branch,
+		GeneratedAt:       s.Data.GeneratedAt,
+		AnalysisKey:       s.Data.AnalysisKey,
+		AnalysisDate:      s.Data.AnalysisDate,
+		QualityGateStatus: s.Data.QualityGateStatus,
+		TotalIssues:       s.Data.TotalIssues,
+	}
+}
+
+// compareIssues matches issues by key first, then by fingerprint, and
+// records new, fixed and re-classified issues on diff
+func compareIssues(diff *ReportDiff, base, head []IssueItem) {
+	baseByKey := make(map[string]int, len(base))
+	baseByFingerprint := make(map[string][]int)
+	for i, issue := range base {
+		baseByKey[issue.Key] = i
+		if issue.Fingerprint != "" {
+			baseByFingerprint[issue.Fingerprint] = append(baseByFingerprint[issue.Fingerprint], i)
+		}
+	}
+
+	matched := make([]bool, len(base))
+	var unmatchedHead []IssueItem
+
+	for _, issue := range head {
+		if i, ok := baseByKey[issue.Key]; ok && !matched[i] {
+			matched[i] = true
+			diff.recordMatch(base[i], issue)
+			continue
+		}
+		unmatchedHead = append(unmatchedHead, issue)
+	}
+
+	// Issues re-created by SonarQube, or on another branch, have a different
+	// key; match them on content
+	unmatchedHead = matchByFingerprint(diff, base, unmatchedHead, matched, baseByFingerprint)
+
+	diff.NewIssues = append(diff.NewIssues, unmatchedHead...)
+
+	for i, issue := range base {
+		if !matched[i] {
+			diff.FixedIssues = append(diff.FixedIssues, issue)
+		}
+	}
+
+	sortIssuesBySeverity(diff.NewIssues)
+	sortIssuesBySeverity(diff.FixedIssues)
+}
+
+// matchByFingerprint pairs head issues with unmatched base issues of the same
+// fingerprint and returns the head issues left unmatched
+func matchByFingerprint(diff *ReportDiff, base, head []IssueItem, matched []bool, index map[string][]int) []IssueItem {
+	var remaining []IssueItem
+	for _, issue := range head {
+		found := false
+		for _, i := range index[issue.Fingerprint] {
+			if matched[i] {
+				continue
+			}
+			matched[i] = true
+			found = true
+			diff.MatchedByFingerprint++
+			diff.recordMatch(base[i], issue)
+			break
+		}
+		if !found {
+			remaining = append(remaining, issue)
+		}
+	}
+	return remaining
+}
+
+func (d *ReportDiff) recordMatch(base, head IssueItem) {
+	if base.Severity != head.Severity {
+		d.SeverityChanges = append(d.SeverityChanges, SeverityChange{
+			Issue:        head,
+			FromSeverity: base.Severity,
+			ToSeverity:   head.Severity,
+		})
+		return
+	}
+	d.UnchangedIssues++
+}
+
+// IssueFingerprint identifies an issue independently of its key, line and
+// message: rule, file and the flagged code, taken from the checksum SonarQube
+// keeps of the code with whitespace removed. Issues without a checksum, such
+// as file-level issues, fall back to the message with whitespace collapsed.
+// Reports, diffs, baselines and suppressions all use it, so a fingerprint
+// taken from one report matches the same issue in every other.
+func IssueFingerprint(issue sonarqube.Issue) string {
+	content := "code\x00" + issue.Hash
+	if issue.Hash == "" {
+		content = "message\x00" + strings.Join(strings.Fields(issue.Message), " ")
+	}
+	sum := sha256.Sum256([]byte(issue.Rule + "\x00" + extractFileName(issue.Component) + "\x00" + content))
+	return hex.EncodeToString(sum[:12])
+}
+
+func allIssues(data *ReportData) []IssueItem {
+	var issues []IssueItem
+	for _, severity := range GetSortedSeverities(data.IssuesBySeverity) {
+		issues = append(issues, data.IssuesBySeverity[severity]...)
+	}
+	return issues
+}
+
+func sortIssuesBySeverity(issues []IssueItem) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		return SeverityOrder(issues[i].Severity) < SeverityOrder(issues[j].Severity)
+	})
+}
+
+func compareConditions(base, head []ConditionResult) []ConditionChange {
+	baseByMetric := make(map[string]ConditionResult, len(base))
+	for _, c := range base {
+		baseByMetric[c.Metric] = c
+	}
+
+	changes := []ConditionChange{}
+	seen := make(map[string]bool, len(head))
+	for _, c := range head {
+		seen[c.Metric] = true
+		before, ok := baseByMetric[c.Metric]
+		if ok && before.Status == c.Status && before.ActualValue == c.ActualValue {
+			continue
+		}
+		changes = append(changes, ConditionChange{
+			Metric:     c.Metric,
+			FromStatus: before.Status,
+			ToStatus:   c.Status,
+			FromValue:  before.ActualValue,
+			ToValue:    c.ActualValue,
+		})
+	}
+
+	// Conditions removed from the gate
+	for _, c := range base {
+		if !seen[c.Metric] {
+			changes = append(changes, ConditionChange{
+				Metric:     c.Metric,
+				FromStatus: c.Status,
+				FromValue:  c.ActualValue,
+			})
+		}
+	}
+
+	return changes
+}
+
+// metricComparison describes how a summary metric is compared
+type metricComparison struct {
+	name          string
+	value         func(MetricsSummary) string
+	minutes       func(MetricsSummary) int // compared instead of value for efforts
+	lowerIsBetter bool
+	rating        bool
+}
+
+var comparedMetrics = []metricComparison{
+	{name: "Bugs", value: func(m MetricsSummary) string { return m.Bugs }, lowerIsBetter: true},
+	{name: "Vulnerabilities", value: func(m MetricsSummary) string { return m.Vulnerabilities }, lowerIsBetter: true},
+	{name: "Code Smells", value: func(m MetricsSummary) string { return m.CodeSmells }, lowerIsBetter: true},
+	{name: "Coverage", value: func(m MetricsSummary) string { return m.Coverage }},
+	{name: "Duplications", value: func(m MetricsSummary) string { return m.DuplicatedLinesDensity }, lowerIsBetter: true},
+	{name: "Lines of Code", value: func(m MetricsSummary) string { return m.LinesOfCode }},
+	{name: "Technical Debt", value: func(m MetricsSummary) string { return m.TechnicalDebt }, minutes: func(m MetricsSummary) int { return m.TechnicalDebtMinutes }, lowerIsBetter: true},
+	{name: "Reliability Rating", value: func(m MetricsSummary) string { return m.ReliabilityRating }, rating: true},
+	{name: "Security Rating", value: func(m MetricsSummary) string { return m.SecurityRating }, rating: true},
+	{name: "Maintainability Rating", value: func(m MetricsSummary) string { return m.MaintainabilityRating }, rating: true},
+}
+
+func compareMetrics(base, head MetricsSummary, dayHours int) []MetricDelta {
+	deltas := make([]MetricDelta, 0, len(comparedMetrics))
+	for _, mc := range comparedMetrics {
+		from, to := mc.value(base), mc.value(head)
+		delta := MetricDelta{Metric: mc.name, From: from, To: to, Trend: TrendUnchanged}
+
+		switch {
+		case mc.minutes != nil:
+			// Formatted durations depend on the working day, so compare
+			// the minutes and format the change for display
+			change := mc.minutes(head) - mc.minutes(base)
+			if change == 0 {
+				break
+			}
+			delta.Delta = formatDebtChange(change, dayHours)
+			if change > 0 {
+				delta.Trend = trend(1, mc.lowerIsBetter)
+			} else {
+				delta.Trend = trend(-1, mc.lowerIsBetter)
+			}
+		case from == to:
+		case mc.rating:
+			// A is the best rating, E the worst
+			delta.Trend = trend(strings.Compare(to, from), true)
+		default:
+			fromNum, fromErr := parseMetricNumber(from)
+			toNum, toErr := parseMetricNumber(to)
+			if fromErr != nil || toErr != nil {
+				// Values that are not numbers are only compared for equality
+				delta.Trend = ""
+				break
+			}
+			diff := math.Round((toNum-fromNum)*10) / 10
+			if diff == 0 {
+				break
+			}
+			delta.Delta = strings.TrimSuffix(strconv.FormatFloat(diff, 'f', 1, 64), ".0")
+			if diff > 0 {
+				delta.Delta = "+" + delta.Delta
+			}
+			if strings.HasSuffix(to, "%") {
+				delta.Delta += "%"
+			}
+			if diff > 0 {
+				delta.Trend = trend(1, mc.lowerIsBetter)
+			} else {
+				delta.Trend = trend(-1, mc.lowerIsBetter)
+			}
+		}
+
+		deltas = append(deltas, delta)
+	}
+	return deltas
+}
+
+// trend maps the sign of a change to better or worse
+func trend(sign int, lowerIsBetter bool) string {
+	switch {
+	case sign == 0:
+		return TrendUnchanged
+	case (sign > 0) == lowerIsBetter:
+		return TrendWorse
+	default:
+		return TrendBetter
+	}
+}
+
+func parseMetricNumber(s string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+}
@@ -0,0 +1,135 @@
+package report
+
+import (
+	"testing"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+func TestIssueFingerprint(t *testing.T) {
+	issue := sonarqube.Issue{Key: "a", Rule: "java:S1", Component: "proj:src/A.java", Line: 10, Hash: "5d41402a", Message: "Remove this unused import."}
+
+	tests := []struct {
+		name  string
+		other sonarqube.Issue
+		same  bool
+	}{
+		{name: "other key and line", other: sonarqube.Issue{Key: "b", Rule: "java:S1", Component: "proj:src/A.java", Line: 42, Hash: "5d41402a", Message: "Remove this unused import."}, same: true},
+		{name: "reworded message", other: sonarqube.Issue{Rule: "java:S1", Component: "proj:src/A.java", Hash: "5d41402a", Message: "Remove the unused import \"x\"."}, same: true},
+		{name: "other code", other: sonarqube.Issue{Rule: "java:S1", Component: "proj:src/A.java", Hash: "7d793037", Message: "Remove this unused import."}},
+		{name: "other rule", other: sonarqube.Issue{Rule: "java:S2", Component: "proj:src/A.java", Hash: "5d41402a"}},
+		{name: "other file", other: sonarqube.Issue{Rule: "java:S1", Component: "proj:src/B.java", Hash: "5d41402a"}},
+		{name: "no code", other: sonarqube.Issue{Rule: "java:S1", Component: "proj:src/A.java", Message: "Remove this unused import."}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IssueFingerprint(issue) == IssueFingerprint(tt.other); got != tt.same {
+				t.Fatalf("same fingerprint = %v, want %v", got, tt.same)
+			}
+		})
+	}
+
+	fileLevel := func(message string) sonarqube.Issue {
+		return sonarqube.Issue{Rule: "java:S2", Component: "proj:src/A.java", Message: message}
+	}
+	if IssueFingerprint(fileLevel("Split  this file.")) != IssueFingerprint(fileLevel("Split this file.")) {
+		t.Error("message fallback does not collapse whitespace")
+	}
+	if IssueFingerprint(fileLevel("Split this file.")) == IssueFingerprint(fileLevel("Split that file.")) {
+		t.Error("message fallback ignores the message")
+	}
+}
+
+func TestCompareIssues(t *testing.T) {
+	issue := func(key, severity, code string) IssueItem {
+		item := newIssueItem(sonarqube.Issue{Key: key, Rule: "java:S1", Component: "proj:src/A.java", Severity: severity, Hash: code})
+		item.Message = "message of " + key
+		return item
+	}
+	base := []IssueItem{
+		issue("kept", "MAJOR", "kept"),
+		issue("raised", "MINOR", "raised"),
+		issue("old-key", "MAJOR", "re-created"),
+		issue("fixed", "MAJOR", "fixed"),
+	}
+	head := []IssueItem{
+		issue("kept", "MAJOR", "kept"),
+		issue("raised", "CRITICAL", "raised"),
+		issue("new-key", "MAJOR", "re-created"),
+		issue("new", "MAJOR", "new"),
+	}
+
+	diff := &ReportDiff{}
+	compareIssues(diff, base, head)
+
+	if diff.UnchangedIssues != 2 {
+		t.Errorf("unchanged = %d, want 2", diff.UnchangedIssues)
+	}
+	if diff.MatchedByFingerprint != 1 {
+		t.Errorf("matched by fingerprint = %d, want 1", diff.MatchedByFingerprint)
+	}
+	if len(diff.SeverityChanges) != 1 || diff.SeverityChanges[0].Issue.Key != "raised" {
+		t.Errorf("severity changes = %+v", diff.SeverityChanges)
+	}
+	if len(diff.NewIssues) != 1 || diff.NewIssues[0].Key != "new" {
+		t.Errorf("new issues = %+v", diff.NewIssues)
+	}
+	if len(diff.FixedIssues) != 1 || diff.FixedIssues[0].Key != "fixed" {
+		t.Errorf("fixed issues = %+v", diff.FixedIssues)
+	}
+}
+
+func TestCompareMetricsDebt(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      MetricsSummary
+		head      MetricsSummary
+		dayHours  int
+		wantDelta string
+		wantTrend string
+	}{
+		{
+			name:      "more debt",
+			base:      MetricsSummary{TechnicalDebt: "1d", TechnicalDebtMinutes: 480},
+			head:      MetricsSummary{TechnicalDebt: "2d 2h", TechnicalDebtMinutes: 1080},
+			dayHours:  8,
+			wantDelta: "+1d 2h",
+			wantTrend: TrendWorse,
+		},
+		{
+			name:      "less debt",
+			base:      MetricsSummary{TechnicalDebt: "3h", TechnicalDebtMinutes: 180},
+			head:      MetricsSummary{TechnicalDebt: "45min", TechnicalDebtMinutes: 45},
+			wantDelta: "-2h 15min",
+			wantTrend: TrendBetter,
+		},
+		{
+			name:      "same minutes formatted differently",
+			base:      MetricsSummary{TechnicalDebt: "1d 2h", TechnicalDebtMinutes: 600},
+			head:      MetricsSummary{TechnicalDebt: "1d 4h", TechnicalDebtMinutes: 600},
+			wantTrend: TrendUnchanged,
+		},
+		{
+			name:      "day length changes the display only",
+			base:      MetricsSummary{TechnicalDebtMinutes: 0},
+			head:      MetricsSummary{TechnicalDebtMinutes: 720},
+			dayHours:  6,
+			wantDelta: "+2d",
+			wantTrend: TrendWorse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, d := range compareMetrics(tt.base, tt.head, tt.dayHours) {
+				if d.Metric != "Technical Debt" {
+					continue
+				}
+				if d.Delta != tt.wantDelta || d.Trend != tt.wantTrend {
+					t.Fatalf("delta = %q (%s), want %q (%s)", d.Delta, d.Trend, tt.wantDelta, tt.wantTrend)
+				}
+				return
+			}
+			t.Fatal("no technical debt delta")
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// DefaultWorkingDayHours is the length of a working day when not configured
+const DefaultWorkingDayHours = 8
+
+// sonarDayHours is the length of a day in SonarQube efforts such as "2d",
+// SonarQube's default working day
+const sonarDayHours = 8
+
+// planTopN is the number of rules, files and authors listed in the
+// remediation plan
+const planTopN = 10
+
+// ParseEffort parses a SonarQube effort such as "2d1h30min" into a duration.
+// Unparseable efforts count as zero.
+func ParseEffort(effort string) time.Duration {
+	return parseEffort(effort, sonarDayHours)
+}
+
+// parseEffort parses an effort whose days are dayHours long, such as the
+// formatted debt of earlier reports
+func parseEffort(effort string, dayHours int) time.Duration {
+	var total time.Duration
+	rest := strings.TrimSpace(effort)
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return total
+		}
+		rest = rest[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "min"):
+			total += time.Duration(n) * time.Minute
+			rest = rest[3:]
+		case strings.HasPrefix(rest, "h"):
+			total += time.Duration(n) * time.Hour
+			rest = rest[1:]
+		case strings.HasPrefix(rest, "d"):
+			total += time.Duration(n*dayHours) * time.Hour
+			rest = rest[1:]
+		default:
+			return total
+		}
+		rest = strings.TrimSpace(rest)
+	}
+	return total
+}
+
+// effortMinutes parses a SonarQube effort into minutes
+func effortMinutes(effort string) int {
+	return int(ParseEffort(effort) / time.Minute)
+}
+
+// workingDayHours returns the configured working day length, or the default
+func workingDayHours(hours int) int {
+	if hours <= 0 {
+		return DefaultWorkingDayHours
+	}
+	return hours
+}
+
+// formatEffort formats minutes of effort as minutes, hours and working days,
+// e.g. "45min", "3h 20min" or "2d 4h"
+func formatEffort(minutes, dayHours int) string {
+	dayHours = workingDayHours(dayHours)
+	if minutes < 60 {
+		return fmt.Sprintf("%dmin", minutes)
+	}
+
+	hours := minutes / 60
+	mins := minutes % 60
+
+	if hours < dayHours {
+		if mins > 0 {
+			return fmt.Sprintf("%dh %dmin", hours, mins)
+		}
+		return fmt.Sprintf("%dh", hours)
+	}
+
+	days := hours / dayHours
+	remainingHours := hours % dayHours
+
+	if remainingHours > 0 {
+		return fmt.Sprintf("%dd %dh", days, remainingHours)
+	}
+	return fmt.Sprintf("%dd", days)
+}
+
+// debtMinutes parses a SonarQube technical debt measure in minutes
+func debtMinutes(minutes string) int {
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 {
+		return 0
+	}
+	return m
+}
+
+// formatDebt formats a SonarQube technical debt measure in minutes
+func formatDebt(minutes string, dayHours int) string {
+	m := debtMinutes(minutes)
+	if m == 0 {
+		return "0min"
+	}
+	return formatEffort(m, dayHours)
+}
+
+// formatDebtChange formats a signed remediation effort in minutes
+func formatDebtChange(minutes, dayHours int) string {
+	switch {
+	case minutes > 0:
+		return "+" + formatEffort(minutes, dayHours)
+	case minutes < 0:
+		return "-" + formatEffort(-minutes, dayHours)
+	default:
+		return "0min"
+	}
+}
+
+// EffortGroup is the remediation effort of the issues sharing a severity,
+// type, rule, file or author
+type EffortGroup struct {
+	Key     string `json:"key"`
+	Issues  int    `json:"issues"`
+	Minutes int    `json:"minutes"`
+}
+
+// EffortSummary aggregates the remediation effort of the issues in a report
+type EffortSummary struct {
+	Issues       int `json:"issues"`
+	TotalMinutes int `json:"totalMinutes"`
+
+	// Truncated is set when the project has more open issues than can be
+	// fetched, so the effort only covers the first of them
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Groups are sorted by effort, largest first. Rules, files and authors
+	// are limited to the largest ones.
+	BySeverity []EffortGroup `json:"bySeverity"`
+	ByType     []EffortGroup `json:"byType"`
+	ByRule     []EffortGroup `json:"byRule"`
+	ByFile     []EffortGroup `json:"byFile"`
+	ByAuthor   []EffortGroup `json:"byAuthor"`
+}
+
+// personDays converts minutes of effort into working days
+func personDays(minutes, dayHours int) float64 {
+	return float64(minutes) / float64(workingDayHours(dayHours)*60)
+}
+
+// summarizeEffort aggregates the effort of issues by severity, type, rule,
+// file and author. complete tells whether issues are all the open issues in
+// the report's scope.
+func summarizeEffort(issues []sonarqube.Issue, complete bool) *EffortSummary {
+	summary := &EffortSummary{Truncated: !complete}
+
+	bySeverity := make(map[string]*EffortGroup)
+	byType := make(map[string]*EffortGroup)
+	byRule := make(map[string]*EffortGroup)
+	byFile := make(map[string]*EffortGroup)
+	byAuthor := make(map[string]*EffortGroup)
+
+	add := func(groups map[string]*EffortGroup, key string, minutes int) {
+		if key == "" {
+			key = "(none)"
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &EffortGroup{Key: key}
+			groups[key] = g
+		}
+		g.Issues++
+		g.Minutes += minutes
+	}
+
+	for _, issue := range issues {
+		minutes := effortMinutes(issue.Effort)
+		summary.Issues++
+		summary.TotalMinutes += minutes
+		add(bySeverity, issue.Severity, minutes)
+		add(byType, issue.Type, minutes)
+		add(byRule, issue.Rule, minutes)
+		add(byFile, extractFileName(issue.Component), minutes)
+		add(byAuthor, issue.Author, minutes)
+	}
+
+	summary.BySeverity = sortedEffortGroups(bySeverity, 0)
+	summary.ByType = sortedEffortGroups(byType, 0)
+	summary.ByRule = sortedEffortGroups(byRule, planTopN)
+	summary.ByFile = sortedEffortGroups(byFile, planTopN)
+	summary.ByAuthor = sortedEffortGroups(byAuthor, planTopN)
+	return summary
+}
+
+// sortedEffortGroups sorts groups by effort, keeping the largest max groups
+// (all if max is 0)
+func sortedEffortGroups(groups map[string]*EffortGroup, max int) []EffortGroup {
+	sorted := make([]EffortGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, *g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Minutes != sorted[j].Minutes {
+			return sorted[i].Minutes > sorted[j].Minutes
+		}
+		return sorted[i].Key < sorted[j].Key
+	})
+	if max > 0 && len(sorted) > max {
+		sorted = sorted[:max]
+	}
+	return sorted
+}
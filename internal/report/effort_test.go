@@ -0,0 +1,133 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+func TestParseEffort(t *testing.T) {
+	tests := []struct {
+		effort   string
+		dayHours int
+		want     time.Duration
+	}{
+		{effort: "5min", dayHours: 8, want: 5 * time.Minute},
+		{effort: "2h", dayHours: 8, want: 2 * time.Hour},
+		{effort: "2d1h30min", dayHours: 8, want: 17*time.Hour + 30*time.Minute},
+		{effort: "1h 5min", dayHours: 8, want: time.Hour + 5*time.Minute},
+		{effort: " 3d 4h ", dayHours: 8, want: 28 * time.Hour},
+		{effort: "2d 4h", dayHours: 6, want: 16 * time.Hour},
+		{effort: "", dayHours: 8, want: 0},
+		{effort: "abc", dayHours: 8, want: 0},
+		{effort: "d", dayHours: 8, want: 0},
+		{effort: "1h3x", dayHours: 8, want: time.Hour},
+		{effort: "2h 15", dayHours: 8, want: 2 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.effort, func(t *testing.T) {
+			if got := parseEffort(tt.effort, tt.dayHours); got != tt.want {
+				t.Fatalf("parseEffort(%q, %d) = %v, want %v", tt.effort, tt.dayHours, got, tt.want)
+			}
+		})
+	}
+
+	if got := ParseEffort("1d"); got != sonarDayHours*time.Hour {
+		t.Errorf("ParseEffort(1d) = %v, want SonarQube's %dh day", got, sonarDayHours)
+	}
+}
+
+func TestFormatEffort(t *testing.T) {
+	tests := []struct {
+		minutes  int
+		dayHours int
+		want     string
+	}{
+		{minutes: 0, dayHours: 8, want: "0min"},
+		{minutes: 45, dayHours: 8, want: "45min"},
+		{minutes: 60, dayHours: 8, want: "1h"},
+		{minutes: 200, dayHours: 8, want: "3h 20min"},
+		{minutes: 8 * 60, dayHours: 8, want: "1d"},
+		{minutes: 20 * 60, dayHours: 8, want: "2d 4h"},
+		{minutes: 20 * 60, dayHours: 6, want: "3d 2h"},
+		{minutes: 20 * 60, dayHours: 0, want: "2d 4h"},
+	}
+	for _, tt := range tests {
+		if got := formatEffort(tt.minutes, tt.dayHours); got != tt.want {
+			t.Errorf("formatEffort(%d, %d) = %q, want %q", tt.minutes, tt.dayHours, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDebt(t *testing.T) {
+	tests := []struct {
+		minutes string
+		want    string
+	}{
+		{minutes: "90", want: "1h 30min"},
+		{minutes: "0", want: "0min"},
+		{minutes: "", want: "0min"},
+		{minutes: "-5", want: "0min"},
+		{minutes: "n/a", want: "0min"},
+	}
+	for _, tt := range tests {
+		if got := formatDebt(tt.minutes, 8); got != tt.want {
+			t.Errorf("formatDebt(%q) = %q, want %q", tt.minutes, got, tt.want)
+		}
+	}
+
+	changes := []struct {
+		minutes int
+		want    string
+	}{
+		{minutes: 90, want: "+1h 30min"},
+		{minutes: -600, want: "-1d 2h"},
+		{minutes: 0, want: "0min"},
+	}
+	for _, tt := range changes {
+		if got := formatDebtChange(tt.minutes, 8); got != tt.want {
+			t.Errorf("formatDebtChange(%d) = %q, want %q", tt.minutes, got, tt.want)
+		}
+	}
+}
+
+func TestBuildReportEffortCoversUnlistedIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	g := NewGenerator(sonarqube.NewClient(server.URL, "token"), nil)
+
+	issues := make([]sonarqube.Issue, 600)
+	for i := range issues {
+		issues[i] = sonarqube.Issue{Key: fmt.Sprintf("i%d", i), Rule: "go:S1", Severity: "MAJOR", Type: "CODE_SMELL", Component: "proj:a.go", Effort: "10min"}
+	}
+
+	for _, complete := range []bool{true, false} {
+		data, err := g.buildReport(context.Background(), &reportSource{
+			projectKey:  "proj",
+			qgStatus:    &sonarqube.QualityGateStatus{},
+			issues:      issues,
+			totalIssues: 900,
+			complete:    complete,
+			maxListed:   maxReportIssues,
+		}, GenerateOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := len(data.IssuesBySeverity["MAJOR"]); n != maxReportIssues {
+			t.Errorf("listed %d issues, want %d", n, maxReportIssues)
+		}
+		if data.Effort.Issues != 600 || data.Effort.TotalMinutes != 6000 {
+			t.Errorf("effort covers %d issues and %d minutes, want 600 and 6000", data.Effort.Issues, data.Effort.TotalMinutes)
+		}
+		if data.Effort.Truncated == complete {
+			t.Errorf("truncated = %v for complete = %v", data.Effort.Truncated, complete)
+		}
+	}
+}
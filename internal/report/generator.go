@@ -1,13 +1,16 @@
 package report
 
 import (
+	"context"
 	"fmt"
 	"html"
+	"log"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"sonarqube-report-generator/internal/sonarqube"
@@ -25,23 +28,183 @@ func stripHTMLTags(s string) string {
 
 // Generator generates reports from SonarQube data
 type Generator struct {
-	client *sonarqube.Client
+	client    *sonarqube.Client
+	rules     *RuleCache
+	metrics   *MetricCatalog
+	languages *languageCatalog
 }
 
 // GenerateOptions contains options for report generation
 type GenerateOptions struct {
-	IncludeCodeSnippets bool // Include code snippets in issues (default: true)
-	IncludeHowToFix     bool // Include how to fix info from rules (default: true)
+	IncludeCodeSnippets    bool // Include code snippets in issues (default: true)
+	IncludeHowToFix        bool // Include how to fix info from rules (default: true)
+	MaxSnippetsPerSeverity int  // Max issues per severity enriched with snippets and how to fix (default: 10)
+
+	// AsOf reconstructs the report for a past date (YYYY-MM-DD or a date-time)
+	// or analysis key instead of the current state
+	AsOf string
+
+	// Activity turns the report into an activity report of the issues opened
+	// and resolved during a period
+	Activity *ActivityPeriod
+
+	// ScoreWeights weigh the factors of the issue risk score; nil uses
+	// DefaultScoreWeights
+	ScoreWeights *ScoreWeights
+	TopIssues    int // Number of top scored issues to list (default: DefaultTopIssues)
+
+	WorkingDayHours int // Length of a working day for effort estimates (default: DefaultWorkingDayHours)
+
+	// Policy is the compliance policy the report is checked against; nil
+	// checks none
+	Policy *Policy
+
+	// Baseline separates the issues accepted in a baseline from the new ones
+	Baseline *Baseline
+
+	// Suppressions hide known issues from the report, listing them in an appendix
+	Suppressions *SuppressionList
+
+	// CodeOwners maps issues to their owning teams; Team limits the report
+	// to the issues and hotspots in files owned by one of them
+	CodeOwners *CodeOwners
+	Team       string
+
+	// Redactor masks secrets in issue messages and code snippets; nil masks nothing
+	Redactor *Redactor
+
+	// Metrics are extra metric keys listed with their values in the report
+	Metrics []string
+
+	// Profile names the report profile the options come from. Sections lists
+	// the sections to render (all when empty), Severities and Types keep only
+	// the issues of these severities and types, and Groupings lists the
+	// breakdowns of the remediation plan (all when empty).
+	Profile    string
+	Sections   []string
+	Severities []string
+	Types      []string
+	Groupings  []string
+
+	// Language is the language the report is rendered in (default: DefaultLanguage)
+	Language string
+
+	// Timezone is the IANA time zone the report's times are rendered in, and
+	// in which dates of activity periods and as-of dates are whole days
+	// (default: DefaultTimezone)
+	Timezone string
+
+	Progress ProgressFunc // Optional callback receiving progress updates
 }
 
-// NewGenerator creates a new report generator
-func NewGenerator(client *sonarqube.Client) *Generator {
-	return &Generator{client: client}
+// DefaultMaxSnippetsPerSeverity is used when GenerateOptions.MaxSnippetsPerSeverity is not set
+const DefaultMaxSnippetsPerSeverity = 10
+
+// maxSnippetsPerSeverity caps GenerateOptions.MaxSnippetsPerSeverity, as each
+// snippet may fetch the source of another file
+const maxSnippetsPerSeverity = 50
+
+// ValidateSnippetLimit checks the number of issues per severity enriched with
+// code snippets and how to fix
+func ValidateSnippetLimit(n int) error {
+	if n < 0 {
+		return fmt.Errorf("maxSnippetsPerSeverity must not be negative")
+	}
+	if n > maxSnippetsPerSeverity {
+		return fmt.Errorf("maxSnippetsPerSeverity must be at most %d", maxSnippetsPerSeverity)
+	}
+	return nil
+}
+
+const (
+	// maxReportIssues caps the open issues listed in a report
+	maxReportIssues = 500
+
+	// maxSearchIssues is the most issues SonarQube's issue search returns
+	maxSearchIssues = 10000
+)
+
+// NewGenerator creates a new report generator. Rule descriptions are looked up
+// through the given cache; a nil cache uses an in-memory one for this generator.
+func NewGenerator(client *sonarqube.Client, rules *RuleCache) *Generator {
+	if rules == nil {
+		rules = NewRuleCache(client, "", 0, 24*time.Hour)
+	}
+	return &Generator{
+		client:    client,
+		rules:     rules,
+		metrics:   NewMetricCatalog(client, metricCatalogTTL),
+		languages: newLanguageCatalog(client, languageCatalogTTL),
+	}
+}
+
+// Metrics returns the metric definitions of the server
+func (g *Generator) Metrics() *MetricCatalog {
+	return g.metrics
+}
+
+// MainBranch returns the name of a project's main branch, empty when
+// SonarQube does not report one
+func (g *Generator) MainBranch(projectKey string) string {
+	branches, err := g.client.GetBranches(projectKey)
+	if err != nil {
+		return ""
+	}
+	for _, b := range branches {
+		if b.IsMain {
+			return b.Name
+		}
+	}
+	return ""
 }
 
 // Generate generates a report for a project
 func (g *Generator) Generate(projectKey, branch string, options GenerateOptions) (*ReportData, error) {
+	return g.GenerateContext(context.Background(), projectKey, branch, options)
+}
+
+// GenerateBranches generates the data of two branches of a project in
+// parallel for a side-by-side comparison. An empty branch is the main branch.
+func (g *Generator) GenerateBranches(ctx context.Context, projectKey, baseBranch, headBranch string, options GenerateOptions) (base, head *ReportData, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg               sync.WaitGroup
+		baseErr, headErr error
+	)
+	generate := func(branch, side string, data **ReportData, err *error) {
+		defer wg.Done()
+
+		sideOptions := options
+		sideOptions.Progress = options.Progress.ForSide(side)
+		*data, *err = g.GenerateContext(ctx, projectKey, branch, sideOptions)
+		if *err != nil {
+			// No point finishing the other side
+			cancel()
+		}
+	}
+
+	wg.Add(2)
+	go generate(baseBranch, "base", &base, &baseErr)
+	go generate(headBranch, "head", &head, &headErr)
+	wg.Wait()
+
+	if baseErr != nil {
+		return nil, nil, fmt.Errorf("base branch: %w", baseErr)
+	}
+	if headErr != nil {
+		return nil, nil, fmt.Errorf("head branch: %w", headErr)
+	}
+	return base, head, nil
+}
+
+// GenerateContext generates a report for a project, stopping early when ctx is cancelled
+func (g *Generator) GenerateContext(ctx context.Context, projectKey, branch string, options GenerateOptions) (*ReportData, error) {
+	progress := options.Progress
+
 	// Get project info
+	progress.Report(PhaseProject, 0, 0)
 	projects, err := g.client.GetProjects()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get projects: %w", err)
@@ -60,36 +223,59 @@ func (g *Generator) Generate(projectKey, branch string, options GenerateOptions)
 
 	// If no branch specified, get main branch
 	if branch == "" {
-		branches, err := g.client.GetBranches(projectKey)
-		if err == nil {
-			for _, b := range branches {
-				if b.IsMain {
-					branch = b.Name
-					break
-				}
-			}
-		}
+		branch = g.MainBranch(projectKey)
+	}
+
+	if options.Activity != nil {
+		return g.generateActivity(ctx, projectKey, projectName, branch, options)
+	}
+	if options.AsOf != "" {
+		return g.generateHistorical(ctx, projectKey, projectName, branch, options)
 	}
 
 	// Get quality gate status
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	progress.Report(PhaseQualityGate, 0, 0)
 	qgStatus, err := g.client.GetQualityGateStatus(projectKey, branch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get quality gate status: %w", err)
 	}
 
 	// Get measures
-	measures, err := g.client.GetMeasures(projectKey, branch, sonarqube.DefaultMetricKeys())
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	progress.Report(PhaseMeasures, 0, 0)
+	measures, err := g.client.GetMeasures(projectKey, branch, measureKeys(options.Metrics))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get measures: %w", err)
 	}
 
 	// Get issues
-	issues, totalIssues, err := g.client.GetIssues(projectKey, branch, 500)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Policies, baselines and the remediation plan count all open issues,
+	// not only those listed
+	progress.Report(PhaseIssues, 0, 0)
+	limit := maxReportIssues
+	if options.Policy != nil || options.Baseline != nil || options.shows(SectionRemediation) {
+		limit = maxSearchIssues
+	}
+	issues, totalIssues, err := g.client.GetIssuesWithProgress(projectKey, branch, limit, func(fetched, total int) {
+		progress.Report(PhaseIssues, fetched, total)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get issues: %w", err)
 	}
 
 	// Get hotspots
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	progress.Report(PhaseHotspots, 0, 0)
 	hotspots, totalHotspots, err := g.client.GetHotspots(projectKey, branch, 100)
 	if err != nil {
 		// Hotspots API might not be available in all editions
@@ -99,18 +285,185 @@ func (g *Generator) Generate(projectKey, branch string, options GenerateOptions)
 
 	// Get latest analysis date
 	analyses, err := g.client.GetAnalyses(projectKey, branch, 1)
-	var analysisDate string
+	var analysisDate sonarqube.DateTime
+	var analysisKey string
 	if err == nil && len(analyses) > 0 {
 		analysisDate = analyses[0].Date
+		analysisKey = analyses[0].Key
+	}
+
+	return g.buildReport(ctx, &reportSource{
+		projectKey:    projectKey,
+		projectName:   projectName,
+		branch:        branch,
+		analysisDate:  analysisDate,
+		analysisKey:   analysisKey,
+		qgStatus:      qgStatus,
+		measures:      measures,
+		issues:        issues,
+		totalIssues:   totalIssues,
+		hotspots:      hotspots,
+		totalHotspots: totalHotspots,
+		complete:      len(issues) >= totalIssues,
+		maxListed:     maxReportIssues,
+	}, options)
+}
+
+// generateHistorical reconstructs a report for a point in the past. Metrics and
+// the quality gate status come from the measure history and issues from their
+// creation and close dates; hotspots, gate conditions and code snippets have
+// no history and are left out.
+func (g *Generator) generateHistorical(ctx context.Context, projectKey, projectName, branch string, options GenerateOptions) (*ReportData, error) {
+	progress := options.Progress
+
+	at, analysis, err := g.resolveAsOf(projectKey, branch, options.AsOf, LoadTimezone(options.Timezone))
+	if err != nil {
+		return nil, err
+	}
+
+	// Get measures and quality gate status as of then
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	progress.Report(PhaseMeasures, 0, 0)
+	measures, qgStatus, err := g.historicalMeasures(projectKey, branch, measureKeys(options.Metrics), at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get measures history: %w", err)
+	}
+
+	// Get issues open at the time
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	progress.Report(PhaseIssues, 0, 0)
+	issues, complete, err := g.historicalIssues(projectKey, branch, at, func(fetched, total int) {
+		progress.Report(PhaseIssues, fetched, total)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues: %w", err)
+	}
+
+	// Current source code may differ from the code analyzed then
+	options.IncludeCodeSnippets = false
+
+	reportData, err := g.buildReport(ctx, &reportSource{
+		projectKey:   projectKey,
+		projectName:  projectName,
+		branch:       branch,
+		analysisDate: analysis.Date,
+		analysisKey:  analysis.Key,
+		qgStatus:     qgStatus,
+		measures:     measures,
+		issues:       issues,
+		totalIssues:  len(issues),
+		complete:     complete,
+		asOf:         at,
+	}, options)
+	if err != nil {
+		return nil, err
+	}
+
+	reportData.Historical = &HistoricalInfo{
+		AsOf:          at,
+		Requested:     options.AsOf,
+		Reconstructed: []string{SectionQualityGate, SectionMetrics, SectionIssues},
+		Unavailable:   []string{SectionQualityGateConditions, SectionHotspots, SectionCodeSnippets},
+	}
+	return reportData, nil
+}
+
+// reportSource is the data fetched from SonarQube that a report is built from
+type reportSource struct {
+	projectKey   string
+	projectName  string
+	branch       string
+	analysisDate sonarqube.DateTime
+	analysisKey  string
+
+	qgStatus      *sonarqube.QualityGateStatus
+	measures      []sonarqube.Measure
+	issues        []sonarqube.Issue
+	totalIssues   int
+	hotspots      []sonarqube.Hotspot
+	totalHotspots int
+
+	// complete tells whether issues are all open issues of the project;
+	// maxListed caps the issues listed in the report, 0 for no cap
+	complete  bool
+	maxListed int
+
+	// asOf is the point in time of a historical report, zero for the
+	// current state
+	asOf time.Time
+}
+
+// buildReport turns fetched data into report data, enriching top issues with
+// code snippets and rule descriptions
+func (g *Generator) buildReport(ctx context.Context, src *reportSource, options GenerateOptions) (*ReportData, error) {
+	progress := options.Progress
+	projectKey := src.projectKey
+	qgStatus, measures, issues, hotspots := src.qgStatus, src.measures, src.issues, src.hotspots
+	totalIssues, totalHotspots := src.totalIssues, src.totalHotspots
+	openIssues := issues
+
+	// Leave out suppressed issues; expiry is checked at the time the report describes
+	var suppressed *SuppressionInfo
+	if options.Suppressions != nil && len(options.Suppressions.Suppressions) > 0 {
+		at := src.asOf
+		if at.IsZero() {
+			at = time.Now()
+		}
+		issues, suppressed = options.Suppressions.apply(projectKey, issues, at)
+		totalIssues -= len(suppressed.Issues)
+		src.issues = issues // keep scoring aligned with the issue items
+	}
+
+	// Team reports only cover the files the team owns
+	if options.CodeOwners != nil && options.Team != "" {
+		issues = options.CodeOwners.filterTeam(issues, options.Team)
+		hotspots = options.CodeOwners.filterTeamHotspots(hotspots, options.Team)
+		totalIssues, totalHotspots = len(issues), len(hotspots)
+		src.issues = issues
+	}
+
+	// Profiles may narrow the report down to some severities and types
+	if len(options.Severities) > 0 || len(options.Types) > 0 {
+		issues = filterIssues(issues, options.Severities, options.Types)
+		totalIssues = len(issues)
+		src.issues = issues
+	}
+
+	// Baseline counts and the remediation effort cover all issues in the
+	// report's scope, not only those listed
+	var baselineInfo *BaselineInfo
+	var accepted map[string]bool
+	if options.Baseline != nil {
+		baselineInfo, accepted = matchBaseline(options.Baseline, issues, src.complete)
+	}
+	effort := summarizeEffort(issues, src.complete)
+	effort.keepGroupings(options.Groupings)
+	if src.maxListed > 0 && len(issues) > src.maxListed {
+		issues = issues[:src.maxListed]
+		src.issues = issues
 	}
 
 	// Build report data
 	reportData := &ReportData{
 		ProjectKey:   projectKey,
-		ProjectName:  projectName,
-		Branch:       branch,
+		ProjectName:  src.projectName,
+		Branch:       src.branch,
 		GeneratedAt:  time.Now(),
-		AnalysisDate: analysisDate,
+		AnalysisDate: src.analysisDate,
+		AnalysisKey:  src.analysisKey,
+		Team:         options.Team,
+		Profile:      options.Profile,
+		Sections:     options.Sections,
+		Severities:   options.Severities,
+		Types:        options.Types,
+		Language:     options.Language,
+		Timezone:     options.Timezone,
+
+		WorkingDayHours: workingDayHours(options.WorkingDayHours),
 	}
 
 	// Quality gate
@@ -126,19 +479,60 @@ func (g *Generator) Generate(projectKey, branch string, options GenerateOptions)
 	}
 
 	// Metrics
-	reportData.Metrics = buildMetricsSummary(measures)
+	reportData.Metrics = buildMetricsSummary(measures, reportData.WorkingDayHours)
+	if len(options.Metrics) > 0 {
+		definitions, err := g.metrics.all()
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		reportData.SelectedMetrics = selectedMetrics(options.Metrics, measures, definitions, reportData.WorkingDayHours)
+	}
+
+	// Compliance is checked against the whole project, regardless of the
+	// suppressions, team and filters of the report
+	if options.Policy != nil {
+		at := src.asOf
+		if at.IsZero() {
+			at = reportData.GeneratedAt
+		}
+		policyItems := make([]IssueItem, len(openIssues))
+		for i, issue := range openIssues {
+			policyItems[i] = newIssueItem(issue)
+		}
+		reportData.Policy = options.Policy.evaluate(reportData, policyItems, src.complete, at)
+	}
 
 	// Issues
 	reportData.TotalIssues = totalIssues
+	reportData.Suppressed = suppressed
 	reportData.IssuesByType = make(map[string]int)
 	reportData.IssuesBySeverity = make(map[string][]IssueItem)
 
-	// Cache for rule descriptions to avoid duplicate API calls
-	ruleCache := make(map[string]string)
-	var ruleCacheMu sync.Mutex
+	// Rule descriptions and languages come from the shared cache, warmed in
+	// bulk from the project's quality profiles instead of one request per rule
+	if len(issues) > 0 {
+		g.rules.CheckServerVersion()
+		if err := g.rules.Warm(projectKey); err != nil {
+			log.Printf("Warning: Failed to warm rule cache: %v", err)
+		}
+		defer func() {
+			if err := g.rules.Save(); err != nil {
+				log.Printf("Warning: Failed to save rule cache: %v", err)
+			}
+		}()
+	}
+
+	// Each file is fetched once and shared by all snippets in it
+	sources := newSourceCache(g.client, src.branch)
 
 	// Limit code snippet fetching to top issues per severity (to avoid too many API calls)
-	maxCodeSnippetsPerSeverity := 10
+	maxCodeSnippetsPerSeverity := options.MaxSnippetsPerSeverity
+	if maxCodeSnippetsPerSeverity <= 0 {
+		maxCodeSnippetsPerSeverity = DefaultMaxSnippetsPerSeverity
+	}
+	if maxCodeSnippetsPerSeverity > maxSnippetsPerSeverity {
+		maxCodeSnippetsPerSeverity = maxSnippetsPerSeverity
+	}
 
 	// First pass: create all issue items and count issues for snippet fetching
 	type issueWithIndex struct {
@@ -153,25 +547,8 @@ func (g *Generator) Generate(projectKey, branch string, options GenerateOptions)
 		// Count by type
 		reportData.IssuesByType[issue.Type]++
 
-		// Determine end line from TextRange
-		endLine := issue.Line
-		if issue.TextRange != nil {
-			endLine = issue.TextRange.EndLine
-		}
-
 		// Create issue item
-		issueItems[i] = IssueItem{
-			Key:       issue.Key,
-			Type:      issue.Type,
-			Severity:  issue.Severity,
-			Message:   issue.Message,
-			Component: extractFileName(issue.Component),
-			Line:      issue.Line,
-			EndLine:   endLine,
-			Effort:    issue.Effort,
-			Rule:      issue.Rule,
-			Language:  getLanguageFromFile(issue.Component),
-		}
+		issueItems[i] = newIssueItem(issue)
 
 		// Track which issues need code snippet fetching (only if enabled)
 		if options.IncludeCodeSnippets || options.IncludeHowToFix {
@@ -188,6 +565,8 @@ func (g *Generator) Generate(projectKey, branch string, options GenerateOptions)
 		const numWorkers = 5 // Limit concurrent API calls
 		jobs := make(chan issueWithIndex, len(issuesToFetch))
 		var wg sync.WaitGroup
+		var fetched int32
+		progress.Report(PhaseSnippets, 0, len(issuesToFetch))
 
 		// Start workers
 		for w := 0; w < numWorkers; w++ {
@@ -195,15 +574,22 @@ func (g *Generator) Generate(projectKey, branch string, options GenerateOptions)
 			go func() {
 				defer wg.Done()
 				for job := range jobs {
+					// Drain remaining jobs without fetching once cancelled
+					if ctx.Err() != nil {
+						continue
+					}
+
 					// Fetch code snippet if enabled
 					if options.IncludeCodeSnippets {
-						issueItems[job.index].CodeSnippet = g.fetchCodeSnippet(job.issue)
+						issueItems[job.index].CodeSnippet = g.fetchCodeSnippet(job.issue, sources)
 					}
 
 					// Fetch how to fix from rule if enabled
 					if options.IncludeHowToFix {
-						issueItems[job.index].HowToFix = g.fetchHowToFix(job.issue.Rule, ruleCache, &ruleCacheMu)
+						issueItems[job.index].HowToFix = g.rules.HowToFix(job.issue.Rule)
 					}
+
+					progress.Report(PhaseSnippets, int(atomic.AddInt32(&fetched, 1)), len(issuesToFetch))
 				}
 			}()
 		}
@@ -216,6 +602,63 @@ func (g *Generator) Generate(projectKey, branch string, options GenerateOptions)
 
 		// Wait for all workers to complete
 		wg.Wait()
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Languages come from the rules, cached by now. The component tree is
+	// only walked for issues whose rule has no language.
+	var fileLanguages map[string]string
+	if g.needFileLanguages(issueItems) {
+		var err error
+		fileLanguages, err = g.fetchFileLanguages(projectKey, src.branch)
+		if err != nil {
+			log.Printf("Warning: Failed to get file languages: %v", err)
+		}
+	}
+	g.assignLanguages(issueItems, fileLanguages)
+
+	// Mask secrets before anything is copied from the issue items
+	red := options.Redactor.begin()
+	red.items(issueItems)
+	red.flows(issues)
+	if suppressed != nil {
+		for i := range suppressed.Issues {
+			suppressed.Issues[i].Message = red.text(suppressed.Issues[i].Message)
+		}
+	}
+
+	// Issues accepted in the baseline
+	if baselineInfo != nil {
+		for i := range issueItems {
+			issueItems[i].InBaseline = accepted[issueItems[i].Key]
+		}
+		reportData.Baseline = baselineInfo
+	}
+
+	// Owning teams
+	if options.CodeOwners != nil {
+		reportData.Owners = assignOwners(options.CodeOwners, issueItems)
+	}
+
+	reportData.Effort = effort
+
+	// Risk scores and the top issues to fix
+	if err := g.scoreIssues(ctx, reportData, src, issueItems, sources, options); err != nil {
+		return nil, err
+	}
+
+	// Lines of code and issues per language
+	var distribution string
+	for _, m := range measures {
+		if m.Metric == "ncloc_language_distribution" {
+			distribution = m.Value
+		}
+	}
+	if distribution != "" || len(issueItems) > 0 {
+		reportData.Languages = summarizeLanguages(distribution, issueItems, g.languages.all())
 	}
 
 	// Group issues by severity
@@ -234,21 +677,54 @@ func (g *Generator) Generate(projectKey, branch string, options GenerateOptions)
 			SecurityCategory:         hotspot.SecurityCategory,
 			VulnerabilityProbability: hotspot.VulnerabilityProbability,
 			Status:                   hotspot.Status,
-			Message:                  hotspot.Message,
+			Message:                  red.text(hotspot.Message),
 			Component:                extractFileName(hotspot.Component),
 			Line:                     hotspot.Line,
 		})
 	}
+	reportData.Redactions = red.info()
 
 	return reportData, nil
 }
 
-func buildMetricsSummary(measures []sonarqube.Measure) MetricsSummary {
+// newIssueItem converts a SonarQube issue into an issue for display
+func newIssueItem(issue sonarqube.Issue) IssueItem {
+	// Determine end line from TextRange
+	endLine := issue.Line
+	if issue.TextRange != nil {
+		endLine = issue.TextRange.EndLine
+	}
+
+	return IssueItem{
+		Key:       issue.Key,
+		Type:      issue.Type,
+		Severity:  issue.Severity,
+		Message:   issue.Message,
+		Component: extractFileName(issue.Component),
+		Line:      issue.Line,
+		EndLine:   endLine,
+		Effort:    issue.Effort,
+		Rule:      issue.Rule,
+		Language:  getLanguageFromFile(issue.Component),
+
+		CreationDate:  issue.CreationDate,
+		Tags:          issue.Tags,
+		Author:        issue.Author,
+		EffortMinutes: effortMinutes(issue.Effort),
+		Fingerprint:   IssueFingerprint(issue),
+	}
+}
+
+func buildMetricsSummary(measures []sonarqube.Measure, dayHours int) MetricsSummary {
 	summary := MetricsSummary{}
 
 	metricsMap := make(map[string]string)
 	for _, m := range measures {
-		metricsMap[m.Metric] = m.Value
+		value := m.Value
+		if value == "" && m.Period != nil {
+			value = m.Period.Value // new code metrics
+		}
+		metricsMap[m.Metric] = value
 	}
 
 	summary.Bugs = getMetricValue(metricsMap, "bugs", "0")
@@ -257,7 +733,8 @@ func buildMetricsSummary(measures []sonarqube.Measure) MetricsSummary {
 	summary.Coverage = formatPercentage(getMetricValue(metricsMap, "coverage", "0"))
 	summary.DuplicatedLinesDensity = formatPercentage(getMetricValue(metricsMap, "duplicated_lines_density", "0"))
 	summary.LinesOfCode = getMetricValue(metricsMap, "ncloc", "0")
-	summary.TechnicalDebt = formatDebt(getMetricValue(metricsMap, "sqale_index", "0"))
+	summary.TechnicalDebt = formatDebt(getMetricValue(metricsMap, "sqale_index", "0"), dayHours)
+	summary.TechnicalDebtMinutes = debtMinutes(getMetricValue(metricsMap, "sqale_index", "0"))
 
 	summary.ReliabilityRating = RatingToLetter(getMetricValue(metricsMap, "reliability_rating", "1"))
 	summary.SecurityRating = RatingToLetter(getMetricValue(metricsMap, "security_rating", "1"))
@@ -287,37 +764,6 @@ func formatPercentage(value string) string {
 	return value + "%"
 }
 
-func formatDebt(minutes string) string {
-	if minutes == "" || minutes == "0" {
-		return "0min"
-	}
-
-	var m int
-	fmt.Sscanf(minutes, "%d", &m)
-
-	if m < 60 {
-		return fmt.Sprintf("%dmin", m)
-	}
-
-	hours := m / 60
-	mins := m % 60
-
-	if hours < 24 {
-		if mins > 0 {
-			return fmt.Sprintf("%dh %dmin", hours, mins)
-		}
-		return fmt.Sprintf("%dh", hours)
-	}
-
-	days := hours / 8 // 8 hour work day
-	remainingHours := hours % 8
-
-	if remainingHours > 0 {
-		return fmt.Sprintf("%dd %dh", days, remainingHours)
-	}
-	return fmt.Sprintf("%dd", days)
-}
-
 func extractFileName(component string) string {
 	// Component is usually in format "project:src/path/file.go"
 	parts := strings.SplitN(component, ":", 2)
@@ -339,8 +785,8 @@ func GetSortedSeverities(issuesBySeverity map[string][]IssueItem) []string {
 	return severities
 }
 
-// fetchCodeSnippet fetches source code for an issue
-func (g *Generator) fetchCodeSnippet(issue sonarqube.Issue) string {
+// fetchCodeSnippet extracts source code for an issue from the generation's source cache
+func (g *Generator) fetchCodeSnippet(issue sonarqube.Issue, sources *sourceCache) string {
 	// Use the issue's reported location as the primary source
 	issueStartLine := issue.Line
 	issueEndLine := issue.Line
@@ -369,7 +815,7 @@ func (g *Generator) fetchCodeSnippet(issue sonarqube.Issue) string {
 	// Handle special case: no line number specified
 	if issueStartLine == 0 {
 		// For issues like "Add a new line at the end of file", show beginning of file
-		sourceLines, err := g.client.GetSourceCode(component, 1, 10)
+		sourceLines, err := sources.lines(component, 1, 10)
 		if err != nil || len(sourceLines) == 0 {
 			return ""
 		}
@@ -389,7 +835,7 @@ func (g *Generator) fetchCodeSnippet(issue sonarqube.Issue) string {
 	}
 	endLine := issueEndLine + 3
 
-	sourceLines, err := g.client.GetSourceCode(component, startLine, endLine)
+	sourceLines, err := sources.lines(component, startLine, endLine)
 	if err != nil {
 		return ""
 	}
@@ -408,38 +854,6 @@ func (g *Generator) fetchCodeSnippet(issue sonarqube.Issue) string {
 	return strings.TrimSuffix(codeBuilder.String(), "\n")
 }
 
-// fetchHowToFix fetches rule description
-func (g *Generator) fetchHowToFix(ruleKey string, ruleCache map[string]string, mu *sync.Mutex) string {
-	// Check cache first
-	mu.Lock()
-	if desc, ok := ruleCache[ruleKey]; ok {
-		mu.Unlock()
-		return desc
-	}
-	mu.Unlock()
-
-	rule, err := g.client.GetRule(ruleKey)
-	if err != nil {
-		return ""
-	}
-
-	// Extract "How to fix" or use description
-	description := rule.MdDesc
-	if description == "" {
-		description = stripHTML(rule.HtmlDesc)
-	}
-
-	// Try to extract "How to fix" section
-	howToFix := extractHowToFix(description)
-
-	// Cache the result
-	mu.Lock()
-	ruleCache[ruleKey] = howToFix
-	mu.Unlock()
-
-	return howToFix
-}
-
 // stripHTML removes HTML tags from a string
 func stripHTML(html string) string {
 	re := regexp.MustCompile(`<[^>]*>`)
@@ -472,62 +886,3 @@ func extractHowToFix(description string) string {
 	}
 	return description
 }
-
-// getLanguageFromFile determines programming language from file extension
-func getLanguageFromFile(filename string) string {
-	ext := strings.ToLower(filepath.Ext(filename))
-	switch ext {
-	case ".go":
-		return "go"
-	case ".java":
-		return "java"
-	case ".js":
-		return "javascript"
-	case ".ts":
-		return "typescript"
-	case ".py":
-		return "python"
-	case ".rb":
-		return "ruby"
-	case ".php":
-		return "php"
-	case ".cs":
-		return "csharp"
-	case ".cpp", ".cc", ".cxx":
-		return "cpp"
-	case ".c", ".h":
-		return "c"
-	case ".swift":
-		return "swift"
-	case ".kt":
-		return "kotlin"
-	case ".rs":
-		return "rust"
-	case ".scala":
-		return "scala"
-	case ".vue":
-		return "vue"
-	case ".jsx":
-		return "jsx"
-	case ".tsx":
-		return "tsx"
-	case ".html":
-		return "html"
-	case ".css":
-		return "css"
-	case ".scss":
-		return "scss"
-	case ".sql":
-		return "sql"
-	case ".xml":
-		return "xml"
-	case ".yaml", ".yml":
-		return "yaml"
-	case ".json":
-		return "json"
-	case ".sh":
-		return "bash"
-	default:
-		return ""
-	}
-}
@@ -0,0 +1,224 @@
+package report
+
+import (
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// syntax describes the tokens of a family of languages well enough to colour
+// code snippets
+type syntax struct {
+	lineComments []string
+	blockComment [2]string // start and end, empty when the family has none
+	quotes       string
+	keywords     map[string]bool
+	ignoreCase   bool // keywords match in any case
+}
+
+func keywordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+var (
+	cLikeSyntax = &syntax{
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'`",
+		keywords: keywordSet(`abstract as async await break case catch chan class const continue
+			def default defer delete do else enum export extends false final finally fn for foreach
+			func function go goto if impl implements import in instanceof interface internal let
+			map match mod mut namespace new nil null object of override package private protected
+			public pub range readonly return select self static struct super switch this throw
+			throws true try type typeof use using val var void when where while yield`),
+	}
+	hashSyntax = &syntax{
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+		keywords: keywordSet(`and as assert begin break case class continue def del do done elif
+			else elsif end ensure esac except export false fi finally for from function global if
+			import in is lambda local module nil none not or pass raise rescue return self then
+			true try unless until while with yield None True False FROM RUN CMD COPY ADD ENV ARG
+			WORKDIR EXPOSE ENTRYPOINT USER LABEL VOLUME`),
+	}
+	sqlSyntax = &syntax{
+		lineComments: []string{"--"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "'\"",
+		ignoreCase:   true,
+		keywords: keywordSet(`add all alter and as asc begin between by case create declare
+			delete desc distinct drop else end exec exists from group having if in index inner
+			insert into is join key left like limit not null on or order outer primary procedure
+			return right select set table then union update values view when where with`),
+	}
+)
+
+// syntaxes maps the languages of issues to their family; other languages
+// are coloured as C-like
+var syntaxes = map[string]*syntax{
+	"python":     hashSyntax,
+	"ruby":       hashSyntax,
+	"bash":       hashSyntax,
+	"yaml":       hashSyntax,
+	"dockerfile": hashSyntax,
+	"makefile":   hashSyntax,
+	"perl":       hashSyntax,
+	"r":          hashSyntax,
+	"sql":        sqlSyntax,
+}
+
+var snippetLinePattern = regexp.MustCompile(`^(> |  )(\d+): ?(.*)$`)
+
+// highlightSnippet renders a code snippet as HTML, one line per element with
+// its number, the lines of the issue marked and the tokens of the language
+// coloured. Snippets of unknown language are not coloured.
+func highlightSnippet(snippet, language string) template.HTML {
+	syn := syntaxes[language]
+	if syn == nil && language != "" {
+		syn = cLikeSyntax
+	}
+
+	var b strings.Builder
+	inComment := false
+	for _, line := range strings.Split(snippet, "\n") {
+		class, number, code := "line", "", line
+		if m := snippetLinePattern.FindStringSubmatch(line); m != nil {
+			number, code = m[2], m[3]
+			if m[1] == "> " {
+				class = "line hit"
+			}
+		}
+
+		b.WriteString(`<span class="` + class + `"><span class="ln">` + number + `</span>`)
+		if syn == nil {
+			b.WriteString(template.HTMLEscapeString(code))
+		} else {
+			inComment = syn.highlight(&b, code, inComment)
+		}
+		b.WriteString("</span>\n")
+	}
+	return template.HTML(b.String())
+}
+
+// highlight writes a line of code as HTML with its tokens in spans. inComment
+// tells whether the line starts inside a block comment; the result whether
+// the next one does.
+func (s *syntax) highlight(b *strings.Builder, code string, inComment bool) bool {
+	var plain strings.Builder
+	flush := func() {
+		b.WriteString(template.HTMLEscapeString(plain.String()))
+		plain.Reset()
+	}
+	token := func(class, text string) {
+		flush()
+		b.WriteString(`<span class="tok-` + class + `">` + template.HTMLEscapeString(text) + `</span>`)
+	}
+
+	i := 0
+	if inComment {
+		end := strings.Index(code, s.blockComment[1])
+		if end < 0 {
+			token("com", code)
+			return true
+		}
+		i = end + len(s.blockComment[1])
+		token("com", code[:i])
+	}
+
+	for i < len(code) {
+		rest := code[i:]
+
+		if s.isLineComment(rest) {
+			token("com", rest)
+			break
+		}
+
+		if start := s.blockComment[0]; start != "" && strings.HasPrefix(rest, start) {
+			end := strings.Index(rest[len(start):], s.blockComment[1])
+			if end < 0 {
+				token("com", rest)
+				return true
+			}
+			n := len(start) + end + len(s.blockComment[1])
+			token("com", rest[:n])
+			i += n
+			continue
+		}
+
+		c := code[i]
+		switch {
+		case strings.IndexByte(s.quotes, c) >= 0:
+			n := quotedLength(rest)
+			token("str", rest[:n])
+			i += n
+		case isDigit(c) && (i == 0 || !isWordByte(code[i-1])):
+			n := 1
+			for n < len(rest) && (isWordByte(rest[n]) || rest[n] == '.') {
+				n++
+			}
+			token("num", rest[:n])
+			i += n
+		case isWordByte(c) && (i == 0 || !isWordByte(code[i-1])):
+			n := 1
+			for n < len(rest) && isWordByte(rest[n]) {
+				n++
+			}
+			word := rest[:n]
+			if s.isKeyword(word) {
+				token("kw", word)
+			} else {
+				plain.WriteString(word)
+			}
+			i += n
+		default:
+			plain.WriteByte(c)
+			i++
+		}
+	}
+
+	flush()
+	return false
+}
+
+func (s *syntax) isLineComment(code string) bool {
+	for _, prefix := range s.lineComments {
+		if strings.HasPrefix(code, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *syntax) isKeyword(word string) bool {
+	if s.ignoreCase {
+		word = strings.ToLower(word)
+	}
+	return s.keywords[word]
+}
+
+// quotedLength returns the length of the string literal code starts with,
+// up to the end of the line when it is not closed
+func quotedLength(code string) int {
+	quote := code[0]
+	for i := 1; i < len(code); i++ {
+		switch code[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(code)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// markupPattern matches the spans highlightSnippet writes itself
+var markupPattern = regexp.MustCompile(`<span class="[a-z -]+">|</span>`)
+
+func TestHighlightSnippetEscapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		snippet  string
+		language string
+		want     string
+	}{
+		{
+			name:     "plain code",
+			snippet:  "> 3: if a < b && c > d {",
+			language: "go",
+			want:     `<span class="tok-kw">if</span> a &lt; b &amp;&amp; c &gt; d {`,
+		},
+		{
+			name:     "string",
+			snippet:  `  4: x := "<script>alert('x')</script>"`,
+			language: "go",
+			want:     `<span class="tok-str">&#34;&lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt;&#34;</span>`,
+		},
+		{
+			name:     "line comment",
+			snippet:  "  5: // <b>bold</b> & \"quoted\"",
+			language: "go",
+			want:     `<span class="tok-com">// &lt;b&gt;bold&lt;/b&gt; &amp; &#34;quoted&#34;</span>`,
+		},
+		{
+			name:     "block comment",
+			snippet:  "  6: /* </span><img src=x> */",
+			language: "java",
+			want:     `<span class="tok-com">/* &lt;/span&gt;&lt;img src=x&gt; */</span>`,
+		},
+		{
+			name:     "hash comment",
+			snippet:  "  7: # <tag> & 'x'",
+			language: "python",
+			want:     `<span class="tok-com"># &lt;tag&gt; &amp; &#39;x&#39;</span>`,
+		},
+		{
+			name:     "unknown language",
+			snippet:  "  8: <div class=\"a\">&nbsp;</div>",
+			language: "",
+			want:     `&lt;div class=&#34;a&#34;&gt;&amp;nbsp;&lt;/div&gt;`,
+		},
+		{
+			name:     "unclosed string",
+			snippet:  `  9: s = "<a href='\`,
+			language: "go",
+			want:     `<span class="tok-str">&#34;&lt;a href=&#39;\</span>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(highlightSnippet(tt.snippet, tt.language))
+			if !strings.Contains(got, tt.want) {
+				t.Fatalf("highlightSnippet(%q) = %q, want it to contain %q", tt.snippet, got, tt.want)
+			}
+			if text := markupPattern.ReplaceAllString(got, ""); strings.ContainsAny(text, `<>"'`) {
+				t.Fatalf("unescaped markup in %q", text)
+			}
+		})
+	}
+}
+
+func TestHighlightSnippetLines(t *testing.T) {
+	got := string(highlightSnippet("  1: /* a\n> 2: b */ return\n  3: x", "go"))
+	want := `<span class="line"><span class="ln">1</span><span class="tok-com">/* a</span></span>
+<span class="line hit"><span class="ln">2</span><span class="tok-com">b */</span> <span class="tok-kw">return</span></span>
+<span class="line"><span class="ln">3</span>x</span>
+`
+	if got != want {
+		t.Fatalf("highlightSnippet() =\n%s\nwant\n%s", got, want)
+	}
+}
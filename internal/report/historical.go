@@ -0,0 +1,188 @@
+package report
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// Report sections marked in historical reports
+const (
+	SectionQualityGate           = "qualityGate"
+	SectionQualityGateConditions = "qualityGateConditions"
+	SectionMetrics               = "metrics"
+	SectionIssues                = "issues"
+	SectionHotspots              = "hotspots"
+	SectionCodeSnippets          = "codeSnippets"
+)
+
+// maxHistoryIssues caps the issues fetched for historical and activity reports.
+// Resolved issues are included, so it is higher than for current reports.
+const maxHistoryIssues = 2000
+
+// HistoricalInfo describes a report reconstructed for a point in the past
+type HistoricalInfo struct {
+	// AsOf is the point in time the report describes
+	AsOf time.Time `json:"asOf"`
+
+	// Requested is the date or analysis key the report was requested for
+	Requested string `json:"requested"`
+
+	// Reconstructed lists the sections rebuilt from history; Unavailable lists
+	// the sections SonarQube keeps no history for
+	Reconstructed []string `json:"reconstructed"`
+	Unavailable   []string `json:"unavailable"`
+}
+
+// IsReconstructed reports whether a section was rebuilt from history
+func (h *HistoricalInfo) IsReconstructed(section string) bool {
+	if h == nil {
+		return false
+	}
+	return containsString(h.Reconstructed, section)
+}
+
+// IsUnavailable reports whether a section could not be reconstructed
+func (h *HistoricalInfo) IsUnavailable(section string) bool {
+	if h == nil {
+		return false
+	}
+	return containsString(h.Unavailable, section)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAsOf parses a point in time given as a date (YYYY-MM-DD, meaning the end
+// of that day) or a date-time, in the given time zone unless it has an
+// offset. It returns false for anything else, which is treated as an
+// analysis key.
+func ParseAsOf(value string, zone *time.Location) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, sonarqube.DateTimeFormat, "2006-01-02T15:04:05"} {
+		if t, err := time.ParseInLocation(layout, value, zone); err == nil {
+			return t, true
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, zone); err == nil {
+		return endOfDay(t), true
+	}
+	return time.Time{}, false
+}
+
+// endOfDay returns the last second of the day starting at t
+func endOfDay(t time.Time) time.Time {
+	return t.AddDate(0, 0, 1).Add(-time.Second)
+}
+
+// resolveAsOf resolves a date or analysis key into a point in time and the
+// analysis describing the project at that time
+func (g *Generator) resolveAsOf(projectKey, branch, asOf string, zone *time.Location) (time.Time, *sonarqube.Analysis, error) {
+	if at, ok := ParseAsOf(asOf, zone); ok {
+		if at.After(time.Now()) {
+			return time.Time{}, nil, fmt.Errorf("as-of date %s is in the future", asOf)
+		}
+		analyses, err := g.client.GetAnalysesUntil(projectKey, branch, at, 1)
+		if err != nil {
+			return time.Time{}, nil, fmt.Errorf("failed to get analyses: %w", err)
+		}
+		if len(analyses) == 0 {
+			return time.Time{}, nil, fmt.Errorf("project has no analysis at or before %s", asOf)
+		}
+		return at, &analyses[0], nil
+	}
+
+	analysis, err := g.client.FindAnalysis(projectKey, branch, asOf)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("failed to find analysis: %w", err)
+	}
+	if analysis.Date.IsZero() {
+		return time.Time{}, nil, fmt.Errorf("analysis %s has no date", asOf)
+	}
+	return analysis.Date.Time, analysis, nil
+}
+
+// historicalMeasures returns the last value of each metric at or before the
+// given time, along with the quality gate status recorded then
+func (g *Generator) historicalMeasures(projectKey, branch string, metricKeys []string, at time.Time) ([]sonarqube.Measure, *sonarqube.QualityGateStatus, error) {
+	metricKeys = append(metricKeys, "alert_status")
+	history, err := g.client.GetMeasuresHistory(projectKey, branch, metricKeys, at)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var measures []sonarqube.Measure
+	qgStatus := &sonarqube.QualityGateStatus{Status: "NONE"}
+	for _, h := range history {
+		value, ok := valueAt(h.History, at)
+		if !ok {
+			continue
+		}
+		if h.Metric == "alert_status" {
+			qgStatus.Status = value
+			continue
+		}
+		measures = append(measures, sonarqube.Measure{Metric: h.Metric, Value: value})
+	}
+
+	return measures, qgStatus, nil
+}
+
+// valueAt returns the last value recorded at or before the given time
+func valueAt(history []sonarqube.HistoryValue, at time.Time) (string, bool) {
+	var value string
+	var found bool
+	var latest time.Time
+	for _, v := range history {
+		date := v.Date.Time
+		if v.Date.IsZero() || date.After(at) || v.Value == "" {
+			continue
+		}
+		if !found || !date.Before(latest) {
+			value, latest, found = v.Value, date, true
+		}
+	}
+	return value, found
+}
+
+// historicalIssues returns the issues that were open at the given time: created
+// before it and not closed or resolved until after it. complete tells whether
+// all issues created before then could be fetched.
+func (g *Generator) historicalIssues(projectKey, branch string, at time.Time, onPage sonarqube.PageFunc) (open []sonarqube.Issue, complete bool, err error) {
+	query := sonarqube.IssueQuery{
+		ProjectKey:    projectKey,
+		Branch:        branch,
+		CreatedBefore: at,
+	}
+	issues, total, err := g.client.SearchIssues(query, maxHistoryIssues, onPage)
+	if err != nil {
+		return nil, false, err
+	}
+	if total > len(issues) {
+		log.Printf("Warning: Historical report for %s uses %d of %d issues", projectKey, len(issues), total)
+	}
+
+	for _, issue := range issues {
+		if openAt(issue, at) {
+			open = append(open, issue)
+		}
+	}
+	return open, total <= len(issues), nil
+}
+
+// openAt reports whether an issue was open at the given time
+func openAt(issue sonarqube.Issue, at time.Time) bool {
+	if issue.CreationDate.IsZero() || issue.CreationDate.After(at) {
+		return false
+	}
+
+	closedAt, ok := resolvedAt(issue)
+	return !ok || closedAt.After(at)
+}
@@ -0,0 +1,747 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// HTMLGenerator generates HTML reports. Each report is a single file with
+// its styles, icons and scripts inline, so it can be mailed or archived and
+// opened offline.
+type HTMLGenerator struct{}
+
+// NewHTMLGenerator creates a new HTML generator
+func NewHTMLGenerator() *HTMLGenerator {
+	return &HTMLGenerator{}
+}
+
+// Format describes HTML reports
+func (g *HTMLGenerator) Format() ReportFormat {
+	return ReportFormat{
+		Name:        "html",
+		Title:       "HTML",
+		Extension:   "html",
+		ContentType: "text/html; charset=utf-8",
+	}
+}
+
+// Generate generates an HTML report
+func (g *HTMLGenerator) Generate(data *ReportData) ([]byte, error) {
+	if data.Activity != nil {
+		return g.generateActivity(data)
+	}
+
+	return executeHTML(htmlTemplate, NewLocale(data.Language, data.Timezone), data.WorkingDayHours, data)
+}
+
+// executeHTML renders the content templates of a report in the page layout
+func executeHTML(content string, loc *Locale, dayHours int, data interface{}) ([]byte, error) {
+	tmpl, err := template.New("page").Funcs(template.FuncMap(localeFuncs(loc, dayHours))).Funcs(htmlFuncs(loc)).Parse(htmlLayout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	if _, err := tmpl.Parse(content); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// htmlFuncs are the template functions of HTML reports besides localeFuncs,
+// which they override to render messages, icons and emphasis as HTML
+func htmlFuncs(loc *Locale) template.FuncMap {
+	return template.FuncMap{
+		// Messages are escaped like their arguments, except those already HTML
+		"t": func(key string, args ...interface{}) template.HTML {
+			msg := template.HTMLEscapeString(loc.T(key))
+			if len(args) == 0 {
+				return template.HTML(msg)
+			}
+			for i, arg := range args {
+				switch v := arg.(type) {
+				case template.HTML:
+					args[i] = string(v)
+				case string:
+					args[i] = template.HTMLEscapeString(v)
+				}
+			}
+			return template.HTML(fmt.Sprintf(msg, args...))
+		},
+		"bold": func(v interface{}) template.HTML {
+			return template.HTML("<strong>" + htmlText(v) + "</strong>")
+		},
+		"code": func(v interface{}) template.HTML {
+			return template.HTML("<code>" + htmlText(v) + "</code>")
+		},
+		"lang": loc.Language,
+		"icon": func(name, color string) template.HTML {
+			return template.HTML(icon(name, color))
+		},
+		"severityIcon": func(severity string) template.HTML {
+			return template.HTML(severityIcon(loc, severity))
+		},
+		"qualityGateIcon": func(status string) template.HTML {
+			return template.HTML(qualityGateIcon(status))
+		},
+		"ratingIcon": func(rating string) template.HTML {
+			return template.HTML(ratingIcon(rating))
+		},
+		"priorityIcon": func(priority string) template.HTML {
+			return template.HTML(priorityIcon(priority))
+		},
+		"trendIcon": func(trend string) template.HTML {
+			return template.HTML(trendIcon(trend))
+		},
+		"typeIcon": func(issueType string) template.HTML {
+			switch issueType {
+			case "BUG":
+				return template.HTML(icon("bug", "danger"))
+			case "VULNERABILITY":
+				return template.HTML(icon("shield", "warning"))
+			default:
+				return template.HTML(icon("broom", "info"))
+			}
+		},
+		"highlight":     highlightSnippet,
+		"severityOrder": SeverityOrder,
+		"getSortedSeverities": func(m map[string][]IssueItem) []string {
+			return GetSortedSeverities(m)
+		},
+		"issueCount": func(m map[string][]IssueItem, sev string) int {
+			return len(m[sev])
+		},
+		"severityGroups": func(groups []EffortGroup) []EffortGroup {
+			return renameGroups(groups, loc.Severity)
+		},
+		"typeGroups": func(groups []EffortGroup) []EffortGroup {
+			return renameGroups(groups, loc.IssueType)
+		},
+		"effortTable": func(title template.HTML, groups []EffortGroup) map[string]interface{} {
+			return map[string]interface{}{"Title": title, "Groups": groups}
+		},
+		"languageName": func(l LanguageSummary) string {
+			if l.Key == "" {
+				return loc.T("languages.unknown")
+			}
+			return l.Name
+		},
+		"issueSection": func(title, iconName, color string, issues []IssueItem, open bool) map[string]interface{} {
+			return map[string]interface{}{"Title": title, "Icon": iconName, "Color": color, "Issues": issues, "Open": open}
+		},
+		"hasDetails": func(issue IssueItem) bool {
+			return issue.CodeSnippet != "" || issue.HowToFix != ""
+		},
+		"share": func(count, total int) float64 {
+			if total == 0 {
+				return 0
+			}
+			return float64(count) / float64(total) * 100
+		},
+		"truncate": truncateString,
+		"join":     strings.Join,
+		"orDash":   orDash,
+		"add": func(a, b int) int {
+			return a + b
+		},
+		"sub": func(a, b int) int {
+			return a - b
+		},
+		"neg": func(a int) int {
+			return -a
+		},
+	}
+}
+
+// htmlText returns a value as HTML, escaping it unless it already is HTML
+func htmlText(v interface{}) string {
+	if h, ok := v.(template.HTML); ok {
+		return string(h)
+	}
+	return template.HTMLEscapeString(fmt.Sprint(v))
+}
+
+// htmlLayout is the page of HTML reports; the content templates define its
+// "title" and "content". Sections are collapsible, tables of class
+// "sortable" sort by the data-sort value or text of the clicked column, and
+// printing expands every section.
+const htmlLayout = `<!DOCTYPE html>
+<html lang="{{ lang }}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ template "title" . }}</title>
+<style>
+:root { --fg: #1f2937; --muted: #6b7280; --border: #e5e7eb; --bg: #f9fafb; --head: #f3f4f6; --accent: #3b82f6; --ok: #22c55e; --warn: #f59e0b; --bad: #ef4444; }
+* { box-sizing: border-box; }
+body { margin: 0; font: 14px/1.5 -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, Helvetica, Arial, sans-serif; color: var(--fg); background: var(--bg); }
+main { max-width: 1100px; margin: 0 auto; padding: 8px 24px 24px; }
+h1 { font-size: 26px; margin: 16px 0 4px; }
+h2 { font-size: 19px; margin: 0; display: inline; }
+h3 { font-size: 16px; margin: 20px 0 8px; }
+h4 { font-size: 14px; margin: 0 0 6px; }
+.subtitle { color: var(--muted); margin: 0 0 8px; font-size: 16px; }
+.icon { width: 1.15em; height: 1.15em; vertical-align: -0.2em; }
+.toolbar { position: sticky; top: 0; z-index: 1; display: flex; gap: 8px; justify-content: flex-end; padding: 8px 24px; background: rgba(249, 250, 251, 0.95); border-bottom: 1px solid var(--border); }
+.toolbar button { font: inherit; font-size: 13px; padding: 4px 12px; border: 1px solid var(--border); border-radius: 6px; background: #fff; color: var(--fg); cursor: pointer; }
+.toolbar button:hover { border-color: var(--accent); color: var(--accent); }
+details.section { background: #fff; border: 1px solid var(--border); border-radius: 8px; margin: 16px 0; padding: 0 20px; }
+details.section > summary { cursor: pointer; list-style: none; padding: 14px 0; }
+details.section > summary::-webkit-details-marker { display: none; }
+details.section > summary::before { content: "\25B8"; display: inline-block; width: 1.2em; color: var(--muted); }
+details.section[open] > summary::before { content: "\25BE"; }
+details.section[open] { padding-bottom: 12px; }
+details.more > summary { cursor: pointer; color: var(--accent); margin: 8px 0; }
+table { border-collapse: collapse; width: 100%; margin: 8px 0 16px; }
+th, td { border-bottom: 1px solid var(--border); padding: 6px 8px; text-align: left; vertical-align: top; }
+th { background: var(--head); font-weight: 600; white-space: nowrap; }
+td.num, th.num { text-align: right; white-space: nowrap; }
+td.center, th.center { text-align: center; }
+table.info th { width: 220px; background: none; }
+table.sortable th { cursor: pointer; user-select: none; }
+table.sortable th[aria-sort="ascending"]::after { content: " \25B2"; font-size: 10px; }
+table.sortable th[aria-sort="descending"]::after { content: " \25BC"; font-size: 10px; }
+code { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 12.5px; background: var(--head); padding: 1px 4px; border-radius: 4px; word-break: break-all; }
+.note { color: var(--muted); font-style: italic; }
+.callout { border-left: 4px solid var(--accent); background: #eff6ff; padding: 8px 12px; margin: 12px 0; border-radius: 0 6px 6px 0; }
+.callout.ok { border-color: var(--ok); background: #f0fdf4; }
+.callout.warn { border-color: var(--warn); background: #fffbeb; }
+.callout.bad { border-color: var(--bad); background: #fef2f2; }
+.cards { display: grid; grid-template-columns: repeat(auto-fit, minmax(180px, 1fr)); gap: 12px; margin: 8px 0 16px; }
+.card { border: 1px solid var(--border); border-radius: 8px; padding: 12px; }
+.card .value { font-size: 24px; font-weight: 700; }
+.card .label { color: var(--muted); }
+.issue { border: 1px solid var(--border); border-radius: 6px; padding: 10px 14px; margin: 10px 0; }
+.meta { color: var(--muted); font-size: 13px; margin-bottom: 6px; }
+.fix { white-space: pre-wrap; border-left: 3px solid var(--warn); padding: 4px 10px; margin: 6px 0; }
+pre.code { background: #0f172a; color: #e2e8f0; padding: 8px 0; border-radius: 6px; overflow-x: auto; font: 12.5px/1.5 ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; margin: 6px 0; }
+pre.code .line { display: block; padding: 0 12px; white-space: pre; }
+pre.code .line.hit { background: rgba(239, 68, 68, 0.28); }
+pre.code .ln { display: inline-block; min-width: 3em; margin-right: 12px; color: #64748b; text-align: right; user-select: none; }
+.tok-kw { color: #c084fc; }
+.tok-str { color: #86efac; }
+.tok-num { color: #fbbf24; }
+.tok-com { color: #94a3b8; font-style: italic; }
+footer { color: var(--muted); text-align: center; margin: 24px 0 8px; font-size: 13px; }
+@media print {
+  body { background: #fff; font-size: 11px; }
+  .toolbar, .hint { display: none; }
+  main { max-width: none; padding: 0; }
+  details.section { border: none; padding: 0; margin: 12px 0; }
+  details > summary::before { content: none !important; }
+  h2, h3, h4 { break-after: avoid; }
+  tr, .issue, pre.code { break-inside: avoid; }
+  pre.code { background: #f8fafc; color: #111827; border: 1px solid var(--border); }
+  pre.code .line { white-space: pre-wrap; }
+  pre.code .line.hit { background: #fee2e2; }
+  .tok-kw { color: #7c3aed; }
+  .tok-str { color: #15803d; }
+  .tok-num { color: #b45309; }
+  .tok-com { color: #64748b; }
+}
+</style>
+</head>
+<body>
+<div class="toolbar">
+<button type="button" data-expand="true">{{ t "common.expandAll" }}</button>
+<button type="button" data-expand="false">{{ t "common.collapseAll" }}</button>
+<button type="button" data-print>{{ t "common.print" }}</button>
+</div>
+<main>
+{{ template "content" . }}
+</main>
+<script>
+(function () {
+  var each = function (list, fn) { Array.prototype.forEach.call(list, fn); };
+
+  // Sort tables by the clicked column: numbers numerically, text naturally
+  var number = /^-?\d+(\.\d+)?$/;
+  var sortKey = function (cell) {
+    var key = cell.getAttribute('data-sort');
+    return key !== null ? key : cell.textContent.trim();
+  };
+  var compare = function (a, b) {
+    if (number.test(a) && number.test(b)) {
+      return parseFloat(a) - parseFloat(b);
+    }
+    return a.localeCompare(b, undefined, { numeric: true, sensitivity: 'base' });
+  };
+  each(document.querySelectorAll('table.sortable'), function (table) {
+    var headers = table.tHead.rows[0].cells;
+    each(headers, function (th, column) {
+      th.addEventListener('click', function () {
+        var ascending = th.getAttribute('aria-sort') !== 'ascending';
+        each(headers, function (h) { h.removeAttribute('aria-sort'); });
+        th.setAttribute('aria-sort', ascending ? 'ascending' : 'descending');
+        var body = table.tBodies[0];
+        var rows = Array.prototype.slice.call(body.rows);
+        rows.sort(function (x, y) {
+          var order = compare(sortKey(x.cells[column]), sortKey(y.cells[column]));
+          return ascending ? order : -order;
+        });
+        each(rows, function (row) { body.appendChild(row); });
+      });
+    });
+  });
+
+  // Expand or collapse every section
+  each(document.querySelectorAll('[data-expand]'), function (button) {
+    button.addEventListener('click', function () {
+      var open = button.getAttribute('data-expand') === 'true';
+      each(document.querySelectorAll('details'), function (d) { d.open = open; });
+    });
+  });
+  each(document.querySelectorAll('[data-print]'), function (button) {
+    button.addEventListener('click', function () { window.print(); });
+  });
+
+  // Print every section, then restore the collapsed ones
+  var collapsed = [];
+  window.addEventListener('beforeprint', function () {
+    collapsed = Array.prototype.filter.call(document.querySelectorAll('details'), function (d) { return !d.open; });
+    each(collapsed, function (d) { d.open = true; });
+  });
+  window.addEventListener('afterprint', function () {
+    each(collapsed, function (d) { d.open = false; });
+    collapsed = [];
+  });
+})();
+</script>
+</body>
+</html>
+`
+
+// htmlIssueTable is the sortable table of a list of issues
+const htmlIssueTable = `{{- define "issueTable" }}
+<table class="sortable">
+<thead><tr><th class="num">#</th><th>{{ t "col.severity" }}</th><th>{{ t "col.type" }}</th><th>{{ t "col.file" }}</th><th class="num">{{ t "col.line" }}</th><th class="num">{{ t "col.effort" }}</th><th>{{ t "col.message" }}</th></tr></thead>
+<tbody>
+{{- range $idx, $issue := . }}
+<tr><td class="num">{{ add $idx 1 }}</td><td data-sort="{{ severityOrder .Severity }}">{{ severityIcon .Severity }}</td><td>{{ issueType .Type }}</td><td><code>{{ .Component }}</code></td><td class="num">{{ .Line }}</td><td class="num" data-sort="{{ .EffortMinutes }}">{{ orDash (effort .Effort) }}</td><td>{{ .Message }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}`
+
+const htmlTemplate = htmlIssueTable + `
+{{- define "title" }}{{ t "report.title" }} — {{ .ProjectName }}{{ end }}
+
+{{- define "effortGroups" }}
+<table class="sortable">
+<thead><tr><th>{{ .Title }}</th><th class="num">{{ t "col.issues" }}</th><th class="num">{{ t "col.effort" }}</th><th class="num">{{ t "col.personDays" }}</th></tr></thead>
+<tbody>
+{{- range .Groups }}
+<tr><td>{{ .Key }}</td><td class="num">{{ int .Issues }}</td><td class="num" data-sort="{{ .Minutes }}">{{ formatEffort .Minutes }}</td><td class="num" data-sort="{{ .Minutes }}">{{ personDays .Minutes }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+
+{{- define "content" }}
+<header>
+<h1>{{ icon "chart-bar" "info" }} {{ t "report.title" }}</h1>
+<p class="subtitle">{{ t "report.subtitle" }}</p>
+{{- if .Historical }}
+<div class="callout warn"><strong>{{ t "report.historicalBanner" (formatTime .Historical.AsOf) }}</strong> {{ t "report.historicalNote" }}</div>
+{{- end }}
+</header>
+
+<details class="section" open>
+<summary><h2>{{ icon "info-circle" "info" }} {{ t "info.title" }}</h2></summary>
+<table class="info">
+<tr><th>{{ t "info.projectName" }}</th><td>{{ .ProjectName }}</td></tr>
+<tr><th>{{ t "info.projectKey" }}</th><td><code>{{ .ProjectKey }}</code></td></tr>
+<tr><th>{{ t "info.branch" }}</th><td><code>{{ .Branch }}</code></td></tr>
+{{- if .Team }}
+<tr><th>{{ t "info.team" }}</th><td>{{ t "info.teamValue" .Team }}</td></tr>
+{{- end }}
+{{- if .Profile }}
+<tr><th>{{ t "info.profile" }}</th><td>{{ .Profile }}</td></tr>
+{{- end }}
+{{- if or .Severities .Types }}
+<tr><th>{{ t "info.issueFilter" }}</th><td>{{ issueFilter . }}</td></tr>
+{{- end }}
+<tr><th>{{ t "info.generated" }}</th><td>{{ formatTime .GeneratedAt }}</td></tr>
+{{- with dateTime .AnalysisDate }}
+<tr><th>{{ t "info.lastAnalysis" }}</th><td>{{ . }}</td></tr>
+{{- end }}
+{{- if .Historical }}
+<tr><th>{{ t "info.asOf" }}</th><td>{{ formatTime .Historical.AsOf }} (<code>{{ .Historical.Requested }}</code>)</td></tr>
+{{- end }}
+{{- if .Redactions }}
+<tr><th>{{ t "info.redacted" }}</th><td>{{ t "info.redactedValue" (int .Redactions.Total) }}</td></tr>
+{{- end }}
+</table>
+</details>
+
+{{- if .Shows "qualityGate" }}
+
+<details class="section" open>
+<summary><h2>{{ icon "activity" "info" }} {{ t "qualityGate.title" }}</h2></summary>
+{{- if eq .QualityGateStatus "OK" }}
+<h3>{{ qualityGateIcon "OK" }} {{ qualityGateText "OK" }}</h3>
+<div class="callout ok"><strong>{{ t "qualityGate.passedTitle" }}</strong> {{ t "qualityGate.passedText" }}</div>
+{{- else if eq .QualityGateStatus "WARN" }}
+<h3>{{ qualityGateIcon "WARN" }} {{ qualityGateText "WARN" }}</h3>
+<div class="callout warn"><strong>{{ t "qualityGate.warnTitle" }}</strong> {{ t "qualityGate.warnText" }}</div>
+{{- else }}
+<h3>{{ qualityGateIcon "ERROR" }} {{ qualityGateText "ERROR" }}</h3>
+<div class="callout bad"><strong>{{ t "qualityGate.failedTitle" }}</strong> {{ t "qualityGate.failedText" }}</div>
+{{- end }}
+{{- if .Historical.IsReconstructed "qualityGate" }}
+<p class="note">{{ t "qualityGate.reconstructed" }}</p>
+{{- end }}
+{{- if .QualityGateConditions }}
+<h3>{{ t "qualityGate.conditions" }}</h3>
+<table>
+<thead><tr><th>{{ t "col.metric" }}</th><th class="center">{{ t "col.status" }}</th><th class="num">{{ t "col.actualValue" }}</th><th>{{ t "col.threshold" }}</th></tr></thead>
+<tbody>
+{{- range .QualityGateConditions }}
+<tr><td>{{ .Metric }}</td><td class="center">{{ qualityGateIcon .Status }}</td><td class="num"><strong>{{ num .ActualValue }}</strong></td><td>{{ .Comparator }} {{ num .ErrorThreshold }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+</details>
+{{- end }}
+
+{{- if and .Policy (.Shows "policy") }}
+
+<details class="section" open>
+<summary><h2>{{ icon "shield" "info" }} {{ t "policy.title" }}</h2></summary>
+{{- if .Policy.Passed }}
+<div class="callout ok">{{ icon "check-circle" "success" }} <strong>{{ t "policy.compliant" .Policy.Policy }}</strong></div>
+{{- else }}
+<div class="callout bad">{{ icon "circle-x" "danger" }} <strong>{{ t "policy.notCompliant" .Policy.Policy (int .Policy.Failed) }}</strong></div>
+{{- end }}
+{{- if .Policy.Description }}
+<p>{{ .Policy.Description }}</p>
+{{- end }}
+<table>
+<thead><tr><th>{{ t "col.rule" }}</th><th>{{ t "col.result" }}</th><th>{{ t "col.explanation" }}</th></tr></thead>
+<tbody>
+{{- range .Policy.Rules }}
+<tr><td>{{ .Name }}</td><td>{{ if eq .Status "passed" }}{{ icon "check-circle" "success" }}{{ else if eq .Status "failed" }}{{ icon "circle-x" "danger" }}{{ else }}{{ icon "info-circle" "info" }}{{ end }} {{ policyStatus .Status }}</td><td>{{ policyExplanation . }}{{ if .Issues }} ({{ joinKeys .Issues 5 }}){{ end }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+</details>
+{{- end }}
+
+{{- if and .TopIssues (.Shows "topIssues") }}
+
+<details class="section" open>
+<summary><h2>{{ icon "alert-triangle" "warning" }} {{ t "topIssues.title" (len .TopIssues) }}</h2></summary>
+<table class="sortable">
+<thead><tr><th class="num">#</th><th class="num">{{ t "col.score" }}</th><th>{{ t "col.severity" }}</th><th>{{ t "col.type" }}</th><th>{{ t "col.file" }}</th><th class="num">{{ t "col.line" }}</th><th class="num">{{ t "col.effort" }}</th><th>{{ t "col.message" }}</th></tr></thead>
+<tbody>
+{{- range $idx, $issue := .TopIssues }}
+<tr><td class="num">{{ add $idx 1 }}</td><td class="num" data-sort="{{ .Score }}"><strong>{{ float1 .Score }}</strong></td><td data-sort="{{ severityOrder .Severity }}">{{ severityIcon .Severity }}</td><td>{{ issueType .Type }}</td><td><code>{{ .Component }}</code></td><td class="num">{{ .Line }}</td><td class="num" data-sort="{{ .EffortMinutes }}">{{ orDash (effort .Effort) }}</td><td>{{ .Message }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+<p class="note">{{ t "topIssues.ranking" (scoreWeights .Scoring.Weights) }}{{ if and .Scoring.Weights.LineCoverage (not .Scoring.CoverageKnown) }} {{ t "topIssues.noCoverage" }}{{ end }}</p>
+</details>
+{{- end }}
+
+{{- if and .Baseline (.Shows "baseline") }}
+
+<details class="section" open>
+<summary><h2>{{ icon "shield" "info" }} {{ t "baseline.title" }}</h2></summary>
+<p>{{ t "baseline.summary" (bold (int .Baseline.NewIssues)) (bold (int .Baseline.BaselineIssues)) }}</p>
+<p class="note">{{ t "baseline.frozen" (formatTime .Baseline.CreatedAt) }}{{ if ne .Baseline.RefreshedAt .Baseline.CreatedAt }}{{ t "baseline.refreshed" (formatTime .Baseline.RefreshedAt) }}{{ end }}.{{ if .Baseline.Truncated }} {{ t "baseline.truncated" }}{{ end }}</p>
+<table>
+<thead><tr><th>{{ t "col.severity" }}</th><th class="num">{{ t "baseline.new" }}</th><th class="num">{{ t "baseline.inBaseline" }}</th></tr></thead>
+<tbody>
+{{- range $sev := .Baseline.Severities }}
+<tr><td>{{ severityIcon $sev }}</td><td class="num"><strong>{{ int (index $.Baseline.NewBySeverity $sev) }}</strong></td><td class="num">{{ int (index $.Baseline.BaselineBySeverity $sev) }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- with .NewIssues }}
+<details class="more">
+<summary>{{ t "baseline.newIssues" }} ({{ int (len .) }})</summary>
+{{ template "issueTable" . }}
+</details>
+{{- else }}
+<div class="callout ok">{{ icon "check-circle" "success" }} <strong>{{ t "baseline.noNewIssues" }}</strong></div>
+{{- end }}
+</details>
+{{- end }}
+
+{{- if or (.Shows "metrics") (.Shows "languages") }}
+
+<details class="section" open>
+<summary><h2>{{ icon "trending-up" "info" }} {{ t "metrics.title" }}</h2></summary>
+{{- if .Historical.IsReconstructed "metrics" }}
+<p class="note">{{ t "metrics.reconstructed" }}</p>
+{{- end }}
+{{- if .Shows "metrics" }}
+<h3>{{ t "metrics.dashboard" }}</h3>
+<div class="cards">
+<div class="card"><div class="label">{{ icon "bug" "danger" }} {{ t "metrics.bugs" }}</div><div class="value">{{ num .Metrics.Bugs }}</div><div>{{ ratingIcon .Metrics.ReliabilityRating }} {{ t "metrics.rating" .Metrics.ReliabilityRating }}</div></div>
+<div class="card"><div class="label">{{ icon "shield" "warning" }} {{ t "metrics.vulnerabilities" }}</div><div class="value">{{ num .Metrics.Vulnerabilities }}</div><div>{{ ratingIcon .Metrics.SecurityRating }} {{ t "metrics.rating" .Metrics.SecurityRating }}</div></div>
+<div class="card"><div class="label">{{ icon "broom" "info" }} {{ t "metrics.codeSmells" }}</div><div class="value">{{ num .Metrics.CodeSmells }}</div><div>{{ ratingIcon .Metrics.MaintainabilityRating }} {{ t "metrics.rating" .Metrics.MaintainabilityRating }}</div></div>
+</div>
+<h3>{{ t "metrics.additional" }}</h3>
+<table>
+<thead><tr><th>{{ t "col.metric" }}</th><th class="num">{{ t "col.value" }}</th><th>{{ t "col.description" }}</th></tr></thead>
+<tbody>
+<tr><td>{{ icon "ruler" "info" }} <strong>{{ t "metrics.linesOfCode" }}</strong></td><td class="num">{{ num .Metrics.LinesOfCode }}</td><td>{{ t "metrics.linesOfCodeDesc" }}</td></tr>
+<tr><td>{{ icon "chart-bar" "info" }} <strong>{{ t "metrics.coverage" }}</strong></td><td class="num">{{ num .Metrics.Coverage }}</td><td>{{ t "metrics.coverageDesc" }}</td></tr>
+<tr><td>{{ icon "copy" "info" }} <strong>{{ t "metrics.duplications" }}</strong></td><td class="num">{{ num .Metrics.DuplicatedLinesDensity }}</td><td>{{ t "metrics.duplicationsDesc" }}</td></tr>
+<tr><td>{{ icon "clock" "info" }} <strong>{{ t "metrics.technicalDebt" }}</strong></td><td class="num">{{ effort .Metrics.TechnicalDebt }}</td><td>{{ t "metrics.technicalDebtDesc" }}</td></tr>
+</tbody>
+</table>
+{{- if .SelectedMetrics }}
+<h3>{{ t "metrics.selected" }}</h3>
+<table class="sortable">
+<thead><tr><th>{{ t "col.metric" }}</th><th>{{ t "col.domain" }}</th><th class="num">{{ t "col.value" }}</th></tr></thead>
+<tbody>
+{{- range .SelectedMetrics }}
+<tr><td><strong>{{ .Name }}</strong> (<code>{{ .Key }}</code>)</td><td>{{ orDash .Domain }}</td><td class="num" data-sort="{{ .Value }}">{{ metricValue . }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+{{- end }}
+{{- if and .Languages (.Shows "languages") }}
+<h3>{{ t "languages.title" }}</h3>
+<table class="sortable">
+<thead><tr><th>{{ t "col.language" }}</th><th class="num">{{ t "col.linesOfCode" }}</th><th class="num">{{ t "col.share" }}</th><th class="num">{{ t "col.issues" }}</th><th class="num">{{ t "col.issueDensity" }}</th></tr></thead>
+<tbody>
+{{- range .Languages }}
+<tr><td>{{ languageName . }}</td><td class="num" data-sort="{{ .Lines }}">{{ int .Lines }}</td><td class="num" data-sort="{{ .Share }}">{{ pct .Share }}</td><td class="num" data-sort="{{ .Issues }}"><strong>{{ int .Issues }}</strong></td><td class="num" data-sort="{{ .IssuesPerKLoc }}">{{ if .Lines }}{{ float1 .IssuesPerKLoc }}{{ else }}-{{ end }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+{{- if and (.Shows "metrics") (or .Metrics.NewBugs .Metrics.NewVulnerabilities .Metrics.NewCodeSmells) }}
+<h3>{{ icon "sparkles" "warning" }} {{ t "metrics.newCode" }}</h3>
+<table>
+<thead><tr><th>{{ t "col.metric" }}</th><th class="num">{{ t "col.value" }}</th></tr></thead>
+<tbody>
+{{- if .Metrics.NewBugs }}
+<tr><td>{{ icon "bug" "danger" }} {{ t "metrics.newBugs" }}</td><td class="num"><strong>{{ num .Metrics.NewBugs }}</strong></td></tr>
+{{- end }}
+{{- if .Metrics.NewVulnerabilities }}
+<tr><td>{{ icon "shield" "warning" }} {{ t "metrics.newVulnerabilities" }}</td><td class="num"><strong>{{ num .Metrics.NewVulnerabilities }}</strong></td></tr>
+{{- end }}
+{{- if .Metrics.NewCodeSmells }}
+<tr><td>{{ icon "broom" "info" }} {{ t "metrics.newCodeSmells" }}</td><td class="num"><strong>{{ num .Metrics.NewCodeSmells }}</strong></td></tr>
+{{- end }}
+{{- if .Metrics.NewCoverage }}
+<tr><td>{{ icon "chart-bar" "info" }} {{ t "metrics.newCoverage" }}</td><td class="num"><strong>{{ num .Metrics.NewCoverage }}</strong></td></tr>
+{{- end }}
+{{- if .Metrics.NewDuplicatedLines }}
+<tr><td>{{ icon "copy" "info" }} {{ t "metrics.newDuplications" }}</td><td class="num"><strong>{{ num .Metrics.NewDuplicatedLines }}</strong></td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+</details>
+{{- end }}
+
+{{- if .Shows "issues" }}
+
+<details class="section" open>
+<summary><h2>{{ icon "search" "info" }} {{ t "issues.title" }}</h2></summary>
+{{- if .Historical.IsReconstructed "issues" }}
+<p class="note">{{ t "issues.reconstructed" }}</p>
+{{- end }}
+<h3>{{ t "issues.total" }}: <strong>{{ int .TotalIssues }}</strong></h3>
+{{- if and .Suppressed .Suppressed.Issues }}
+<p class="note">{{ t "issues.suppressedNote" (int (len .Suppressed.Issues)) }}</p>
+{{- end }}
+<h3>{{ t "issues.byType" }}</h3>
+<table>
+<thead><tr><th>{{ t "col.type" }}</th><th class="num">{{ t "col.count" }}</th><th class="num">{{ t "col.percentage" }}</th></tr></thead>
+<tbody>
+{{- range $type, $count := .IssuesByType }}
+<tr><td>{{ typeIcon $type }} {{ issueType $type }}</td><td class="num"><strong>{{ int $count }}</strong></td><td class="num">{{ pct (share $count $.TotalIssues) }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+<h3>{{ t "issues.bySeverity" }}</h3>
+<table>
+<thead><tr><th>{{ t "col.severity" }}</th><th class="num">{{ t "col.count" }}</th></tr></thead>
+<tbody>
+{{- range $sev := getSortedSeverities .IssuesBySeverity }}
+<tr><td>{{ severityIcon $sev }}</td><td class="num"><strong>{{ int (issueCount $.IssuesBySeverity $sev) }}</strong></td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- if and .Owners (.Shows "owners") }}
+<h3>{{ t "owners.title" }}</h3>
+<table class="sortable">
+<thead><tr><th>{{ t "col.owner" }}</th><th class="num">{{ t "col.issues" }}</th><th class="num">{{ severityTitle "BLOCKER" }}</th><th class="num">{{ severityTitle "CRITICAL" }}</th><th class="num">{{ severityTitle "MAJOR" }}</th><th class="num">{{ severityTitle "MINOR" }}</th><th class="num">{{ severityTitle "INFO" }}</th><th class="num">{{ t "col.effort" }}</th></tr></thead>
+<tbody>
+{{- range .Owners }}
+<tr><td>{{ .Owner }}</td><td class="num" data-sort="{{ .Issues }}"><strong>{{ int .Issues }}</strong></td><td class="num">{{ int (index .BySeverity "BLOCKER") }}</td><td class="num">{{ int (index .BySeverity "CRITICAL") }}</td><td class="num">{{ int (index .BySeverity "MAJOR") }}</td><td class="num">{{ int (index .BySeverity "MINOR") }}</td><td class="num">{{ int (index .BySeverity "INFO") }}</td><td class="num" data-sort="{{ .EffortMinutes }}">{{ formatEffort .EffortMinutes }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+<p class="note">{{ t "owners.note" }}</p>
+{{- end }}
+</details>
+{{- end }}
+
+{{- if .Shows "issueDetails" }}
+{{- range $sev := getSortedSeverities .IssuesBySeverity }}
+{{- $issues := index $.IssuesBySeverity $sev }}
+{{- if $issues }}
+
+<details class="section">
+<summary><h2>{{ t "details.title" (severityIcon $sev) (int (len $issues)) }}</h2></summary>
+<p class="note hint">{{ t "common.sortHint" }}</p>
+{{ template "issueTable" $issues }}
+{{- range $issues }}
+{{- if hasDetails . }}
+<div class="issue">
+<h4>{{ severityIcon .Severity }} {{ .Message }}</h4>
+<div class="meta"><code>{{ .Component }}</code> · {{ t "col.line" }} {{ .Line }}{{ if and .EndLine (ne .EndLine .Line) }}-{{ .EndLine }}{{ end }} · {{ issueType .Type }} · <code>{{ .Rule }}</code>{{ if .Effort }} · {{ effort .Effort }}{{ end }}{{ if .Owners }} · {{ join .Owners ", " }}{{ end }}</div>
+{{- if .CodeSnippet }}
+<strong>{{ icon "code" "info" }} {{ t "details.problematic" }}</strong>
+<pre class="code"><code>{{ highlight .CodeSnippet .Language }}</code></pre>
+{{- end }}
+{{- if .HowToFix }}
+<strong>{{ icon "bulb" "warning" }} {{ t "details.howToFix" }}</strong>
+<div class="fix">{{ .HowToFix }}</div>
+{{- end }}
+</div>
+{{- end }}
+{{- end }}
+</details>
+{{- end }}
+{{- end }}
+{{- end }}
+
+{{- if and .Effort .Effort.TotalMinutes (.Shows "remediation") }}
+
+<details class="section" open>
+<summary><h2>{{ icon "clock" "info" }} {{ t "remediation.title" }}</h2></summary>
+<p>{{ t "remediation.intro" (int .Effort.Issues) (bold (formatEffort .Effort.TotalMinutes)) (bold (t "remediation.personDays" (personDays .Effort.TotalMinutes))) .WorkingDayHours }}</p>
+{{- if .Effort.Truncated }}
+<p class="note">{{ t "remediation.truncated" (int .Effort.Issues) }}</p>
+{{- end }}
+{{- if .Effort.BySeverity }}
+<h3>{{ t "remediation.bySeverity" }}</h3>
+{{ template "effortGroups" (effortTable (t "col.severity") (severityGroups .Effort.BySeverity)) }}
+{{- end }}
+{{- if .Effort.ByType }}
+<h3>{{ t "remediation.byType" }}</h3>
+{{ template "effortGroups" (effortTable (t "col.type") (typeGroups .Effort.ByType)) }}
+{{- end }}
+{{- if .Effort.ByRule }}
+<h3>{{ t "remediation.byRule" }}</h3>
+{{ template "effortGroups" (effortTable (t "col.rule") .Effort.ByRule) }}
+{{- end }}
+{{- if .Effort.ByFile }}
+<h3>{{ t "remediation.byFile" }}</h3>
+{{ template "effortGroups" (effortTable (t "col.file") .Effort.ByFile) }}
+{{- end }}
+{{- if .Effort.ByAuthor }}
+<h3>{{ t "remediation.byAuthor" }}</h3>
+{{ template "effortGroups" (effortTable (t "col.author") .Effort.ByAuthor) }}
+{{- end }}
+</details>
+{{- end }}
+
+{{- if .Shows "hotspots" }}
+
+<details class="section" open>
+<summary><h2>{{ icon "shield" "info" }} {{ t "hotspots.title" }}</h2></summary>
+{{- if .Historical.IsUnavailable "hotspots" }}
+<p class="note">{{ t "hotspots.unavailable" }}</p>
+{{- else if gt .TotalHotspots 0 }}
+<h3>{{ t "hotspots.total" }}: <strong>{{ int .TotalHotspots }}</strong></h3>
+<h3>{{ t "hotspots.byPriority" }}</h3>
+<table>
+<thead><tr><th>{{ t "col.priority" }}</th><th class="num">{{ t "col.count" }}</th></tr></thead>
+<tbody>
+{{- range $priority, $count := .HotspotsByPriority }}
+<tr><td>{{ priorityIcon $priority }} {{ priority $priority }}</td><td class="num"><strong>{{ int $count }}</strong></td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- if .Hotspots }}
+<details class="more">
+<summary>{{ t "hotspots.details" }} ({{ int (len .Hotspots) }})</summary>
+<table class="sortable">
+<thead><tr><th class="num">#</th><th>{{ t "col.priority" }}</th><th>{{ t "col.category" }}</th><th>{{ t "col.location" }}</th><th>{{ t "col.status" }}</th></tr></thead>
+<tbody>
+{{- range $idx, $hotspot := .Hotspots }}
+<tr><td class="num">{{ add $idx 1 }}</td><td>{{ priorityIcon .VulnerabilityProbability }} {{ priority .VulnerabilityProbability }}</td><td>{{ .SecurityCategory }}</td><td><code>{{ .Component }}:{{ .Line }}</code></td><td>{{ hotspotStatus .Status }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+</details>
+{{- end }}
+{{- else }}
+<h3>{{ icon "check-circle" "success" }} {{ t "hotspots.none" }}</h3>
+<p>{{ t "hotspots.noneText" }}</p>
+{{- end }}
+</details>
+{{- end }}
+
+{{- if .Shows "summary" }}
+
+<details class="section" open>
+<summary><h2>{{ icon "list" "info" }} {{ t "summary.title" }}</h2></summary>
+{{- if eq .QualityGateStatus "OK" }}
+<table>
+<thead><tr><th>{{ t "col.status" }}</th><th>{{ t "col.result" }}</th></tr></thead>
+<tbody>
+<tr><td>{{ t "qualityGate.title" }}</td><td>{{ qualityGateIcon "OK" }} <strong>{{ qualityGateText "OK" }}</strong></td></tr>
+<tr><td>{{ t "metrics.bugs" }}</td><td>{{ num .Metrics.Bugs }} ({{ .Metrics.ReliabilityRating }})</td></tr>
+<tr><td>{{ t "metrics.vulnerabilities" }}</td><td>{{ num .Metrics.Vulnerabilities }} ({{ .Metrics.SecurityRating }})</td></tr>
+<tr><td>{{ t "metrics.codeSmells" }}</td><td>{{ num .Metrics.CodeSmells }} ({{ .Metrics.MaintainabilityRating }})</td></tr>
+</tbody>
+</table>
+{{- else }}
+<div class="callout warn">
+<p>{{ icon "alert-triangle" "warning" }} <strong>{{ t "summary.actionRequired" }}</strong></p>
+<p>{{ t "summary.qualityGate" (bold (qualityGateText .QualityGateStatus)) }}</p>
+<p>{{ t "summary.reviewIssues" }}</p>
+</div>
+{{- end }}
+</details>
+{{- end }}
+
+{{- if and .Suppressed (.Shows "suppressed") }}
+{{- if or .Suppressed.Issues .Suppressed.Expired }}
+
+<details class="section">
+<summary><h2>{{ icon "info-circle" "gray" }} {{ t "suppressed.title" }}</h2></summary>
+{{- if .Suppressed.Issues }}
+<p>{{ t "suppressed.intro" (int (len .Suppressed.Issues)) }}</p>
+<table class="sortable">
+<thead><tr><th class="num">#</th><th>{{ t "col.severity" }}</th><th>{{ t "col.type" }}</th><th>{{ t "col.file" }}</th><th class="num">{{ t "col.line" }}</th><th>{{ t "col.message" }}</th><th>{{ t "col.reason" }}</th><th>{{ t "col.expires" }}</th></tr></thead>
+<tbody>
+{{- range $idx, $issue := .Suppressed.Issues }}
+<tr><td class="num">{{ add $idx 1 }}</td><td data-sort="{{ severityOrder .Severity }}">{{ severityIcon .Severity }}</td><td>{{ issueType .Type }}</td><td><code>{{ .Component }}</code></td><td class="num">{{ .Line }}</td><td>{{ .Message }}</td><td>{{ .Reason }}</td><td>{{ .Expires }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+{{- if .Suppressed.Expired }}
+<p><strong>{{ t "suppressed.expiredTitle" }}</strong> {{ t "suppressed.expiredNote" }}</p>
+<ul>
+{{- range .Suppressed.Expired }}
+<li><code>{{ suppressionTarget . }}</code>: {{ .Reason }} {{ t "suppressed.expired" .Expires }}</li>
+{{- end }}
+</ul>
+{{- end }}
+</details>
+{{- end }}
+{{- end }}
+
+<footer>{{ t "report.generatedBy" (bold "SonarQube Report Generator") }}<br>{{ formatTime .GeneratedAt }}</footer>
+{{- end }}
+`
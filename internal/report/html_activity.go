@@ -0,0 +1,81 @@
+package report
+
+// generateActivity generates an HTML activity report
+func (g *HTMLGenerator) generateActivity(data *ReportData) ([]byte, error) {
+	return executeHTML(htmlActivityTemplate, NewLocale(data.Language, data.Timezone), data.WorkingDayHours, data)
+}
+
+const htmlActivityTemplate = htmlIssueTable + `
+{{- define "title" }}{{ t "activity.title" }} — {{ .ProjectName }}{{ end }}
+
+{{- define "issueSection" }}
+<details class="section"{{ if .Open }} open{{ end }}>
+<summary><h2>{{ icon .Icon .Color }} {{ t .Title }} ({{ int (len .Issues) }})</h2></summary>
+{{ template "issueTable" .Issues }}
+</details>
+{{- end }}
+
+{{- define "content" }}
+{{- $a := .Activity }}
+<header>
+<h1>{{ icon "activity" "info" }} {{ t "activity.title" }}</h1>
+<p class="subtitle">{{ t "activity.subtitle" .ProjectName (shortDate $a.From) (shortDate $a.To) }}</p>
+</header>
+
+<details class="section" open>
+<summary><h2>{{ icon "info-circle" "info" }} {{ t "info.title" }}</h2></summary>
+<table class="info">
+<tr><th>{{ t "info.projectName" }}</th><td>{{ .ProjectName }}</td></tr>
+<tr><th>{{ t "info.projectKey" }}</th><td><code>{{ .ProjectKey }}</code></td></tr>
+<tr><th>{{ t "info.branch" }}</th><td><code>{{ .Branch }}</code></td></tr>
+<tr><th>{{ t "info.period" }}</th><td>{{ t "info.periodValue" (formatTime $a.From) (formatTime $a.To) }}</td></tr>
+<tr><th>{{ t "info.generated" }}</th><td>{{ formatTime .GeneratedAt }}</td></tr>
+{{- with dateTime .AnalysisDate }}
+<tr><th>{{ t "info.lastAnalysisInPeriod" }}</th><td>{{ . }}</td></tr>
+{{- end }}
+{{- if .Redactions }}
+<tr><th>{{ t "info.redacted" }}</th><td>{{ t "info.redactedValue" (int .Redactions.Total) }}</td></tr>
+{{- end }}
+</table>
+</details>
+
+<details class="section" open>
+<summary><h2>{{ icon "chart-bar" "info" }} {{ t "activity.summary" }}</h2></summary>
+{{- if $a.Truncated }}
+<p class="note">{{ t "activity.truncated" }}</p>
+{{- end }}
+<table>
+<thead><tr><th>{{ t "col.activity" }}</th><th class="num">{{ t "col.issues" }}</th></tr></thead>
+<tbody>
+<tr><td>{{ t "activity.opened" }}</td><td class="num">{{ t "activity.stillOpen" (bold (int (len $a.Opened))) (int $a.StillOpen) }}</td></tr>
+<tr><td>{{ t "activity.fixed" }}</td><td class="num"><strong>{{ int (len $a.Fixed) }}</strong></td></tr>
+<tr><td>{{ t "activity.falsePositive" }}</td><td class="num"><strong>{{ int (len $a.FalsePositive) }}</strong></td></tr>
+<tr><td>{{ t "activity.wontFix" }}</td><td class="num"><strong>{{ int (len $a.WontFix) }}</strong></td></tr>
+</tbody>
+</table>
+<table>
+<thead><tr><th>{{ t "activity.debt" }}</th><th class="num">{{ t "col.effort" }}</th></tr></thead>
+<tbody>
+<tr><td>{{ t "activity.debtAdded" }}</td><td class="num">{{ formatDebtChange $a.DebtAdded }}</td></tr>
+<tr><td>{{ t "activity.debtRemoved" }}</td><td class="num">{{ formatDebtChange (neg $a.DebtRemoved) }}</td></tr>
+<tr><td><strong>{{ t "activity.debtNet" }}</strong></td><td class="num"><strong>{{ formatDebtChange $a.NetDebtChange }}</strong></td></tr>
+</tbody>
+</table>
+</details>
+
+{{- if $a.Opened }}
+{{ template "issueSection" (issueSection "activity.openedTitle" "alert-triangle" "danger" $a.Opened true) }}
+{{- end }}
+{{- if $a.Fixed }}
+{{ template "issueSection" (issueSection "activity.fixedTitle" "check-circle" "success" $a.Fixed true) }}
+{{- end }}
+{{- if $a.FalsePositive }}
+{{ template "issueSection" (issueSection "activity.falsePosTitle" "info-circle" "info" $a.FalsePositive false) }}
+{{- end }}
+{{- if $a.WontFix }}
+{{ template "issueSection" (issueSection "activity.wontFixTitle" "info-circle" "warning" $a.WontFix false) }}
+{{- end }}
+
+<footer>{{ t "activity.generatedBy" (bold "SonarQube Report Generator") }}<br>{{ formatTime .GeneratedAt }}</footer>
+{{- end }}
+`
@@ -0,0 +1,138 @@
+package report
+
+// GenerateDiff generates an HTML delta report for a comparison of two reports
+func (g *HTMLGenerator) GenerateDiff(diff *ReportDiff) ([]byte, error) {
+	return executeHTML(htmlDiffTemplate, NewLocale(diff.Language, diff.Timezone), 0, diff)
+}
+
+const htmlDiffTemplate = `{{- define "title" }}{{ if eq .Kind "branches" }}{{ t "diff.titleBranches" }}{{ else }}{{ t "diff.titleReports" }}{{ end }} — {{ .ProjectName }}{{ end }}
+
+{{- define "diffIssues" }}
+<table class="sortable">
+<thead><tr><th class="num">#</th><th>{{ t "col.severity" }}</th><th>{{ t "col.type" }}</th><th>{{ t "col.file" }}</th><th class="num">{{ t "col.line" }}</th><th>{{ t "col.message" }}</th></tr></thead>
+<tbody>
+{{- range $idx, $issue := . }}
+<tr><td class="num">{{ add $idx 1 }}</td><td data-sort="{{ severityOrder .Severity }}">{{ severityIcon .Severity }}</td><td>{{ issueType .Type }}</td><td><code>{{ .Component }}</code></td><td class="num">{{ .Line }}</td><td>{{ .Message }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+
+{{- define "content" }}
+{{- $branches := eq .Kind "branches" }}
+<header>
+<h1>{{ icon "chart-bar" "info" }} {{ if $branches }}{{ t "diff.titleBranches" }}{{ else }}{{ t "diff.titleReports" }}{{ end }}</h1>
+<p class="subtitle">{{ .ProjectName }} — {{ if $branches }}{{ t "diff.subtitleBranches" (code .Head.Branch) (code .Base.Branch) }}{{ else }}{{ t "diff.subtitleReports" }}{{ end }}</p>
+</header>
+
+<details class="section" open>
+<summary><h2>{{ icon "info-circle" "info" }} {{ if $branches }}{{ t "diff.comparedBranches" }}{{ else }}{{ t "diff.comparedReports" }}{{ end }}</h2></summary>
+<table>
+<thead><tr><th></th><th>{{ t "col.base" }}</th><th>{{ t "col.head" }}</th></tr></thead>
+<tbody>
+{{- if .Base.ReportID }}
+<tr><th>{{ t "col.report" }}</th><td><code>{{ .Base.ReportID }}</code></td><td><code>{{ .Head.ReportID }}</code></td></tr>
+{{- end }}
+<tr><th>{{ t "info.branch" }}</th><td><code>{{ .Base.Branch }}</code></td><td><code>{{ .Head.Branch }}</code></td></tr>
+<tr><th>{{ t "col.generated" }}</th><td>{{ formatTime .Base.GeneratedAt }}</td><td>{{ formatTime .Head.GeneratedAt }}</td></tr>
+<tr><th>{{ t "info.lastAnalysis" }}</th><td>{{ orDash (dateTime .Base.AnalysisDate) }}</td><td>{{ orDash (dateTime .Head.AnalysisDate) }}</td></tr>
+<tr><th>{{ t "issues.total" }}</th><td>{{ int .Base.TotalIssues }}</td><td>{{ int .Head.TotalIssues }}</td></tr>
+</tbody>
+</table>
+</details>
+
+<details class="section" open>
+<summary><h2>{{ icon "activity" "info" }} {{ t "qualityGate.title" }}</h2></summary>
+{{- if $branches }}
+<table>
+<thead><tr><th class="center">{{ t "col.base" }}</th><th class="center">{{ t "col.head" }}</th></tr></thead>
+<tbody>
+<tr><td class="center">{{ qualityGateIcon .QualityGate.From }} <strong>{{ qualityGateText .QualityGate.From }}</strong></td><td class="center">{{ qualityGateIcon .QualityGate.To }} <strong>{{ qualityGateText .QualityGate.To }}</strong></td></tr>
+</tbody>
+</table>
+{{- else if .QualityGate.Changed }}
+<p>{{ qualityGateIcon .QualityGate.From }} <strong>{{ qualityGateText .QualityGate.From }}</strong> → {{ qualityGateIcon .QualityGate.To }} <strong>{{ qualityGateText .QualityGate.To }}</strong></p>
+{{- else }}
+<p>{{ qualityGateIcon .QualityGate.To }} {{ t "qualityGate.unchanged" (bold (qualityGateText .QualityGate.To)) }}</p>
+{{- end }}
+{{- if .ConditionChanges }}
+<table>
+<thead><tr><th>{{ t "col.metric" }}</th><th>{{ t "col.status" }}</th><th>{{ t "col.value" }}</th></tr></thead>
+<tbody>
+{{- range .ConditionChanges }}
+<tr><td>{{ .Metric }}</td><td>{{ orDash .FromStatus }} → {{ orDash .ToStatus }}</td><td>{{ orDash (num .FromValue) }} → {{ orDash (num .ToValue) }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+</details>
+
+<details class="section" open>
+<summary><h2>{{ icon "trending-up" "info" }} {{ if $branches }}{{ t "diff.ratingsAndMetrics" }}{{ else }}{{ t "diff.metricDeltas" }}{{ end }}</h2></summary>
+<table>
+<thead><tr><th>{{ t "col.metric" }}</th><th class="num">{{ t "col.base" }}</th><th class="num">{{ t "col.head" }}</th><th class="num">{{ t "col.change" }}</th><th class="center"></th></tr></thead>
+<tbody>
+{{- range .MetricDeltas }}
+<tr><td>{{ metricName .Metric }}</td><td class="num">{{ orDash (measure .From) }}</td><td class="num">{{ orDash (measure .To) }}</td><td class="num">{{ if .Delta }}<strong>{{ measure .Delta }}</strong>{{ else if eq .From .To }}-{{ else }}{{ t "diff.changed" }}{{ end }}</td><td class="center" title="{{ trend .Trend }}">{{ trendIcon .Trend }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+</details>
+
+<details class="section" open>
+<summary><h2>{{ icon "search" "info" }} {{ t "diff.issueChanges" }}</h2></summary>
+<table>
+<thead><tr><th>{{ t "col.change" }}</th><th class="num">{{ t "col.count" }}</th></tr></thead>
+<tbody>
+{{- if $branches }}
+<tr><td>{{ t "diff.onlyIn" (code .Head.Branch) }}</td><td class="num"><strong>{{ int (len .NewIssues) }}</strong></td></tr>
+<tr><td>{{ t "diff.onlyIn" (code .Base.Branch) }}</td><td class="num"><strong>{{ int (len .FixedIssues) }}</strong></td></tr>
+<tr><td>{{ t "diff.differentSeverity" }}</td><td class="num"><strong>{{ int (len .SeverityChanges) }}</strong></td></tr>
+<tr><td>{{ t "diff.inBoth" }}</td><td class="num">{{ int .UnchangedIssues }}</td></tr>
+{{- else }}
+<tr><td>{{ t "diff.newIssues" }}</td><td class="num"><strong>{{ int (len .NewIssues) }}</strong></td></tr>
+<tr><td>{{ t "diff.fixedIssues" }}</td><td class="num"><strong>{{ int (len .FixedIssues) }}</strong></td></tr>
+<tr><td>{{ t "diff.severityChanges" }}</td><td class="num"><strong>{{ int (len .SeverityChanges) }}</strong></td></tr>
+<tr><td>{{ t "diff.unchanged" }}</td><td class="num">{{ int .UnchangedIssues }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- if and .MatchedByFingerprint (not $branches) }}
+<p class="note">{{ t "diff.matchedByFingerprint" (int .MatchedByFingerprint) }}</p>
+{{- end }}
+</details>
+
+{{- if .NewIssues }}
+
+<details class="section" open>
+<summary><h2>{{ icon "alert-triangle" "danger" }} {{ if $branches }}{{ t "diff.onlyIn" (code .Head.Branch) }}{{ else }}{{ t "diff.newIssuesTitle" }}{{ end }} ({{ int (len .NewIssues) }})</h2></summary>
+{{ template "diffIssues" .NewIssues }}
+</details>
+{{- end }}
+
+{{- if .FixedIssues }}
+
+<details class="section" open>
+<summary><h2>{{ icon "check-circle" "success" }} {{ if $branches }}{{ t "diff.onlyIn" (code .Base.Branch) }}{{ else }}{{ t "diff.fixedIssuesTitle" }}{{ end }} ({{ int (len .FixedIssues) }})</h2></summary>
+{{ template "diffIssues" .FixedIssues }}
+</details>
+{{- end }}
+
+{{- if .SeverityChanges }}
+
+<details class="section" open>
+<summary><h2>{{ icon "activity" "warning" }} {{ if $branches }}{{ t "diff.differentSeverityTitle" }}{{ else }}{{ t "diff.severityChangesTitle" }}{{ end }} ({{ int (len .SeverityChanges) }})</h2></summary>
+<table class="sortable">
+<thead><tr><th class="num">#</th><th>{{ if $branches }}{{ t "col.base" }}{{ else }}{{ t "col.from" }}{{ end }}</th><th>{{ if $branches }}{{ t "col.head" }}{{ else }}{{ t "col.to" }}{{ end }}</th><th>{{ t "col.file" }}</th><th class="num">{{ t "col.line" }}</th><th>{{ t "col.message" }}</th></tr></thead>
+<tbody>
+{{- range $idx, $change := .SeverityChanges }}
+<tr><td class="num">{{ add $idx 1 }}</td><td data-sort="{{ severityOrder .FromSeverity }}">{{ severityIcon .FromSeverity }}</td><td data-sort="{{ severityOrder .ToSeverity }}">{{ severityIcon .ToSeverity }}</td><td><code>{{ .Issue.Component }}</code></td><td class="num">{{ .Issue.Line }}</td><td>{{ .Issue.Message }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+</details>
+{{- end }}
+
+<footer>{{ if $branches }}{{ t "diff.generatedBranches" (bold "SonarQube Report Generator") }}{{ else }}{{ t "diff.generatedReports" (bold "SonarQube Report Generator") }}{{ end }}<br>{{ formatTime .GeneratedAt }}</footer>
+{{- end }}
+`
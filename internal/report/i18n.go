@@ -0,0 +1,483 @@
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// DefaultLanguage is the language reports are rendered in when none is chosen
+const DefaultLanguage = "en"
+
+// DefaultTimezone is the time zone reports are rendered in when none is chosen
+const DefaultTimezone = "UTC"
+
+// ReportLanguage is a language reports can be rendered in
+type ReportLanguage struct {
+	Code string `json:"code"`
+	Name string `json:"name"` // in the language itself
+}
+
+// catalogue holds the messages and formats of one language. Messages are
+// fmt formats keyed by message ID.
+type catalogue struct {
+	name     string
+	messages map[string]string
+
+	// Layouts of time.Format; English month names are replaced by months
+	timeLayout      string
+	dateLayout      string
+	shortDateLayout string
+	months          [12]string
+
+	decimalSep string
+	groupSep   string
+
+	// Units of effort durations: days, hours and minutes
+	dayUnit, hourUnit, minuteUnit string
+}
+
+// catalogues are the languages reports can be rendered in, by code
+var catalogues = map[string]*catalogue{
+	"en": &englishCatalogue,
+	"id": &indonesianCatalogue,
+}
+
+// languageOrder lists the codes of catalogues, the default first
+var languageOrder = []string{"en", "id"}
+
+// Languages returns the languages reports can be rendered in, the default first
+func Languages() []ReportLanguage {
+	languages := make([]ReportLanguage, 0, len(languageOrder))
+	for _, code := range languageOrder {
+		languages = append(languages, ReportLanguage{Code: code, Name: catalogues[code].name})
+	}
+	return languages
+}
+
+// ValidateLanguage checks that reports can be rendered in a language
+func ValidateLanguage(code string) error {
+	if _, ok := catalogues[code]; !ok {
+		return fmt.Errorf("unsupported language %q", code)
+	}
+	return nil
+}
+
+// ValidateTimezone checks that a time zone is an IANA name such as
+// Asia/Jakarta. The server's local zone is not accepted, as reports would
+// render differently on other servers.
+func ValidateTimezone(name string) error {
+	if name == "" || name == "Local" {
+		return fmt.Errorf("unsupported time zone %q", name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("unsupported time zone %q", name)
+	}
+	return nil
+}
+
+// Locale renders the text, numbers and dates of a report in one language and
+// time zone
+type Locale struct {
+	lang string
+	c    *catalogue
+	zone *time.Location
+}
+
+// NewLocale returns the locale of a language and time zone; unknown
+// languages and zones, as in reports stored before they could be chosen, are
+// rendered in English and UTC
+func NewLocale(lang, timezone string) *Locale {
+	c, ok := catalogues[lang]
+	if !ok {
+		lang, c = DefaultLanguage, catalogues[DefaultLanguage]
+	}
+	return &Locale{lang: lang, c: c, zone: LoadTimezone(timezone)}
+}
+
+// LoadTimezone returns the location of a time zone, UTC if it is not valid
+func LoadTimezone(name string) *time.Location {
+	if ValidateTimezone(name) != nil {
+		return time.UTC
+	}
+	zone, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return zone
+}
+
+// Language returns the code of the locale's language
+func (l *Locale) Language() string {
+	return l.lang
+}
+
+// lookup returns the message of a key, in English when the language lacks it
+func (l *Locale) lookup(key string) (string, bool) {
+	if msg, ok := l.c.messages[key]; ok {
+		return msg, true
+	}
+	msg, ok := englishCatalogue.messages[key]
+	return msg, ok
+}
+
+// T returns the message of a key formatted with args, or the key itself
+// when no language has it
+func (l *Locale) T(key string, args ...interface{}) string {
+	msg, ok := l.lookup(key)
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// translate returns the message of prefix+value, or the value itself
+func (l *Locale) translate(prefix, value string) string {
+	if msg, ok := l.lookup(prefix + value); ok {
+		return msg
+	}
+	return value
+}
+
+// Severity returns the name of an issue severity
+func (l *Locale) Severity(severity string) string {
+	return l.translate("severity.", severity)
+}
+
+// SeverityTitle returns the name of an issue severity for table headers
+func (l *Locale) SeverityTitle(severity string) string {
+	return l.translate("severityTitle.", severity)
+}
+
+// IssueType returns the name of an issue type
+func (l *Locale) IssueType(issueType string) string {
+	return l.translate("type.", issueType)
+}
+
+// QualityGate returns the text of a quality gate status
+func (l *Locale) QualityGate(status string) string {
+	return l.translate("qualityGate.", status)
+}
+
+// Priority returns the name of a hotspot review priority
+func (l *Locale) Priority(priority string) string {
+	return l.translate("priority.", priority)
+}
+
+// HotspotStatus returns the name of a hotspot review status
+func (l *Locale) HotspotStatus(status string) string {
+	return l.translate("hotspotStatus.", status)
+}
+
+// PolicyStatus returns the name of the result of a policy rule
+func (l *Locale) PolicyStatus(status string) string {
+	return l.translate("policyStatus.", status)
+}
+
+// Trend returns the name of the trend of a metric
+func (l *Locale) Trend(trend string) string {
+	return l.translate("trend.", trend)
+}
+
+// MetricName returns the name of a metric compared in delta reports
+func (l *Locale) MetricName(name string) string {
+	return l.translate("metricName.", name)
+}
+
+// Time formats a timestamp in the locale's time zone, followed by its UTC
+// offset, e.g. "2026-10-18 16:30:50 UTC+07:00"
+func (l *Locale) Time(t time.Time) string {
+	return l.format(t, l.c.timeLayout) + " " + utcOffset(t.In(l.zone))
+}
+
+// DateTime formats a SonarQube date-time like Time, or returns "" when absent
+func (l *Locale) DateTime(d sonarqube.DateTime) string {
+	if d.IsZero() {
+		return ""
+	}
+	return l.Time(d.Time)
+}
+
+// Date formats the day of a timestamp in the locale's time zone
+func (l *Locale) Date(t time.Time) string {
+	return l.format(t, l.c.dateLayout)
+}
+
+// ShortDate formats the day of a timestamp in digits
+func (l *Locale) ShortDate(t time.Time) string {
+	return l.format(t, l.c.shortDateLayout)
+}
+
+func (l *Locale) format(t time.Time, layout string) string {
+	t = t.In(l.zone)
+	s := t.Format(layout)
+	if strings.Contains(layout, "January") {
+		s = strings.Replace(s, t.Month().String(), l.c.months[t.Month()-1], 1)
+	}
+	return s
+}
+
+// utcOffset names the UTC offset of a time, e.g. "UTC" or "UTC+07:00"
+func utcOffset(t time.Time) string {
+	_, offset := t.Zone()
+	if offset == 0 {
+		return "UTC"
+	}
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// Int formats an integer with digit grouping, e.g. "12,345"
+func (l *Locale) Int(n int) string {
+	return l.groupDigits(n)
+}
+
+// Float formats a number with prec decimals
+func (l *Locale) Float(f float64, prec int) string {
+	return l.Number(strconv.FormatFloat(f, 'f', prec, 64))
+}
+
+// Percent formats a percentage with one decimal, e.g. "12.5%"
+func (l *Locale) Percent(f float64) string {
+	return l.Float(f, 1) + "%"
+}
+
+var numberPattern = regexp.MustCompile(`^([+-]?)(\d+)(?:\.(\d+))?(%?)$`)
+
+// Number localises a number formatted in the neutral form used by report
+// data, e.g. "1234", "12.5%" or "+3"; other values are returned as they are
+func (l *Locale) Number(s string) string {
+	m := numberPattern.FindStringSubmatch(s)
+	if m == nil {
+		return s
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return s
+	}
+	out := m[1] + l.groupDigits(n)
+	if m[3] != "" {
+		out += l.c.decimalSep + m[3]
+	}
+	return out + m[4]
+}
+
+func (l *Locale) groupDigits(n int) string {
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	digits := strconv.Itoa(n)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(l.c.groupSep)
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String()
+}
+
+var effortUnitPattern = regexp.MustCompile(`(\d+)(min|d|h)`)
+
+// EffortText localises the units of an effort formatted as by SonarQube or
+// formatEffort, e.g. "1h30min" or "2d 4h"
+func (l *Locale) EffortText(s string) string {
+	return effortUnitPattern.ReplaceAllStringFunc(s, func(part string) string {
+		m := effortUnitPattern.FindStringSubmatch(part)
+		switch m[2] {
+		case "d":
+			return m[1] + l.c.dayUnit
+		case "h":
+			return m[1] + l.c.hourUnit
+		default:
+			return m[1] + l.c.minuteUnit
+		}
+	})
+}
+
+// Effort formats minutes of effort in working days, hours and minutes
+func (l *Locale) Effort(minutes, dayHours int) string {
+	return l.EffortText(formatEffort(minutes, dayHours))
+}
+
+// DebtChange formats a signed remediation effort in minutes
+func (l *Locale) DebtChange(minutes, dayHours int) string {
+	return l.EffortText(formatDebtChange(minutes, dayHours))
+}
+
+// Measure localises a value of MetricsSummary: a number, a percentage, a
+// rating or a debt duration
+func (l *Locale) Measure(s string) string {
+	return l.EffortText(l.Number(s))
+}
+
+// PersonDays formats minutes of effort as working days
+func (l *Locale) PersonDays(minutes, dayHours int) string {
+	return l.Float(personDays(minutes, dayHours), 1)
+}
+
+// MetricValue formats the value of a selected metric according to its type
+func (l *Locale) MetricValue(m MetricValue) string {
+	if m.Value == "" {
+		return m.Formatted
+	}
+	switch m.Type {
+	case "INT", "FLOAT", "PERCENT":
+		return l.Number(m.Formatted)
+	case "MILLISEC":
+		number := strings.TrimRight(m.Formatted, "ms")
+		return l.Number(number) + strings.TrimPrefix(m.Formatted, number)
+	case "WORK_DUR":
+		return l.EffortText(m.Formatted)
+	case "BOOL":
+		if m.Value == "true" {
+			return l.T("common.yes")
+		}
+		return l.T("common.no")
+	case "LEVEL":
+		return l.QualityGate(m.Value)
+	}
+	return m.Formatted
+}
+
+// IssueFilter describes the severities and types a report is limited to
+func (l *Locale) IssueFilter(data *ReportData) string {
+	var parts []string
+	if len(data.Severities) > 0 {
+		parts = append(parts, l.T("info.filterSeverity", l.list(data.Severities, l.Severity)))
+	}
+	if len(data.Types) > 0 {
+		parts = append(parts, l.T("info.filterType", l.list(data.Types, l.IssueType)))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// columns returns the names of table columns
+func (l *Locale) columns(keys ...string) []string {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = l.T("col." + key)
+	}
+	return names
+}
+
+func (l *Locale) list(values []string, name func(string) string) string {
+	return strings.Join(l.names(values, name), ", ")
+}
+
+func (l *Locale) names(values []string, name func(string) string) []string {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = name(v)
+	}
+	return names
+}
+
+// ScoreWeights describes the weights of issue scores, e.g. "severity 35%, type 15%"
+func (l *Locale) ScoreWeights(w ScoreWeights) string {
+	total := w.total()
+	if total == 0 {
+		return ""
+	}
+	parts := []string{}
+	for _, f := range []struct {
+		name   string
+		weight float64
+	}{
+		{"severity", w.Severity}, {"type", w.Type}, {"age", w.Age},
+		{"hotness", w.Hotness}, {"lineCoverage", w.LineCoverage}, {"effort", w.Effort},
+	} {
+		if f.weight > 0 {
+			parts = append(parts, l.T("score."+f.name)+" "+l.Float(f.weight/total*100, 0)+"%")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// PolicyExplanation explains the outcome of a policy rule
+func (l *Locale) PolicyExplanation(r PolicyRuleResult) string {
+	switch r.Type {
+	case PolicyRuleMetric:
+		if r.Actual == "" {
+			return l.T("policy.noValue", r.Metric)
+		}
+		if _, err := policyNumber(r.Actual); err != nil {
+			return l.T("policy.notNumeric", r.Metric, r.Actual)
+		}
+		return l.T("policy.metric", r.Metric, l.Measure(r.Actual), r.Operator, l.Measure(r.Threshold))
+	case PolicyRuleIssues:
+		if r.Incomplete {
+			return l.T("policy.issuesPartial", l.Int(r.Found), l.policyIssues(r), l.Int(r.Checked), l.Int(r.Max))
+		}
+		return l.T("policy.issues", l.Int(r.Found), l.policyIssues(r), l.Int(r.Max))
+	case PolicyRuleQualityGate:
+		allowed := make([]string, len(r.Allow))
+		for i, s := range r.Allow {
+			allowed[i] = l.QualityGate(s)
+		}
+		return l.T("policy.qualityGate", l.QualityGate(r.GateStatus), strings.Join(allowed, " "+l.T("common.or")+" "))
+	}
+	return ""
+}
+
+// policyIssues describes the issues an issue rule counts, e.g. "BLOCKER
+// issues older than 7 days"
+func (l *Locale) policyIssues(r PolicyRuleResult) string {
+	var kinds []string
+	if len(r.Severities) > 0 {
+		kinds = append(kinds, strings.Join(l.names(r.Severities, l.Severity), "/"))
+	}
+	if len(r.Types) > 0 {
+		kinds = append(kinds, strings.Join(l.names(r.Types, l.IssueType), "/"))
+	}
+
+	parts := []string{l.T("policy.anyIssues")}
+	if len(kinds) > 0 {
+		parts[0] = l.T("policy.issuesOf", strings.Join(kinds, " "))
+	}
+	if len(r.Rules) > 0 {
+		parts = append(parts, l.T("policy.ofRule", strings.Join(r.Rules, ", ")))
+	}
+	if len(r.Tags) > 0 {
+		parts = append(parts, l.T("policy.tagged", strings.Join(r.Tags, ", ")))
+	}
+	if r.OlderThanDays > 0 {
+		parts = append(parts, l.T("policy.olderThan", l.Int(r.OlderThanDays)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// JoinKeys lists up to max issue keys, noting how many were left out
+func (l *Locale) JoinKeys(keys []string, max int) string {
+	if len(keys) <= max {
+		return strings.Join(keys, ", ")
+	}
+	return l.T("policy.moreKeys", strings.Join(keys[:max], ", "), l.Int(len(keys)-max))
+}
+
+// SuppressionTarget describes what a suppression applies to
+func (l *Locale) SuppressionTarget(s Suppression) string {
+	switch {
+	case s.Issue != "":
+		return l.T("suppressed.issue", s.Issue)
+	case s.Fingerprint != "":
+		return l.T("suppressed.fingerprint", s.Fingerprint)
+	case s.Rule != "" && s.Path != "":
+		return l.T("suppressed.ruleInPath", s.Rule, s.Path)
+	case s.Rule != "":
+		return l.T("suppressed.rule", s.Rule)
+	default:
+		return l.T("suppressed.path", s.Path)
+	}
+}
@@ -0,0 +1,328 @@
+package report
+
+// englishCatalogue is the default language, which other languages fall back
+// to for messages they lack
+var englishCatalogue = catalogue{
+	name: "English",
+
+	timeLayout:      "2006-01-02 15:04:05",
+	dateLayout:      "January 02, 2006",
+	shortDateLayout: "2006-01-02",
+	months: [12]string{"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December"},
+
+	decimalSep: ".",
+	groupSep:   ",",
+
+	dayUnit:    "d",
+	hourUnit:   "h",
+	minuteUnit: "min",
+
+	messages: map[string]string{
+		// Titles and footers
+		"report.title":            "SonarQube Analysis Report",
+		"report.subtitle":         "Code Quality Analysis Summary",
+		"report.historicalBanner": "Historical report as of %s.",
+		"report.historicalNote":   "Sections marked as reconstructed were rebuilt from SonarQube's history and may differ slightly from a report generated at the time.",
+		"report.generatedBy":      "Report generated by %s",
+		"activity.title":          "SonarQube Activity Report",
+		"activity.subtitle":       "%s — %s to %s",
+		"activity.generatedBy":    "Activity report generated by %s",
+		"diff.titleBranches":      "SonarQube Branch Comparison",
+		"diff.titleReports":       "SonarQube Delta Report",
+		"diff.subtitleBranches":   "%s compared with %s",
+		"diff.subtitleReports":    "what changed between two reports",
+		"diff.generatedBranches":  "Comparison generated by %s",
+		"diff.generatedReports":   "Delta report generated by %s",
+
+		// Project information
+		"info.title":                "Project Information",
+		"info.project":              "Project",
+		"info.projectName":          "Project Name",
+		"info.projectKey":           "Project Key",
+		"info.branch":               "Branch",
+		"info.team":                 "Team",
+		"info.teamValue":            "%s (files owned per CODEOWNERS)",
+		"info.profile":              "Profile",
+		"info.issueFilter":          "Issue Filter",
+		"info.filterSeverity":       "Severity %s",
+		"info.filterType":           "Type %s",
+		"info.generated":            "Report Generated",
+		"info.lastAnalysis":         "Last Analysis",
+		"info.lastAnalysisInPeriod": "Last Analysis in Period",
+		"info.asOf":                 "As Of",
+		"info.period":               "Period",
+		"info.periodValue":          "%s to %s",
+		"info.redacted":             "Redacted",
+		"info.redactedValue":        "%s possible secret(s) masked in messages and code snippets",
+
+		// Quality gate
+		"qualityGate.title":         "Quality Gate",
+		"qualityGate.OK":            "PASSED",
+		"qualityGate.WARN":          "WARNING",
+		"qualityGate.ERROR":         "FAILED",
+		"qualityGate.passedTitle":   "Congratulations!",
+		"qualityGate.passedText":    "Your code meets all quality standards.",
+		"qualityGate.warnTitle":     "Attention needed.",
+		"qualityGate.warnText":      "Some quality thresholds are close to failing.",
+		"qualityGate.failedTitle":   "Action required!",
+		"qualityGate.failedText":    "Your code does not meet quality standards.",
+		"qualityGate.reconstructed": "Reconstructed from the quality gate status recorded at the time. Condition details are not kept in history.",
+		"qualityGate.conditions":    "Quality Gate Conditions",
+		"qualityGate.conditionOK":   "OK",
+		"qualityGate.conditionFail": "FAIL",
+		"qualityGate.unchanged":     "Unchanged: %s",
+
+		// Policy compliance
+		"policy.title":          "Policy Compliance",
+		"policy.compliant":      "Compliant with policy %s",
+		"policy.notCompliant":   "Not compliant with policy %s: %s rule(s) failed",
+		"policyStatus.passed":   "passed",
+		"policyStatus.failed":   "failed",
+		"policy.noValue":        "%s has no value in this report, so it cannot be verified",
+		"policy.notNumeric":     "%s value %q is not numeric",
+		"policy.metric":         "%s is %s, required %s %s",
+		"policy.issues":         "%s %s, at most %s allowed",
+		"policy.issuesPartial":  "%s %s among the %s issues checked, at most %s allowed; the remaining open issues could not be checked",
+		"policy.qualityGate":    "quality gate is %s, required %s",
+		"policy.anyIssues":      "issues",
+		"policy.issuesOf":       "%s issues",
+		"policy.ofRule":         "of rule %s",
+		"policy.tagged":         "tagged %s",
+		"policy.olderThan":      "older than %s days",
+		"policy.moreKeys":       "%s and %s more",
+		"score.severity":        "severity",
+		"score.type":            "type",
+		"score.age":             "age",
+		"score.hotness":         "hotness",
+		"score.lineCoverage":    "line coverage",
+		"score.effort":          "effort",
+		"topIssues.title":       "Top %d Things to Fix This Week",
+		"topIssues.ranking":     "Ranked by risk score (0-100) weighing %s.",
+		"topIssues.noCoverage":  "Line coverage was not available, so coverage counted as neutral.",
+		"baseline.title":        "New Since Baseline",
+		"baseline.summary":      "%s new issue(s) since the baseline; %s accepted issue(s) in the baseline are not listed here.",
+		"baseline.frozen":       "Baseline frozen %s",
+		"baseline.refreshed":    ", refreshed %s",
+		"baseline.new":          "New",
+		"baseline.inBaseline":   "In Baseline",
+		"baseline.showing":      "Showing 50 of %s new issues.",
+		"baseline.truncated":    "The project has more open issues than can be fetched, so only the first were counted.",
+		"baseline.noNewIssues":  "No new issues since the baseline.",
+		"baseline.newIssues":    "New issues",
+		"common.yes":            "Yes",
+		"common.no":             "No",
+		"common.or":             "or",
+		"common.showing":        "Showing 50 of %s issues.",
+		"common.showingShort":   "Showing 25 of %s.",
+		"common.issueLocation":  "File: %s | Line: %d | Rule: %s",
+		"common.changeLocation": "File: %s | Line: %d",
+		"common.issueEffort":    "Effort: %s",
+		"common.expandAll":      "Expand all",
+		"common.collapseAll":    "Collapse all",
+		"common.print":          "Print",
+		"common.sortHint":       "Click a column header to sort the table.",
+
+		// Table columns
+		"col.number":       "#",
+		"col.score":        "Score",
+		"col.severity":     "Severity",
+		"col.type":         "Type",
+		"col.file":         "File",
+		"col.line":         "Line",
+		"col.effort":       "Effort",
+		"col.message":      "Message",
+		"col.metric":       "Metric",
+		"col.status":       "Status",
+		"col.value":        "Value",
+		"col.actualValue":  "Actual Value",
+		"col.threshold":    "Threshold",
+		"col.rule":         "Rule",
+		"col.result":       "Result",
+		"col.explanation":  "Explanation",
+		"col.description":  "Description",
+		"col.domain":       "Domain",
+		"col.language":     "Language",
+		"col.linesOfCode":  "Lines of Code",
+		"col.share":        "Share",
+		"col.issues":       "Issues",
+		"col.issueDensity": "Issues / 1k Lines",
+		"col.count":        "Count",
+		"col.percentage":   "Percentage",
+		"col.owner":        "Owner",
+		"col.owners":       "Owners",
+		"col.property":     "Property",
+		"col.personDays":   "Person-days",
+		"col.author":       "Author",
+		"col.priority":     "Priority",
+		"col.category":     "Category",
+		"col.location":     "Location",
+		"col.reason":       "Reason",
+		"col.expires":      "Expires",
+		"col.activity":     "Activity",
+		"col.change":       "Change",
+		"col.trend":        "Trend",
+		"col.base":         "Base",
+		"col.head":         "Head",
+		"col.from":         "From",
+		"col.to":           "To",
+		"col.report":       "Report",
+		"col.generated":    "Generated",
+
+		// Metrics
+		"metrics.title":              "Metrics Overview",
+		"metrics.reconstructed":      "Reconstructed from the measure history of the last analysis at or before this date.",
+		"metrics.dashboard":          "Code Health Dashboard",
+		"metrics.bugs":               "Bugs",
+		"metrics.vulnerabilities":    "Vulnerabilities",
+		"metrics.codeSmells":         "Code Smells",
+		"metrics.rating":             "Rating: %s",
+		"metrics.additional":         "Additional Metrics",
+		"metrics.linesOfCode":        "Lines of Code",
+		"metrics.linesOfCodeDesc":    "Total lines analyzed",
+		"metrics.coverage":           "Coverage",
+		"metrics.coverageDesc":       "Test coverage percentage",
+		"metrics.duplications":       "Duplications",
+		"metrics.duplicationsDesc":   "Duplicated code percentage",
+		"metrics.technicalDebt":      "Technical Debt",
+		"metrics.technicalDebtDesc":  "Estimated time to fix all issues",
+		"metrics.selected":           "Selected Metrics",
+		"metrics.newCode":            "New Code Analysis",
+		"metrics.newBugs":            "New Bugs",
+		"metrics.newVulnerabilities": "New Vulnerabilities",
+		"metrics.newCodeSmells":      "New Code Smells",
+		"metrics.newCoverage":        "New Coverage",
+		"metrics.newDuplications":    "New Duplications",
+		"languages.title":            "Languages",
+		"languages.unknown":          "Unknown",
+
+		// Names of the metrics compared in delta reports
+		"metricName.Bugs":                   "Bugs",
+		"metricName.Vulnerabilities":        "Vulnerabilities",
+		"metricName.Code Smells":            "Code Smells",
+		"metricName.Coverage":               "Coverage",
+		"metricName.Duplications":           "Duplications",
+		"metricName.Lines of Code":          "Lines of Code",
+		"metricName.Technical Debt":         "Technical Debt",
+		"metricName.Reliability Rating":     "Reliability Rating",
+		"metricName.Security Rating":        "Security Rating",
+		"metricName.Maintainability Rating": "Maintainability Rating",
+
+		// Issues
+		"issues.title":           "Issues Analysis",
+		"issues.reconstructed":   "Reconstructed: issues created before this date and not yet closed or resolved at the time. Code snippets are omitted because the current source may differ.",
+		"issues.total":           "Total Issues",
+		"issues.totalValue":      "Total Issues: %s",
+		"issues.suppressedNote":  "%s suppressed issue(s) are not counted; see the appendix.",
+		"issues.byType":          "Issues by Type",
+		"issues.bySeverity":      "Issues by Severity",
+		"owners.title":           "Issues by Owner",
+		"owners.note":            "Issues in files with several owners count for each of them.",
+		"details.title":          "%s Issues (%s)",
+		"details.list":           "%s Issues List:",
+		"details.showingList":    "Showing 25 of %s %s issues. See SonarQube for full list.",
+		"details.expand":         "Click to expand %s issues with details and code",
+		"details.problematic":    "Problematic Code:",
+		"details.howToFix":       "How to Fix:",
+		"details.note":           "Note:",
+		"details.showingDetails": "Showing detailed view for first 10 of %s %s issues.",
+		"severity.BLOCKER":       "BLOCKER",
+		"severity.CRITICAL":      "CRITICAL",
+		"severity.MAJOR":         "MAJOR",
+		"severity.MINOR":         "MINOR",
+		"severity.INFO":          "INFO",
+		"severityTitle.BLOCKER":  "Blocker",
+		"severityTitle.CRITICAL": "Critical",
+		"severityTitle.MAJOR":    "Major",
+		"severityTitle.MINOR":    "Minor",
+		"severityTitle.INFO":     "Info",
+		"type.BUG":               "BUG",
+		"type.VULNERABILITY":     "VULNERABILITY",
+		"type.CODE_SMELL":        "CODE_SMELL",
+
+		// Remediation plan
+		"remediation.title":      "Remediation Plan",
+		"remediation.intro":      "Fixing the %s issues in this report takes an estimated %s, or %s of %d hours.",
+		"remediation.personDays": "%s person-days",
+		"remediation.truncated":  "The project has more open issues than can be fetched, so the estimate only covers the first %s.",
+		"remediation.bySeverity": "By Severity",
+		"remediation.byType":     "By Type",
+		"remediation.byRule":     "Largest Rules",
+		"remediation.byFile":     "Largest Files",
+		"remediation.byAuthor":   "By Author",
+
+		// Security hotspots
+		"hotspots.title":          "Security Hotspots",
+		"hotspots.unavailable":    "Not available for historical reports: SonarQube keeps no history of security hotspots.",
+		"hotspots.total":          "Total Hotspots",
+		"hotspots.totalValue":     "Total Hotspots: %s",
+		"hotspots.byPriority":     "Hotspots by Priority",
+		"hotspots.details":        "Hotspot Details",
+		"hotspots.detailsShort":   "Details:",
+		"hotspots.expand":         "Click to expand security hotspots",
+		"hotspots.showing":        "Showing first 20 of %s hotspots.",
+		"hotspots.none":           "No Security Hotspots Found",
+		"hotspots.noneText":       "Great job! No security hotspots detected in this analysis.",
+		"priority.HIGH":           "HIGH",
+		"priority.MEDIUM":         "MEDIUM",
+		"priority.LOW":            "LOW",
+		"hotspotStatus.TO_REVIEW": "TO_REVIEW",
+		"hotspotStatus.REVIEWED":  "REVIEWED",
+		"summary.title":           "Summary",
+		"summary.actionRequired":  "Action Required",
+		"summary.qualityGate":     "Quality Gate: %s",
+		"summary.reviewIssues":    "Please review and fix the issues above",
+		"suppressed.title":        "Appendix: Suppressed Issues",
+		"suppressed.intro":        "These %s issue(s) are hidden from this report by the suppression list. SonarQube metrics above still count them.",
+		"suppressed.expiredTitle": "Expired suppressions",
+		"suppressed.expiredNote":  "(no longer applied):",
+		"suppressed.expired":      "(expired %s)",
+		"suppressed.issue":        "issue %s",
+		"suppressed.fingerprint":  "fingerprint %s",
+		"suppressed.rule":         "rule %s",
+		"suppressed.ruleInPath":   "rule %s in %s",
+		"suppressed.path":         "path %s",
+
+		// Activity reports
+		"activity.summary":       "Summary",
+		"activity.truncated":     "The period has more issues than can be fetched, so the lists and counts are incomplete.",
+		"activity.opened":        "Opened",
+		"activity.stillOpen":     "%s (%s still open)",
+		"activity.fixed":         "Fixed",
+		"activity.falsePositive": "Marked false positive",
+		"activity.wontFix":       "Marked won't fix",
+		"activity.debt":          "Technical Debt",
+		"activity.debtAdded":     "Added by opened issues",
+		"activity.debtRemoved":   "Removed by resolved issues",
+		"activity.debtNet":       "Net change",
+		"activity.openedTitle":   "Opened Issues",
+		"activity.fixedTitle":    "Fixed Issues",
+		"activity.falsePosTitle": "Marked False Positive",
+		"activity.wontFixTitle":  "Marked Won't Fix",
+		"activity.openedList":    "Opened issues",
+		"activity.fixedList":     "Fixed issues",
+
+		// Delta reports
+		"diff.comparedBranches":       "Compared Branches",
+		"diff.comparedReports":        "Compared Reports",
+		"diff.ratingsAndMetrics":      "Ratings and Metrics",
+		"diff.metricDeltas":           "Metric Deltas",
+		"diff.changed":                "changed",
+		"diff.issueChanges":           "Issue Changes",
+		"diff.onlyIn":                 "Only in %s",
+		"diff.differentSeverity":      "Different severity",
+		"diff.inBoth":                 "In both",
+		"diff.newIssues":              "New issues",
+		"diff.fixedIssues":            "Fixed issues",
+		"diff.severityChanges":        "Severity changes",
+		"diff.unchanged":              "Unchanged",
+		"diff.newIssuesTitle":         "New Issues",
+		"diff.fixedIssuesTitle":       "Fixed Issues",
+		"diff.differentSeverityTitle": "Different Severity",
+		"diff.severityChangesTitle":   "Severity Changes",
+		"diff.matchedByFingerprint":   "%s issue(s) changed key and were matched by rule, file and code.",
+		"trend.better":                "better",
+		"trend.worse":                 "worse",
+		"trend.unchanged":             "unchanged",
+	},
+}
@@ -0,0 +1,327 @@
+package report
+
+// indonesianCatalogue renders reports in Bahasa Indonesia
+var indonesianCatalogue = catalogue{
+	name: "Bahasa Indonesia",
+
+	timeLayout:      "02 January 2006 15.04.05",
+	dateLayout:      "02 January 2006",
+	shortDateLayout: "02/01/2006",
+	months: [12]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni",
+		"Juli", "Agustus", "September", "Oktober", "November", "Desember"},
+
+	decimalSep: ",",
+	groupSep:   ".",
+
+	dayUnit:    "h",
+	hourUnit:   "j",
+	minuteUnit: "mnt",
+
+	messages: map[string]string{
+		// Titles and footers
+		"report.title":            "Laporan Analisis SonarQube",
+		"report.subtitle":         "Ringkasan Analisis Kualitas Kode",
+		"report.historicalBanner": "Laporan historis per %s.",
+		"report.historicalNote":   "Bagian yang ditandai direkonstruksi disusun ulang dari riwayat SonarQube dan dapat sedikit berbeda dari laporan yang dibuat pada saat itu.",
+		"report.generatedBy":      "Laporan dibuat oleh %s",
+		"activity.title":          "Laporan Aktivitas SonarQube",
+		"activity.subtitle":       "%s — %s s.d. %s",
+		"activity.generatedBy":    "Laporan aktivitas dibuat oleh %s",
+		"diff.titleBranches":      "Perbandingan Cabang SonarQube",
+		"diff.titleReports":       "Laporan Perubahan SonarQube",
+		"diff.subtitleBranches":   "%s dibandingkan dengan %s",
+		"diff.subtitleReports":    "perubahan di antara dua laporan",
+		"diff.generatedBranches":  "Perbandingan dibuat oleh %s",
+		"diff.generatedReports":   "Laporan perubahan dibuat oleh %s",
+
+		// Project information
+		"info.title":                "Informasi Proyek",
+		"info.project":              "Proyek",
+		"info.projectName":          "Nama Proyek",
+		"info.projectKey":           "Kunci Proyek",
+		"info.branch":               "Cabang",
+		"info.team":                 "Tim",
+		"info.teamValue":            "%s (berkas milik tim menurut CODEOWNERS)",
+		"info.profile":              "Profil",
+		"info.issueFilter":          "Filter Isu",
+		"info.filterSeverity":       "Keparahan %s",
+		"info.filterType":           "Jenis %s",
+		"info.generated":            "Laporan Dibuat",
+		"info.lastAnalysis":         "Analisis Terakhir",
+		"info.lastAnalysisInPeriod": "Analisis Terakhir dalam Periode",
+		"info.asOf":                 "Per Tanggal",
+		"info.period":               "Periode",
+		"info.periodValue":          "%s sampai %s",
+		"info.redacted":             "Disamarkan",
+		"info.redactedValue":        "%s kemungkinan rahasia disamarkan dalam pesan dan cuplikan kode",
+
+		// Quality gate
+		"qualityGate.title":         "Quality Gate",
+		"qualityGate.OK":            "LULUS",
+		"qualityGate.WARN":          "PERINGATAN",
+		"qualityGate.ERROR":         "GAGAL",
+		"qualityGate.passedTitle":   "Selamat!",
+		"qualityGate.passedText":    "Kode Anda memenuhi semua standar kualitas.",
+		"qualityGate.warnTitle":     "Perlu perhatian.",
+		"qualityGate.warnText":      "Beberapa ambang kualitas hampir tidak terpenuhi.",
+		"qualityGate.failedTitle":   "Tindakan diperlukan!",
+		"qualityGate.failedText":    "Kode Anda belum memenuhi standar kualitas.",
+		"qualityGate.reconstructed": "Direkonstruksi dari status quality gate yang tercatat saat itu. Rincian kondisi tidak disimpan dalam riwayat.",
+		"qualityGate.conditions":    "Kondisi Quality Gate",
+		"qualityGate.conditionOK":   "OK",
+		"qualityGate.conditionFail": "GAGAL",
+		"qualityGate.unchanged":     "Tidak berubah: %s",
+
+		// Policy compliance
+		"policy.title":          "Kepatuhan Kebijakan",
+		"policy.compliant":      "Patuh terhadap kebijakan %s",
+		"policy.notCompliant":   "Tidak patuh terhadap kebijakan %s: %s aturan gagal",
+		"policyStatus.passed":   "lulus",
+		"policyStatus.failed":   "gagal",
+		"policy.noValue":        "%s tidak memiliki nilai dalam laporan ini, sehingga tidak dapat diverifikasi",
+		"policy.notNumeric":     "nilai %s %q bukan angka",
+		"policy.metric":         "%s bernilai %s, disyaratkan %s %s",
+		"policy.issues":         "%s %s, paling banyak %s diizinkan",
+		"policy.issuesPartial":  "%s %s di antara %s isu yang diperiksa, paling banyak %s diizinkan; isu terbuka lainnya tidak dapat diperiksa",
+		"policy.qualityGate":    "quality gate %s, disyaratkan %s",
+		"policy.anyIssues":      "isu",
+		"policy.issuesOf":       "isu %s",
+		"policy.ofRule":         "dari aturan %s",
+		"policy.tagged":         "bertag %s",
+		"policy.olderThan":      "lebih lama dari %s hari",
+		"policy.moreKeys":       "%s dan %s lainnya",
+		"score.severity":        "keparahan",
+		"score.type":            "jenis",
+		"score.age":             "usia",
+		"score.hotness":         "keaktifan berkas",
+		"score.lineCoverage":    "cakupan baris",
+		"score.effort":          "upaya",
+		"topIssues.title":       "%d Hal Utama untuk Diperbaiki Minggu Ini",
+		"topIssues.ranking":     "Diurutkan menurut skor risiko (0-100) dengan bobot %s.",
+		"topIssues.noCoverage":  "Cakupan baris tidak tersedia, sehingga cakupan dihitung netral.",
+		"baseline.title":        "Baru Sejak Baseline",
+		"baseline.summary":      "%s isu baru sejak baseline; %s isu yang telah diterima dalam baseline tidak dicantumkan di sini.",
+		"baseline.frozen":       "Baseline dibekukan %s",
+		"baseline.refreshed":    ", diperbarui %s",
+		"baseline.new":          "Baru",
+		"baseline.inBaseline":   "Dalam Baseline",
+		"baseline.showing":      "Menampilkan 50 dari %s isu baru.",
+		"baseline.truncated":    "Proyek memiliki lebih banyak isu terbuka daripada yang dapat diambil, sehingga hanya yang pertama yang dihitung.",
+		"baseline.noNewIssues":  "Tidak ada isu baru sejak baseline.",
+		"baseline.newIssues":    "Isu baru",
+		"common.yes":            "Ya",
+		"common.no":             "Tidak",
+		"common.or":             "atau",
+		"common.showing":        "Menampilkan 50 dari %s isu.",
+		"common.showingShort":   "Menampilkan 25 dari %s.",
+		"common.issueLocation":  "Berkas: %s | Baris: %d | Aturan: %s",
+		"common.changeLocation": "Berkas: %s | Baris: %d",
+		"common.issueEffort":    "Upaya: %s",
+		"common.expandAll":      "Buka semua",
+		"common.collapseAll":    "Tutup semua",
+		"common.print":          "Cetak",
+		"common.sortHint":       "Klik judul kolom untuk mengurutkan tabel.",
+
+		// Table columns
+		"col.number":       "#",
+		"col.score":        "Skor",
+		"col.severity":     "Keparahan",
+		"col.type":         "Jenis",
+		"col.file":         "Berkas",
+		"col.line":         "Baris",
+		"col.effort":       "Upaya",
+		"col.message":      "Pesan",
+		"col.metric":       "Metrik",
+		"col.status":       "Status",
+		"col.value":        "Nilai",
+		"col.actualValue":  "Nilai Aktual",
+		"col.threshold":    "Ambang Batas",
+		"col.rule":         "Aturan",
+		"col.result":       "Hasil",
+		"col.explanation":  "Penjelasan",
+		"col.description":  "Deskripsi",
+		"col.domain":       "Domain",
+		"col.language":     "Bahasa",
+		"col.linesOfCode":  "Baris Kode",
+		"col.share":        "Porsi",
+		"col.issues":       "Isu",
+		"col.issueDensity": "Isu / 1rb Baris",
+		"col.count":        "Jumlah",
+		"col.percentage":   "Persentase",
+		"col.owner":        "Pemilik",
+		"col.owners":       "Pemilik",
+		"col.property":     "Properti",
+		"col.personDays":   "Hari-orang",
+		"col.author":       "Penulis",
+		"col.priority":     "Prioritas",
+		"col.category":     "Kategori",
+		"col.location":     "Lokasi",
+		"col.reason":       "Alasan",
+		"col.expires":      "Kedaluwarsa",
+		"col.activity":     "Aktivitas",
+		"col.change":       "Perubahan",
+		"col.trend":        "Tren",
+		"col.base":         "Basis",
+		"col.head":         "Pembanding",
+		"col.from":         "Dari",
+		"col.to":           "Menjadi",
+		"col.report":       "Laporan",
+		"col.generated":    "Dibuat",
+
+		// Metrics
+		"metrics.title":              "Ikhtisar Metrik",
+		"metrics.reconstructed":      "Direkonstruksi dari riwayat pengukuran analisis terakhir pada atau sebelum tanggal ini.",
+		"metrics.dashboard":          "Dasbor Kesehatan Kode",
+		"metrics.bugs":               "Bug",
+		"metrics.vulnerabilities":    "Kerentanan",
+		"metrics.codeSmells":         "Code Smell",
+		"metrics.rating":             "Peringkat: %s",
+		"metrics.additional":         "Metrik Tambahan",
+		"metrics.linesOfCode":        "Baris Kode",
+		"metrics.linesOfCodeDesc":    "Total baris yang dianalisis",
+		"metrics.coverage":           "Cakupan",
+		"metrics.coverageDesc":       "Persentase cakupan pengujian",
+		"metrics.duplications":       "Duplikasi",
+		"metrics.duplicationsDesc":   "Persentase kode duplikat",
+		"metrics.technicalDebt":      "Utang Teknis",
+		"metrics.technicalDebtDesc":  "Perkiraan waktu untuk memperbaiki semua isu",
+		"metrics.selected":           "Metrik Terpilih",
+		"metrics.newCode":            "Analisis Kode Baru",
+		"metrics.newBugs":            "Bug Baru",
+		"metrics.newVulnerabilities": "Kerentanan Baru",
+		"metrics.newCodeSmells":      "Code Smell Baru",
+		"metrics.newCoverage":        "Cakupan Baru",
+		"metrics.newDuplications":    "Duplikasi Baru",
+		"languages.title":            "Bahasa",
+		"languages.unknown":          "Tidak diketahui",
+
+		// Names of the metrics compared in delta reports
+		"metricName.Bugs":                   "Bug",
+		"metricName.Vulnerabilities":        "Kerentanan",
+		"metricName.Code Smells":            "Code Smell",
+		"metricName.Coverage":               "Cakupan",
+		"metricName.Duplications":           "Duplikasi",
+		"metricName.Lines of Code":          "Baris Kode",
+		"metricName.Technical Debt":         "Utang Teknis",
+		"metricName.Reliability Rating":     "Peringkat Keandalan",
+		"metricName.Security Rating":        "Peringkat Keamanan",
+		"metricName.Maintainability Rating": "Peringkat Keterpeliharaan",
+
+		// Issues
+		"issues.title":           "Analisis Isu",
+		"issues.reconstructed":   "Direkonstruksi: isu yang dibuat sebelum tanggal ini dan belum ditutup atau diselesaikan saat itu. Cuplikan kode dihilangkan karena kode sumber saat ini mungkin berbeda.",
+		"issues.total":           "Total Isu",
+		"issues.totalValue":      "Total Isu: %s",
+		"issues.suppressedNote":  "%s isu yang disembunyikan tidak dihitung; lihat lampiran.",
+		"issues.byType":          "Isu per Jenis",
+		"issues.bySeverity":      "Isu per Keparahan",
+		"owners.title":           "Isu per Pemilik",
+		"owners.note":            "Isu dalam berkas dengan beberapa pemilik dihitung untuk masing-masing pemilik.",
+		"details.title":          "Isu %s (%s)",
+		"details.list":           "Daftar Isu %s:",
+		"details.showingList":    "Menampilkan 25 dari %s isu %s. Lihat SonarQube untuk daftar lengkap.",
+		"details.expand":         "Klik untuk membuka isu %s beserta rincian dan kode",
+		"details.problematic":    "Kode Bermasalah:",
+		"details.howToFix":       "Cara Memperbaiki:",
+		"details.note":           "Catatan:",
+		"details.showingDetails": "Menampilkan rincian 10 pertama dari %s isu %s.",
+		"severity.BLOCKER":       "PEMBLOKIR",
+		"severity.CRITICAL":      "KRITIS",
+		"severity.MAJOR":         "MAYOR",
+		"severity.MINOR":         "MINOR",
+		"severity.INFO":          "INFO",
+		"severityTitle.BLOCKER":  "Pemblokir",
+		"severityTitle.CRITICAL": "Kritis",
+		"severityTitle.MAJOR":    "Mayor",
+		"severityTitle.MINOR":    "Minor",
+		"severityTitle.INFO":     "Info",
+		"type.BUG":               "BUG",
+		"type.VULNERABILITY":     "KERENTANAN",
+		"type.CODE_SMELL":        "CODE SMELL",
+
+		// Remediation plan
+		"remediation.title":      "Rencana Perbaikan",
+		"remediation.intro":      "Memperbaiki %s isu dalam laporan ini diperkirakan membutuhkan %s, atau %s dengan %d jam kerja per hari.",
+		"remediation.personDays": "%s hari-orang",
+		"remediation.truncated":  "Proyek memiliki lebih banyak isu terbuka daripada yang dapat diambil, sehingga perkiraan hanya mencakup %s isu pertama.",
+		"remediation.bySeverity": "Per Keparahan",
+		"remediation.byType":     "Per Jenis",
+		"remediation.byRule":     "Aturan Terbesar",
+		"remediation.byFile":     "Berkas Terbesar",
+		"remediation.byAuthor":   "Per Penulis",
+
+		// Security hotspots
+		"hotspots.title":          "Titik Rawan Keamanan",
+		"hotspots.unavailable":    "Tidak tersedia untuk laporan historis: SonarQube tidak menyimpan riwayat titik rawan keamanan.",
+		"hotspots.total":          "Total Titik Rawan",
+		"hotspots.totalValue":     "Total Titik Rawan: %s",
+		"hotspots.byPriority":     "Titik Rawan per Prioritas",
+		"hotspots.details":        "Rincian Titik Rawan",
+		"hotspots.detailsShort":   "Rincian:",
+		"hotspots.expand":         "Klik untuk membuka titik rawan keamanan",
+		"hotspots.showing":        "Menampilkan 20 pertama dari %s titik rawan.",
+		"hotspots.none":           "Tidak Ada Titik Rawan Keamanan",
+		"hotspots.noneText":       "Kerja bagus! Tidak ada titik rawan keamanan yang terdeteksi dalam analisis ini.",
+		"priority.HIGH":           "TINGGI",
+		"priority.MEDIUM":         "SEDANG",
+		"priority.LOW":            "RENDAH",
+		"hotspotStatus.TO_REVIEW": "PERLU DITINJAU",
+		"hotspotStatus.REVIEWED":  "SUDAH DITINJAU",
+		"summary.title":           "Ringkasan",
+		"summary.actionRequired":  "Tindakan Diperlukan",
+		"summary.qualityGate":     "Quality Gate: %s",
+		"summary.reviewIssues":    "Harap tinjau dan perbaiki isu di atas",
+		"suppressed.title":        "Lampiran: Isu yang Disembunyikan",
+		"suppressed.intro":        "%s isu ini disembunyikan dari laporan oleh daftar penyembunyian. Metrik SonarQube di atas tetap menghitungnya.",
+		"suppressed.expiredTitle": "Penyembunyian kedaluwarsa",
+		"suppressed.expiredNote":  "(tidak lagi diterapkan):",
+		"suppressed.expired":      "(kedaluwarsa %s)",
+		"suppressed.issue":        "isu %s",
+		"suppressed.fingerprint":  "sidik jari %s",
+		"suppressed.rule":         "aturan %s",
+		"suppressed.ruleInPath":   "aturan %s di %s",
+		"suppressed.path":         "jalur %s",
+
+		// Activity reports
+		"activity.summary":       "Ringkasan",
+		"activity.truncated":     "Periode ini memiliki lebih banyak isu daripada yang dapat diambil, sehingga daftar dan jumlahnya tidak lengkap.",
+		"activity.opened":        "Dibuka",
+		"activity.stillOpen":     "%s (%s masih terbuka)",
+		"activity.fixed":         "Diperbaiki",
+		"activity.falsePositive": "Ditandai positif palsu",
+		"activity.wontFix":       "Ditandai tidak akan diperbaiki",
+		"activity.debt":          "Utang Teknis",
+		"activity.debtAdded":     "Ditambah oleh isu yang dibuka",
+		"activity.debtRemoved":   "Dikurangi oleh isu yang diselesaikan",
+		"activity.debtNet":       "Perubahan bersih",
+		"activity.openedTitle":   "Isu Dibuka",
+		"activity.fixedTitle":    "Isu Diperbaiki",
+		"activity.falsePosTitle": "Ditandai Positif Palsu",
+		"activity.wontFixTitle":  "Ditandai Tidak Akan Diperbaiki",
+		"activity.openedList":    "Isu dibuka",
+		"activity.fixedList":     "Isu diperbaiki",
+
+		// Delta reports
+		"diff.comparedBranches":       "Cabang yang Dibandingkan",
+		"diff.comparedReports":        "Laporan yang Dibandingkan",
+		"diff.ratingsAndMetrics":      "Peringkat dan Metrik",
+		"diff.metricDeltas":           "Perubahan Metrik",
+		"diff.changed":                "berubah",
+		"diff.issueChanges":           "Perubahan Isu",
+		"diff.onlyIn":                 "Hanya di %s",
+		"diff.differentSeverity":      "Keparahan berbeda",
+		"diff.inBoth":                 "Di keduanya",
+		"diff.newIssues":              "Isu baru",
+		"diff.fixedIssues":            "Isu diperbaiki",
+		"diff.severityChanges":        "Perubahan keparahan",
+		"diff.unchanged":              "Tidak berubah",
+		"diff.newIssuesTitle":         "Isu Baru",
+		"diff.fixedIssuesTitle":       "Isu Diperbaiki",
+		"diff.differentSeverityTitle": "Keparahan Berbeda",
+		"diff.severityChangesTitle":   "Perubahan Keparahan",
+		"diff.matchedByFingerprint":   "%s isu berganti kunci dan dicocokkan berdasarkan aturan, berkas, dan kode.",
+		"trend.better":                "lebih baik",
+		"trend.worse":                 "lebih buruk",
+		"trend.unchanged":             "tidak berubah",
+	},
+}
@@ -0,0 +1,289 @@
+package report
+
+import (
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// languageCatalogTTL is how long language names are cached
+const languageCatalogTTL = time.Hour
+
+// languageHighlights maps SonarQube language keys to the languages of code
+// blocks, for keys that differ
+var languageHighlights = map[string]string{
+	"js":                   "javascript",
+	"ts":                   "typescript",
+	"py":                   "python",
+	"ipynb":                "python",
+	"cs":                   "csharp",
+	"objc":                 "objectivec",
+	"web":                  "html",
+	"docker":               "dockerfile",
+	"terraform":            "hcl",
+	"cloudformation":       "yaml",
+	"kubernetes":           "yaml",
+	"azureresourcemanager": "bicep",
+	"shell":                "bash",
+	"tsql":                 "sql",
+	"plsql":                "sql",
+	"flex":                 "actionscript",
+	"text":                 "",
+	"secrets":              "",
+}
+
+// highlightLanguage returns the code block language of a SonarQube language key
+func highlightLanguage(key string) string {
+	if lang, ok := languageHighlights[key]; ok {
+		return lang
+	}
+	return key
+}
+
+// getLanguageFromFile guesses the code block language from a file name, for
+// issues SonarQube reports no language for
+func getLanguageFromFile(filename string) string {
+	base := filepath.Base(filename)
+	switch {
+	case base == "Dockerfile" || strings.HasPrefix(base, "Dockerfile.") || strings.HasSuffix(base, ".dockerfile"):
+		return "dockerfile"
+	case base == "Makefile":
+		return "makefile"
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	switch ext {
+	case ".go":
+		return "go"
+	case ".tmpl", ".gotmpl":
+		return "gotemplate"
+	case ".java":
+		return "java"
+	case ".js", ".mjs", ".cjs":
+		return "javascript"
+	case ".ts", ".mts", ".cts":
+		return "typescript"
+	case ".py":
+		return "python"
+	case ".rb":
+		return "ruby"
+	case ".php":
+		return "php"
+	case ".cs":
+		return "csharp"
+	case ".cpp", ".cc", ".cxx", ".hpp":
+		return "cpp"
+	case ".c", ".h":
+		return "c"
+	case ".swift":
+		return "swift"
+	case ".kt", ".kts":
+		return "kotlin"
+	case ".rs":
+		return "rust"
+	case ".scala":
+		return "scala"
+	case ".vue":
+		return "vue"
+	case ".jsx":
+		return "jsx"
+	case ".tsx":
+		return "tsx"
+	case ".html", ".htm":
+		return "html"
+	case ".css":
+		return "css"
+	case ".scss":
+		return "scss"
+	case ".sql":
+		return "sql"
+	case ".xml":
+		return "xml"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".json":
+		return "json"
+	case ".tf", ".tfvars", ".hcl":
+		return "hcl"
+	case ".sh", ".bash":
+		return "bash"
+	default:
+		return ""
+	}
+}
+
+// LanguageSummary is the size and issues of one language of a project
+type LanguageSummary struct {
+	Key           string  `json:"key"` // SonarQube language key, empty for issues of unknown language
+	Name          string  `json:"name"`
+	Lines         int     `json:"lines"` // lines of code
+	Share         float64 `json:"share"` // percentage of the project's lines of code
+	Issues        int     `json:"issues"`
+	IssuesPerKLoc float64 `json:"issuesPerKloc,omitempty"`
+}
+
+// fetchFileLanguages returns the language of each file of a project by path.
+// It walks the whole component tree, so it is only called for issues whose
+// rule has no language.
+func (g *Generator) fetchFileLanguages(projectKey, branch string) (map[string]string, error) {
+	files, err := g.client.GetFileMeasures(projectKey, branch, []string{"ncloc"})
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make(map[string]string, len(files))
+	for _, f := range files {
+		if f.Language != "" {
+			languages[extractFileName(f.Key)] = f.Language
+		}
+	}
+	return languages, nil
+}
+
+// needFileLanguages reports whether some issue item has a rule SonarQube
+// knows no language for
+func (g *Generator) needFileLanguages(items []IssueItem) bool {
+	for _, item := range items {
+		if g.rules.Language(item.Rule) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// assignLanguages sets the language of each issue item from SonarQube: the
+// language of its rule, else that of its file when the files were fetched.
+// Items SonarQube knows no language for keep the one guessed from their file
+// name.
+func (g *Generator) assignLanguages(items []IssueItem, fileLanguages map[string]string) {
+	for i := range items {
+		key := g.rules.Language(items[i].Rule)
+		if key == "" {
+			key = fileLanguages[items[i].Component]
+		}
+		if key == "" {
+			continue
+		}
+		items[i].LanguageKey = key
+		if lang := highlightLanguage(key); lang != "" {
+			items[i].Language = lang
+		}
+	}
+}
+
+// languageCatalog caches the names of the languages of the server by key
+type languageCatalog struct {
+	client *sonarqube.Client
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	names     map[string]string
+	fetchedAt time.Time
+}
+
+// newLanguageCatalog creates a language catalog caching names for ttl
+func newLanguageCatalog(client *sonarqube.Client, ttl time.Duration) *languageCatalog {
+	return &languageCatalog{client: client, ttl: ttl}
+}
+
+// all returns the language names by key, fetching them when stale. When the
+// server cannot be reached the names fetched last are kept.
+func (c *languageCatalog) all() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.names != nil && time.Since(c.fetchedAt) < c.ttl {
+		return c.names
+	}
+
+	languages, err := c.client.GetLanguages()
+	if err != nil {
+		log.Printf("Warning: Failed to get languages: %v", err)
+		if c.names == nil {
+			return map[string]string{}
+		}
+		return c.names
+	}
+	c.names = make(map[string]string, len(languages))
+	for _, l := range languages {
+		c.names[l.Key] = l.Name
+	}
+	c.fetchedAt = time.Now()
+	return c.names
+}
+
+// parseLanguageDistribution parses the ncloc_language_distribution measure,
+// e.g. "go=1200;js=300"
+func parseLanguageDistribution(value string) map[string]int {
+	lines := make(map[string]int)
+	for _, part := range strings.Split(value, ";") {
+		key, n, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		if v, err := strconv.Atoi(n); err == nil {
+			lines[key] = v
+		}
+	}
+	return lines
+}
+
+// summarizeLanguages combines the lines of code per language with the
+// issues per language, largest languages first
+func summarizeLanguages(distribution string, items []IssueItem, names map[string]string) []LanguageSummary {
+	lines := parseLanguageDistribution(distribution)
+	issues := make(map[string]int)
+	for _, item := range items {
+		issues[item.LanguageKey]++
+	}
+	if len(lines) == 0 && len(issues) == 0 {
+		return nil
+	}
+
+	totalLines := 0
+	for _, n := range lines {
+		totalLines += n
+	}
+
+	keys := make(map[string]bool)
+	for key := range lines {
+		keys[key] = true
+	}
+	for key := range issues {
+		keys[key] = true
+	}
+
+	summaries := make([]LanguageSummary, 0, len(keys))
+	for key := range keys {
+		s := LanguageSummary{Key: key, Name: names[key], Lines: lines[key], Issues: issues[key]}
+		switch {
+		case key == "":
+			s.Name = "Unknown"
+		case s.Name == "":
+			s.Name = key
+		}
+		if totalLines > 0 {
+			s.Share = float64(s.Lines) / float64(totalLines) * 100
+		}
+		if s.Lines > 0 {
+			s.IssuesPerKLoc = float64(s.Issues) / float64(s.Lines) * 1000
+		}
+		summaries = append(summaries, s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Lines != summaries[j].Lines {
+			return summaries[i].Lines > summaries[j].Lines
+		}
+		if summaries[i].Issues != summaries[j].Issues {
+			return summaries[i].Issues > summaries[j].Issues
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+	return summaries
+}
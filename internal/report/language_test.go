@@ -0,0 +1,75 @@
+package report
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+func TestAssignLanguages(t *testing.T) {
+	rules := NewRuleCache(nil, "", 0, time.Hour)
+	rules.put("go:S1", "", "go")
+	rules.put("external:S1", "", "")
+	g := &Generator{rules: rules}
+
+	files := map[string]string{"web/app.ts": "ts", "src/main.go": "java"}
+	tests := []struct {
+		name     string
+		item     IssueItem
+		files    map[string]string
+		wantKey  string
+		wantLang string
+	}{
+		{name: "rule language", item: IssueItem{Rule: "go:S1", Component: "src/main.go", Language: "go"}, files: files, wantKey: "go", wantLang: "go"},
+		{name: "file language without rule language", item: IssueItem{Rule: "external:S1", Component: "web/app.ts"}, files: files, wantKey: "ts", wantLang: "typescript"},
+		{name: "no files fetched", item: IssueItem{Rule: "external:S1", Component: "web/app.ts", Language: "typescript"}, wantLang: "typescript"},
+		{name: "unknown keeps guess", item: IssueItem{Rule: "other:S1", Component: "x.rb", Language: "ruby"}, files: files, wantLang: "ruby"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := []IssueItem{tt.item}
+			g.assignLanguages(items, tt.files)
+			if items[0].LanguageKey != tt.wantKey || items[0].Language != tt.wantLang {
+				t.Fatalf("language = %q (%q), want %q (%q)", items[0].Language, items[0].LanguageKey, tt.wantLang, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestLanguageCatalog(t *testing.T) {
+	var requests int32
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"languages": [{"key": "go", "name": "Go"}, {"key": "ts", "name": "TypeScript"}]}`))
+	}))
+	defer server.Close()
+
+	catalog := newLanguageCatalog(sonarqube.NewClient(server.URL, "token"), time.Hour)
+	for i := 0; i < 3; i++ {
+		if got := catalog.all()["ts"]; got != "TypeScript" {
+			t.Fatalf("name of ts = %q", got)
+		}
+	}
+	if requests := atomic.LoadInt32(&requests); requests != 1 {
+		t.Fatalf("%d requests, want the names cached after the first", requests)
+	}
+
+	// Stale names are refetched, and kept when that fails
+	catalog.fetchedAt = time.Now().Add(-2 * time.Hour)
+	failing.Store(true)
+	if got := catalog.all()["go"]; got != "Go" {
+		t.Fatalf("name of go = %q after a failed refresh", got)
+	}
+	if requests := atomic.LoadInt32(&requests); requests != 2 {
+		t.Fatalf("%d requests, want a refresh of stale names", requests)
+	}
+}
@@ -3,8 +3,8 @@ package report
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"text/template"
-	"time"
 )
 
 // MarkdownGenerator generates markdown reports
@@ -15,14 +15,26 @@ func NewMarkdownGenerator() *MarkdownGenerator {
 	return &MarkdownGenerator{}
 }
 
+// Format describes markdown reports
+func (g *MarkdownGenerator) Format() ReportFormat {
+	return ReportFormat{
+		Name:        FormatMarkdown,
+		Title:       "Markdown",
+		Extension:   "md",
+		ContentType: "text/markdown; charset=utf-8",
+		Previewable: true,
+	}
+}
+
 // Generate generates a markdown report
 func (g *MarkdownGenerator) Generate(data *ReportData) ([]byte, error) {
-	tmpl, err := template.New("report").Funcs(template.FuncMap{
-		"severityIcon":    severityIcon,
+	if data.Activity != nil {
+		return g.generateActivity(data)
+	}
+
+	loc := NewLocale(data.Language, data.Timezone)
+	tmpl, err := template.New("report").Funcs(localeFuncs(loc, data.WorkingDayHours)).Funcs(template.FuncMap{
 		"qualityGateIcon": qualityGateIcon,
-		"qualityGateText": QualityGateText,
-		"formatTime":      formatTime,
-		"formatDate":      formatDate,
 		"getSortedSeverities": func(m map[string][]IssueItem) []string {
 			return GetSortedSeverities(m)
 		},
@@ -33,12 +45,32 @@ func (g *MarkdownGenerator) Generate(data *ReportData) ([]byte, error) {
 		"issueCount": func(m map[string][]IssueItem, sev string) int {
 			return len(m[sev])
 		},
+		"join":   strings.Join,
+		"orDash": orDash,
+		"effortTable": func(title string, groups []EffortGroup) map[string]interface{} {
+			return map[string]interface{}{"Title": title, "Groups": groups}
+		},
+		"severityGroups": func(groups []EffortGroup) []EffortGroup {
+			return renameGroups(groups, loc.Severity)
+		},
+		"typeGroups": func(groups []EffortGroup) []EffortGroup {
+			return renameGroups(groups, loc.IssueType)
+		},
+		"languageName": func(l LanguageSummary) string {
+			if l.Key == "" {
+				return loc.T("languages.unknown")
+			}
+			return l.Name
+		},
 		"hasCodeSnippet": func(s string) bool {
 			return s != ""
 		},
 		"add": func(a, b int) int {
 			return a + b
 		},
+		"sub": func(a, b int) int {
+			return a - b
+		},
 		"mul": func(a, b float64) float64 {
 			return a * b
 		},
@@ -64,12 +96,62 @@ func (g *MarkdownGenerator) Generate(data *ReportData) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func formatTime(t time.Time) string {
-	return t.Format("2006-01-02 15:04:05")
+// localeFuncs are the template functions rendering text, numbers, dates and
+// efforts in the language of a report
+func localeFuncs(loc *Locale, dayHours int) template.FuncMap {
+	return template.FuncMap{
+		"t":                 loc.T,
+		"int":               loc.Int,
+		"num":               loc.Number,
+		"pct":               loc.Percent,
+		"float1":            func(f float64) string { return loc.Float(f, 1) },
+		"formatTime":        loc.Time,
+		"dateTime":          loc.DateTime,
+		"formatDate":        loc.Date,
+		"shortDate":         loc.ShortDate,
+		"effort":            loc.EffortText,
+		"measure":           loc.Measure,
+		"severity":          loc.Severity,
+		"severityTitle":     loc.SeverityTitle,
+		"issueType":         loc.IssueType,
+		"priority":          loc.Priority,
+		"hotspotStatus":     loc.HotspotStatus,
+		"policyStatus":      loc.PolicyStatus,
+		"policyExplanation": loc.PolicyExplanation,
+		"suppressionTarget": loc.SuppressionTarget,
+		"joinKeys":          loc.JoinKeys,
+		"trend":             loc.Trend,
+		"metricName":        loc.MetricName,
+		"metricValue":       loc.MetricValue,
+		"issueFilter":       loc.IssueFilter,
+		"scoreWeights":      loc.ScoreWeights,
+		"qualityGateText":   loc.QualityGate,
+		"severityIcon": func(severity string) string {
+			return severityIcon(loc, severity)
+		},
+		"formatEffort": func(minutes int) string {
+			return loc.Effort(minutes, dayHours)
+		},
+		"formatDebtChange": func(minutes int) string {
+			return loc.DebtChange(minutes, dayHours)
+		},
+		"personDays": func(minutes int) string {
+			return loc.PersonDays(minutes, dayHours)
+		},
+		"bold": func(v interface{}) string {
+			return fmt.Sprintf("**%v**", v)
+		},
+	}
 }
 
-func formatDate(t time.Time) string {
-	return t.Format("January 02, 2006")
+// renameGroups returns effort groups with their keys named by name
+func renameGroups(groups []EffortGroup, name func(string) string) []EffortGroup {
+	renamed := make([]EffortGroup, len(groups))
+	for i, g := range groups {
+		g.Key = name(g.Key)
+		renamed[i] = g
+	}
+	return renamed
 }
 
 func truncateString(s string, maxLen int) string {
@@ -139,18 +221,18 @@ func ratingIcon(rating string) string {
 	}
 }
 
-func severityIcon(severity string) string {
+func severityIcon(loc *Locale, severity string) string {
 	switch severity {
 	case "BLOCKER":
-		return icon("circle", "#ef4444") + " BLOCKER"
+		return icon("circle", "#ef4444") + " " + loc.Severity(severity)
 	case "CRITICAL":
-		return icon("circle", "#f97316") + " CRITICAL"
+		return icon("circle", "#f97316") + " " + loc.Severity(severity)
 	case "MAJOR":
-		return icon("circle", "#f59e0b") + " MAJOR"
+		return icon("circle", "#f59e0b") + " " + loc.Severity(severity)
 	case "MINOR":
-		return icon("circle", "#3b82f6") + " MINOR"
+		return icon("circle", "#3b82f6") + " " + loc.Severity(severity)
 	case "INFO":
-		return icon("circle", "#94a3b8") + " INFO"
+		return icon("circle", "#94a3b8") + " " + loc.Severity(severity)
 	default:
 		return severity
 	}
@@ -180,125 +262,277 @@ func qualityGateIcon(status string) string {
 	}
 }
 
-const markdownTemplate = `# <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M18 20V10"/><path d="M12 20V4"/><path d="M6 20v-6"/></svg> SonarQube Analysis Report
+const markdownTemplate = `# <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M18 20V10"/><path d="M12 20V4"/><path d="M6 20v-6"/></svg> {{ t "report.title" }}
 
-### Code Quality Analysis Summary
+### {{ t "report.subtitle" }}
+{{- if .Historical }}
+
+> **{{ t "report.historicalBanner" (formatTime .Historical.AsOf) }}** {{ t "report.historicalNote" }}
+{{- end }}
 
 ---
 
-## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><circle cx="12" cy="12" r="10"/><path d="M12 16v-4"/><path d="M12 8h.01"/></svg> Project Information
+## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><circle cx="12" cy="12" r="10"/><path d="M12 16v-4"/><path d="M12 8h.01"/></svg> {{ t "info.title" }}
 
 | | |
 |---|---|
-| **Project Name** | {{ .ProjectName }} |
-| **Project Key** | ` + "`{{ .ProjectKey }}`" + ` |
-| **Branch** | ` + "`{{ .Branch }}`" + ` |
-| **Report Generated** | {{ formatTime .GeneratedAt }} |
-{{- if .AnalysisDate }}
-| **Last Analysis** | {{ .AnalysisDate }} |
+| **{{ t "info.projectName" }}** | {{ .ProjectName }} |
+| **{{ t "info.projectKey" }}** | ` + "`{{ .ProjectKey }}`" + ` |
+| **{{ t "info.branch" }}** | ` + "`{{ .Branch }}`" + ` |
+{{- if .Team }}
+| **{{ t "info.team" }}** | {{ t "info.teamValue" .Team }} |
+{{- end }}
+{{- if .Profile }}
+| **{{ t "info.profile" }}** | {{ .Profile }} |
+{{- end }}
+{{- if or .Severities .Types }}
+| **{{ t "info.issueFilter" }}** | {{ issueFilter . }} |
+{{- end }}
+| **{{ t "info.generated" }}** | {{ formatTime .GeneratedAt }} |
+{{- with dateTime .AnalysisDate }}
+| **{{ t "info.lastAnalysis" }}** | {{ . }} |
 {{- end }}
+{{- if .Historical }}
+| **{{ t "info.asOf" }}** | {{ formatTime .Historical.AsOf }} (` + "`{{ .Historical.Requested }}`" + `) |
+{{- end }}
+{{- if .Redactions }}
+| **{{ t "info.redacted" }}** | {{ t "info.redactedValue" (int .Redactions.Total) }} |
+{{- end }}
+{{- if .Shows "qualityGate" }}
 
 ---
 
-## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M22 12h-4l-3 9L9 3l-3 9H2"/></svg> Quality Gate
+## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M22 12h-4l-3 9L9 3l-3 9H2"/></svg> {{ t "qualityGate.title" }}
 
 {{- if eq .QualityGateStatus "OK" }}
 
-### <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#22c55e" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M22 11.08V12a10 10 0 1 1-5.93-9.14"/><path d="M22 4L12 14.01l-3-3"/></svg> PASSED
+### <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#22c55e" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M22 11.08V12a10 10 0 1 1-5.93-9.14"/><path d="M22 4L12 14.01l-3-3"/></svg> {{ qualityGateText "OK" }}
 
-> **Congratulations!** Your code meets all quality standards.
+> **{{ t "qualityGate.passedTitle" }}** {{ t "qualityGate.passedText" }}
 
 {{- else if eq .QualityGateStatus "WARN" }}
 
-### <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m21.73 18-8-14a2 2 0 0 0-3.48 0l-8 14A2 2 0 0 0 4 21h16a2 2 0 0 0 1.73-3Z"/><path d="M12 9v4"/><path d="M12 17h.01"/></svg> WARNING
+### <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m21.73 18-8-14a2 2 0 0 0-3.48 0l-8 14A2 2 0 0 0 4 21h16a2 2 0 0 0 1.73-3Z"/><path d="M12 9v4"/><path d="M12 17h.01"/></svg> {{ qualityGateText "WARN" }}
 
-> **Attention needed.** Some quality thresholds are close to failing.
+> **{{ t "qualityGate.warnTitle" }}** {{ t "qualityGate.warnText" }}
 
 {{- else }}
 
-### <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#ef4444" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><circle cx="12" cy="12" r="10"/><path d="m15 9-6 6"/><path d="m9 9 6 6"/></svg> FAILED
+### <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#ef4444" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><circle cx="12" cy="12" r="10"/><path d="m15 9-6 6"/><path d="m9 9 6 6"/></svg> {{ qualityGateText "ERROR" }}
 
-> **Action required!** Your code does not meet quality standards.
+> **{{ t "qualityGate.failedTitle" }}** {{ t "qualityGate.failedText" }}
 
 {{- end }}
 
+{{- if .Historical.IsReconstructed "qualityGate" }}
+
+> *{{ t "qualityGate.reconstructed" }}*
+{{- end }}
+
 {{- if .QualityGateConditions }}
 
-### Quality Gate Conditions
+### {{ t "qualityGate.conditions" }}
 
-| Metric | Status | Actual Value | Threshold |
+| {{ t "col.metric" }} | {{ t "col.status" }} | {{ t "col.actualValue" }} | {{ t "col.threshold" }} |
 |:-------|:------:|:------------:|:----------|
 {{- range .QualityGateConditions }}
-| {{ .Metric }} | {{ if eq .Status "OK" }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#22c55e" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M22 11.08V12a10 10 0 1 1-5.93-9.14"/><path d="M22 4L12 14.01l-3-3"/></svg>{{ else if eq .Status "WARN" }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m21.73 18-8-14a2 2 0 0 0-3.48 0l-8 14A2 2 0 0 0 4 21h16a2 2 0 0 0 1.73-3Z"/><path d="M12 9v4"/><path d="M12 17h.01"/></svg>{{ else }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#ef4444" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><circle cx="12" cy="12" r="10"/><path d="m15 9-6 6"/><path d="m9 9 6 6"/></svg>{{ end }} | **{{ .ActualValue }}** | {{ .Comparator }} {{ .ErrorThreshold }} |
+| {{ .Metric }} | {{ if eq .Status "OK" }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#22c55e" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M22 11.08V12a10 10 0 1 1-5.93-9.14"/><path d="M22 4L12 14.01l-3-3"/></svg>{{ else if eq .Status "WARN" }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m21.73 18-8-14a2 2 0 0 0-3.48 0l-8 14A2 2 0 0 0 4 21h16a2 2 0 0 0 1.73-3Z"/><path d="M12 9v4"/><path d="M12 17h.01"/></svg>{{ else }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#ef4444" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><circle cx="12" cy="12" r="10"/><path d="m15 9-6 6"/><path d="m9 9 6 6"/></svg>{{ end }} | **{{ num .ActualValue }}** | {{ .Comparator }} {{ num .ErrorThreshold }} |
+{{- end }}
+{{- end }}
+{{- end }}
+
+{{- if and .Policy (.Shows "policy") }}
+
+---
+
+## {{ icon "shield" "info" }} {{ t "policy.title" }}
+
+{{ if .Policy.Passed }}{{ icon "check-circle" "success" }} **{{ t "policy.compliant" .Policy.Policy }}**{{ else }}{{ icon "circle-x" "danger" }} **{{ t "policy.notCompliant" .Policy.Policy (int .Policy.Failed) }}**{{ end }}
+{{- if .Policy.Description }}
+
+> {{ .Policy.Description }}
+{{- end }}
+
+| {{ t "col.rule" }} | {{ t "col.result" }} | {{ t "col.explanation" }} |
+|:-----|:------:|:------------|
+{{- range .Policy.Rules }}
+| {{ .Name }} | {{ if eq .Status "passed" }}{{ icon "check-circle" "success" }}{{ else if eq .Status "failed" }}{{ icon "circle-x" "danger" }}{{ else }}{{ icon "info-circle" "info" }}{{ end }} {{ policyStatus .Status }} | {{ policyExplanation . }}{{ if .Issues }} ({{ truncate (joinKeys .Issues 5) 120 }}){{ end }} |
+{{- end }}
+{{- end }}
+
+{{- if and .TopIssues (.Shows "topIssues") }}
+
+---
+
+## {{ icon "alert-triangle" "warning" }} {{ t "topIssues.title" (len .TopIssues) }}
+
+| # | {{ t "col.score" }} | {{ t "col.severity" }} | {{ t "col.type" }} | {{ t "col.file" }} | {{ t "col.line" }} | {{ t "col.effort" }} | {{ t "col.message" }} |
+|:-:|:-----:|:---------|:-----|:-----|:----:|:------:|:--------|
+{{- range $idx, $issue := .TopIssues }}
+| {{ add $idx 1 }} | **{{ float1 .Score }}** | {{ severityIcon .Severity }} | {{ issueType .Type }} | ` + "`{{ .Component }}`" + ` | {{ .Line }} | {{ orDash (effort .Effort) }} | {{ truncate .Message 60 }} |
 {{- end }}
+
+> *{{ t "topIssues.ranking" (scoreWeights .Scoring.Weights) }}{{ if and .Scoring.Weights.LineCoverage (not .Scoring.CoverageKnown) }} {{ t "topIssues.noCoverage" }}{{ end }}*
 {{- end }}
 
+{{- if and .Baseline (.Shows "baseline") }}
+
 ---
 
-## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><polyline points="23 6 13.5 15.5 8.5 10.5 1 18"/><polyline points="17 6 23 6 23 12"/></svg> Metrics Overview
+## {{ icon "shield" "info" }} {{ t "baseline.title" }}
+
+{{ t "baseline.summary" (bold (int .Baseline.NewIssues)) (bold (int .Baseline.BaselineIssues)) }}
 
-### Code Health Dashboard
+> *{{ t "baseline.frozen" (formatTime .Baseline.CreatedAt) }}{{ if ne .Baseline.RefreshedAt .Baseline.CreatedAt }}{{ t "baseline.refreshed" (formatTime .Baseline.RefreshedAt) }}{{ end }}.{{ if .Baseline.Truncated }} {{ t "baseline.truncated" }}{{ end }}*
 
-| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#ef4444" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><rect width="8" height="14" x="8" y="6" rx="4"/><path d="m19 7-3 2"/><path d="m5 7 3 2"/><path d="m19 19-3-2"/><path d="m5 19 3-2"/><path d="M20 13h-4"/><path d="M4 13h4"/><path d="m10 4 1 2"/></svg> Bugs | <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M12 22s8-4 8-10V5l-8-3-8 3v7c0 6 8 10 8 10z"/></svg> Vulnerabilities | <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m16 5-6.4 11.2a2.1 2.1 0 0 1-1.2 1.3 2.1 2.1 0 0 1-1.2.3L4.6 16"/><path d="m10 5 1.7 3.4a2.1 2.1 0 0 1 .4 1.5L9 16"/><path d="m14 5 6.4 11.2a2.1 2.1 0 0 1 1.2 1.3 2.1 2.1 0 0 1-1.2.3l-2.4-2"/></svg> Code Smells |
+| {{ t "col.severity" }} | {{ t "baseline.new" }} | {{ t "baseline.inBaseline" }} |
+|:---------|:---:|:-----------:|
+{{- range $sev := .Baseline.Severities }}
+| {{ severityIcon $sev }} | **{{ int (index $.Baseline.NewBySeverity $sev) }}** | {{ int (index $.Baseline.BaselineBySeverity $sev) }} |
+{{- end }}
+
+{{- $new := .NewIssues }}
+{{- if $new }}
+
+| # | {{ t "col.severity" }} | {{ t "col.type" }} | {{ t "col.file" }} | {{ t "col.line" }} | {{ t "col.effort" }} | {{ t "col.message" }} |
+|:-:|:---------|:-----|:-----|:----:|:------:|:--------|
+{{- range $idx, $issue := $new }}
+{{- if lt $idx 50 }}
+| {{ add $idx 1 }} | {{ severityIcon .Severity }} | {{ issueType .Type }} | ` + "`{{ .Component }}`" + ` | {{ .Line }} | {{ orDash (effort .Effort) }} | {{ truncate .Message 60 }} |
+{{- end }}
+{{- end }}
+{{- if gt (len $new) 50 }}
+
+> {{ t "baseline.showing" (int (len $new)) }}
+{{- end }}
+{{- else }}
+
+{{ icon "check-circle" "success" }} **{{ t "baseline.noNewIssues" }}**
+{{- end }}
+{{- end }}
+{{- if or (.Shows "metrics") (.Shows "languages") }}
+
+---
+
+## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><polyline points="23 6 13.5 15.5 8.5 10.5 1 18"/><polyline points="17 6 23 6 23 12"/></svg> {{ t "metrics.title" }}
+
+{{- if .Historical.IsReconstructed "metrics" }}
+
+> *{{ t "metrics.reconstructed" }}*
+{{- end }}
+{{- if .Shows "metrics" }}
+
+### {{ t "metrics.dashboard" }}
+
+| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#ef4444" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><rect width="8" height="14" x="8" y="6" rx="4"/><path d="m19 7-3 2"/><path d="m5 7 3 2"/><path d="m19 19-3-2"/><path d="m5 19 3-2"/><path d="M20 13h-4"/><path d="M4 13h4"/><path d="m10 4 1 2"/></svg> {{ t "metrics.bugs" }} | <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M12 22s8-4 8-10V5l-8-3-8 3v7c0 6 8 10 8 10z"/></svg> {{ t "metrics.vulnerabilities" }} | <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m16 5-6.4 11.2a2.1 2.1 0 0 1-1.2 1.3 2.1 2.1 0 0 1-1.2.3L4.6 16"/><path d="m10 5 1.7 3.4a2.1 2.1 0 0 1 .4 1.5L9 16"/><path d="m14 5 6.4 11.2a2.1 2.1 0 0 1 1.2 1.3 2.1 2.1 0 0 1-1.2.3l-2.4-2"/></svg> {{ t "metrics.codeSmells" }} |
 |:---|:---|:---|
-| **{{ .Metrics.Bugs }}** | **{{ .Metrics.Vulnerabilities }}** | **{{ .Metrics.CodeSmells }}** |
-| Rating: {{ .Metrics.ReliabilityRating }} | Rating: {{ .Metrics.SecurityRating }} | Rating: {{ .Metrics.MaintainabilityRating }} |
+| **{{ num .Metrics.Bugs }}** | **{{ num .Metrics.Vulnerabilities }}** | **{{ num .Metrics.CodeSmells }}** |
+| {{ t "metrics.rating" .Metrics.ReliabilityRating }} | {{ t "metrics.rating" .Metrics.SecurityRating }} | {{ t "metrics.rating" .Metrics.MaintainabilityRating }} |
 
-### Additional Metrics
+### {{ t "metrics.additional" }}
 
-| Metric | Value | Description |
+| {{ t "col.metric" }} | {{ t "col.value" }} | {{ t "col.description" }} |
 |:-------|:-----:|:------------|
-| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M21.21 15.89A10 10 0 1 1 8 2.83"/><path d="M22 12A10 10 0 0 0 12 2v10z"/></svg> **Lines of Code** | {{ .Metrics.LinesOfCode }} | Total lines analyzed |
-| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M18 20V10"/><path d="M12 20V4"/><path d="M6 20v-6"/></svg> **Coverage** | {{ .Metrics.Coverage }} | Test coverage percentage |
-| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><rect width="14" height="14" x="8" y="8" rx="2" ry="2"/><path d="M4 16c-1.1 0-2-.9-2-2V4c0-1.1.9-2 2-2h10c1.1 0 2 .9 2 2"/></svg> **Duplications** | {{ .Metrics.DuplicatedLinesDensity }} | Duplicated code percentage |
-| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><circle cx="12" cy="12" r="10"/><polyline points="12 6 12 12 16 14"/></svg> **Technical Debt** | {{ .Metrics.TechnicalDebt }} | Estimated time to fix all issues |
+| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M21.21 15.89A10 10 0 1 1 8 2.83"/><path d="M22 12A10 10 0 0 0 12 2v10z"/></svg> **{{ t "metrics.linesOfCode" }}** | {{ num .Metrics.LinesOfCode }} | {{ t "metrics.linesOfCodeDesc" }} |
+| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M18 20V10"/><path d="M12 20V4"/><path d="M6 20v-6"/></svg> **{{ t "metrics.coverage" }}** | {{ num .Metrics.Coverage }} | {{ t "metrics.coverageDesc" }} |
+| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><rect width="14" height="14" x="8" y="8" rx="2" ry="2"/><path d="M4 16c-1.1 0-2-.9-2-2V4c0-1.1.9-2 2-2h10c1.1 0 2 .9 2 2"/></svg> **{{ t "metrics.duplications" }}** | {{ num .Metrics.DuplicatedLinesDensity }} | {{ t "metrics.duplicationsDesc" }} |
+| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><circle cx="12" cy="12" r="10"/><polyline points="12 6 12 12 16 14"/></svg> **{{ t "metrics.technicalDebt" }}** | {{ effort .Metrics.TechnicalDebt }} | {{ t "metrics.technicalDebtDesc" }} |
 
-{{- if or .Metrics.NewBugs .Metrics.NewVulnerabilities .Metrics.NewCodeSmells }}
+{{- if .SelectedMetrics }}
 
-### <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m12 3-1.912 5.813a2 2 0 0 1-1.275 1.275L3 12l5.813 1.912a2 2 0 0 1 1.275 1.275L12 21l1.912-5.813a2 2 0 0 1 1.275-1.275L21 12l-5.813-1.912a2 2 0 0 1-1.275-1.275L12 3Z"/><path d="M5 3v4"/><path d="M9 3v4"/><path d="M1 7h4"/><path d="M3 5h4"/><path d="M3 7h4"/><path d="M1 11h4"/></svg> New Code Analysis
+### {{ t "metrics.selected" }}
 
-| Metric | Value |
+| {{ t "col.metric" }} | {{ t "col.domain" }} | {{ t "col.value" }} |
+|:-------|:-------|:-----:|
+{{- range .SelectedMetrics }}
+| **{{ .Name }}** (` + "`{{ .Key }}`" + `) | {{ orDash .Domain }} | {{ metricValue . }} |
+{{- end }}
+{{- end }}
+
+{{- end }}
+{{- if and .Languages (.Shows "languages") }}
+
+### {{ t "languages.title" }}
+
+| {{ t "col.language" }} | {{ t "col.linesOfCode" }} | {{ t "col.share" }} | {{ t "col.issues" }} | {{ t "col.issueDensity" }} |
+|:---------|:-------------:|:-----:|:------:|:-----------------:|
+{{- range .Languages }}
+| {{ languageName . }} | {{ int .Lines }} | {{ pct .Share }} | **{{ int .Issues }}** | {{ if .Lines }}{{ float1 .IssuesPerKLoc }}{{ else }}-{{ end }} |
+{{- end }}
+{{- end }}
+
+{{- if and (.Shows "metrics") (or .Metrics.NewBugs .Metrics.NewVulnerabilities .Metrics.NewCodeSmells) }}
+
+### <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m12 3-1.912 5.813a2 2 0 0 1-1.275 1.275L3 12l5.813 1.912a2 2 0 0 1 1.275 1.275L12 21l1.912-5.813a2 2 0 0 1 1.275-1.275L21 12l-5.813-1.912a2 2 0 0 1-1.275-1.275L12 3Z"/><path d="M5 3v4"/><path d="M9 3v4"/><path d="M1 7h4"/><path d="M3 5h4"/><path d="M3 7h4"/><path d="M1 11h4"/></svg> {{ t "metrics.newCode" }}
+
+| {{ t "col.metric" }} | {{ t "col.value" }} |
 |:-------|:-----:|
 {{- if .Metrics.NewBugs }}
-| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#ef4444" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><rect width="8" height="14" x="8" y="6" rx="4"/><path d="m19 7-3 2"/><path d="m5 7 3 2"/><path d="m19 19-3-2"/><path d="m5 19 3-2"/><path d="M20 13h-4"/><path d="M4 13h4"/><path d="m10 4 1 2"/></svg> New Bugs | **{{ .Metrics.NewBugs }}** |
+| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#ef4444" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><rect width="8" height="14" x="8" y="6" rx="4"/><path d="m19 7-3 2"/><path d="m5 7 3 2"/><path d="m19 19-3-2"/><path d="m5 19 3-2"/><path d="M20 13h-4"/><path d="M4 13h4"/><path d="m10 4 1 2"/></svg> {{ t "metrics.newBugs" }} | **{{ num .Metrics.NewBugs }}** |
 {{- end }}
 {{- if .Metrics.NewVulnerabilities }}
-| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M12 22s8-4 8-10V5l-8-3-8 3v7c0 6 8 10 8 10z"/></svg> New Vulnerabilities | **{{ .Metrics.NewVulnerabilities }}** |
+| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M12 22s8-4 8-10V5l-8-3-8 3v7c0 6 8 10 8 10z"/></svg> {{ t "metrics.newVulnerabilities" }} | **{{ num .Metrics.NewVulnerabilities }}** |
 {{- end }}
 {{- if .Metrics.NewCodeSmells }}
-| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m16 5-6.4 11.2a2.1 2.1 0 0 1-1.2 1.3 2.1 2.1 0 0 1-1.2.3L4.6 16"/><path d="m10 5 1.7 3.4a2.1 2.1 0 0 1 .4 1.5L9 16"/><path d="m14 5 6.4 11.2a2.1 2.1 0 0 1 1.2 1.3 2.1 2.1 0 0 1-1.2.3l-2.4-2"/></svg> New Code Smells | **{{ .Metrics.NewCodeSmells }}** |
+| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m16 5-6.4 11.2a2.1 2.1 0 0 1-1.2 1.3 2.1 2.1 0 0 1-1.2.3L4.6 16"/><path d="m10 5 1.7 3.4a2.1 2.1 0 0 1 .4 1.5L9 16"/><path d="m14 5 6.4 11.2a2.1 2.1 0 0 1 1.2 1.3 2.1 2.1 0 0 1-1.2.3l-2.4-2"/></svg> {{ t "metrics.newCodeSmells" }} | **{{ num .Metrics.NewCodeSmells }}** |
 {{- end }}
 {{- if .Metrics.NewCoverage }}
-| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M18 20V10"/><path d="M12 20V4"/><path d="M6 20v-6"/></svg> New Coverage | **{{ .Metrics.NewCoverage }}** |
+| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M18 20V10"/><path d="M12 20V4"/><path d="M6 20v-6"/></svg> {{ t "metrics.newCoverage" }} | **{{ num .Metrics.NewCoverage }}** |
 {{- end }}
 {{- if .Metrics.NewDuplicatedLines }}
-| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><rect width="14" height="14" x="8" y="8" rx="2" ry="2"/><path d="M4 16c-1.1 0-2-.9-2-2V4c0-1.1.9-2 2-2h10c1.1 0 2 .9 2 2"/></svg> New Duplications | **{{ .Metrics.NewDuplicatedLines }}** |
+| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><rect width="14" height="14" x="8" y="8" rx="2" ry="2"/><path d="M4 16c-1.1 0-2-.9-2-2V4c0-1.1.9-2 2-2h10c1.1 0 2 .9 2 2"/></svg> {{ t "metrics.newDuplications" }} | **{{ num .Metrics.NewDuplicatedLines }}** |
 {{- end }}
 {{- end }}
+{{- end }}
+{{- if .Shows "issues" }}
 
 ---
 
-## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><circle cx="10" cy="10" r="7"/><path d="m21 21-4.3-4.3"/></svg> Issues Analysis
+## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><circle cx="10" cy="10" r="7"/><path d="m21 21-4.3-4.3"/></svg> {{ t "issues.title" }}
+
+{{- if .Historical.IsReconstructed "issues" }}
 
-### Total Issues: **{{ .TotalIssues }}**
+> *{{ t "issues.reconstructed" }}*
+{{- end }}
 
-### Issues by Type
+### {{ t "issues.total" }}: **{{ int .TotalIssues }}**
+{{- if and .Suppressed .Suppressed.Issues }}
+
+> *{{ t "issues.suppressedNote" (int (len .Suppressed.Issues)) }}*
+{{- end }}
 
-| Type | Count | Percentage |
+### {{ t "issues.byType" }}
+
+| {{ t "col.type" }} | {{ t "col.count" }} | {{ t "col.percentage" }} |
 |:-----|:-----:|:----------:|
 {{- range $type, $count := .IssuesByType }}
-| {{ if eq $type "BUG" }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#ef4444" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><rect width="8" height="14" x="8" y="6" rx="4"/><path d="m19 7-3 2"/><path d="m5 7 3 2"/><path d="m19 19-3-2"/><path d="m5 19 3-2"/><path d="M20 13h-4"/><path d="M4 13h4"/><path d="m10 4 1 2"/></svg>{{ else if eq $type "VULNERABILITY" }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M12 22s8-4 8-10V5l-8-3-8 3v7c0 6 8 10 8 10z"/></svg>{{ else }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m16 5-6.4 11.2a2.1 2.1 0 0 1-1.2 1.3 2.1 2.1 0 0 1-1.2.3L4.6 16"/><path d="m10 5 1.7 3.4a2.1 2.1 0 0 1 .4 1.5L9 16"/><path d="m14 5 6.4 11.2a2.1 2.1 0 0 1 1.2 1.3 2.1 2.1 0 0 1-1.2.3l-2.4-2"/></svg>{{ end }} {{ $type }} | **{{ $count }}** | {{ if $.TotalIssues }}{{ printf "%.1f%%" (mul (div (float64 $count) (float64 $.TotalIssues)) 100) }}{{ else }}0%{{ end }} |
+| {{ if eq $type "BUG" }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#ef4444" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><rect width="8" height="14" x="8" y="6" rx="4"/><path d="m19 7-3 2"/><path d="m5 7 3 2"/><path d="m19 19-3-2"/><path d="m5 19 3-2"/><path d="M20 13h-4"/><path d="M4 13h4"/><path d="m10 4 1 2"/></svg>{{ else if eq $type "VULNERABILITY" }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M12 22s8-4 8-10V5l-8-3-8 3v7c0 6 8 10 8 10z"/></svg>{{ else }}<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m16 5-6.4 11.2a2.1 2.1 0 0 1-1.2 1.3 2.1 2.1 0 0 1-1.2.3L4.6 16"/><path d="m10 5 1.7 3.4a2.1 2.1 0 0 1 .4 1.5L9 16"/><path d="m14 5 6.4 11.2a2.1 2.1 0 0 1 1.2 1.3 2.1 2.1 0 0 1-1.2.3l-2.4-2"/></svg>{{ end }} {{ issueType $type }} | **{{ int $count }}** | {{ if $.TotalIssues }}{{ pct (mul (div (float64 $count) (float64 $.TotalIssues)) 100) }}{{ else }}0%{{ end }} |
 {{- end }}
 
-### Issues by Severity
+### {{ t "issues.bySeverity" }}
 
-| Severity | Count |
+| {{ t "col.severity" }} | {{ t "col.count" }} |
 |:---------|:-----:|
 {{- $severities := getSortedSeverities .IssuesBySeverity }}
 {{- range $sev := $severities }}
-| {{ severityIcon $sev }} | **{{ issueCount $.IssuesBySeverity $sev }}** |
+| {{ severityIcon $sev }} | **{{ int (issueCount $.IssuesBySeverity $sev) }}** |
+{{- end }}
+
 {{- end }}
+{{- if and .Owners (.Shows "owners") }}
+
+### {{ t "owners.title" }}
 
+| {{ t "col.owner" }} | {{ t "col.issues" }} | {{ severityTitle "BLOCKER" }} | {{ severityTitle "CRITICAL" }} | {{ severityTitle "MAJOR" }} | {{ severityTitle "MINOR" }} | {{ severityTitle "INFO" }} | {{ t "col.effort" }} |
+|:------|:------:|:-------:|:--------:|:-----:|:-----:|:----:|:------:|
+{{- range .Owners }}
+| {{ .Owner }} | **{{ int .Issues }}** | {{ int (index .BySeverity "BLOCKER") }} | {{ int (index .BySeverity "CRITICAL") }} | {{ int (index .BySeverity "MAJOR") }} | {{ int (index .BySeverity "MINOR") }} | {{ int (index .BySeverity "INFO") }} | {{ formatEffort .EffortMinutes }} |
+{{- end }}
+
+> *{{ t "owners.note" }}*
+{{- end }}
+
+{{- if .Shows "issueDetails" }}
 {{- $severities := getSortedSeverities .IssuesBySeverity }}
 {{- range $sev := $severities }}
 {{- $issues := index $.IssuesBySeverity $sev }}
@@ -306,13 +540,13 @@ const markdownTemplate = `# <svg xmlns="http://www.w3.org/2000/svg" width="24" h
 
 ---
 
-### {{ severityIcon $sev }} Issues ({{ len $issues }})
+### {{ t "details.title" (severityIcon $sev) (int (len $issues)) }}
 
 {{- if gt (len $issues) 10 }}
 
-**{{ severityIcon $sev }} Issues List:**
+**{{ t "details.list" (severityIcon $sev) }}**
 
-| # | File | Line | Message |
+| # | {{ t "col.file" }} | {{ t "col.line" }} | {{ t "col.message" }} |
 |:-:|:-----|:----:|:--------|
 {{- range $idx, $issue := $issues }}
 {{- if and (ge $idx 10) (lt $idx 25) }}
@@ -321,32 +555,35 @@ const markdownTemplate = `# <svg xmlns="http://www.w3.org/2000/svg" width="24" h
 {{- end }}
 {{- if gt (len $issues) 25 }}
 
-> Showing 25 of {{ len $issues }} {{ $sev }} issues. See SonarQube for full list.
+> {{ t "details.showingList" (int (len $issues)) (severity $sev) }}
 {{- end }}
 
 {{- end }}
 
 <details>
-<summary>Click to expand {{ $sev }} issues with details and code</summary>
+<summary>{{ t "details.expand" (severity $sev) }}</summary>
 
 {{- range $idx, $issue := $issues }}
 {{- if lt $idx 10 }}
 
 #### {{ add $idx 1 }}. {{ .Message }}
 
-| Property | Value |
+| {{ t "col.property" }} | {{ t "col.value" }} |
 |:---------|:------|
-| **File** | ` + "`{{ .Component }}`" + ` |
-| **Line** | {{ .Line }}{{ if and .EndLine (ne .EndLine .Line) }} - {{ .EndLine }}{{ end }} |
-| **Type** | {{ .Type }} |
-| **Rule** | ` + "`{{ .Rule }}`" + ` |
+| **{{ t "col.file" }}** | ` + "`{{ .Component }}`" + ` |
+| **{{ t "col.line" }}** | {{ .Line }}{{ if and .EndLine (ne .EndLine .Line) }} - {{ .EndLine }}{{ end }} |
+| **{{ t "col.type" }}** | {{ issueType .Type }} |
+| **{{ t "col.rule" }}** | ` + "`{{ .Rule }}`" + ` |
 {{- if .Effort }}
-| **Effort** | {{ .Effort }} |
+| **{{ t "col.effort" }}** | {{ effort .Effort }} |
+{{- end }}
+{{- if .Owners }}
+| **{{ t "col.owners" }}** | {{ join .Owners ", " }} |
 {{- end }}
 
 {{- if hasCodeSnippet .CodeSnippet }}
 
-**<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><polyline points="16 18 22 12 16 6"/><polyline points="8 6 2 12 8 18"/></svg> Problematic Code:**
+**<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><polyline points="16 18 22 12 16 6"/><polyline points="8 6 2 12 8 18"/></svg> {{ t "details.problematic" }}**
 
 ` + "```{{ .Language }}" + `
 {{ .CodeSnippet }}
@@ -356,7 +593,7 @@ const markdownTemplate = `# <svg xmlns="http://www.w3.org/2000/svg" width="24" h
 
 {{- if hasCodeSnippet .HowToFix }}
 
-**<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M9 18h6"/><path d="M10 22h4"/><path d="M15.09 14c.18-.98.65-1.74 1.41-2.5A4.65 4.65 0 0 0 18 8 6 6 0 0 0 6 8c0 1 .23 2.23 1.5 3.5A4.61 4.61 0 0 1 8.91 14"/></svg> How to Fix:**
+**<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M9 18h6"/><path d="M10 22h4"/><path d="M15.09 14c.18-.98.65-1.74 1.41-2.5A4.65 4.65 0 0 0 18 8 6 6 0 0 0 6 8c0 1 .23 2.23 1.5 3.5A4.61 4.61 0 0 1 8.91 14"/></svg> {{ t "details.howToFix" }}**
 
 > {{ truncate .HowToFix 500 }}
 
@@ -369,48 +606,105 @@ const markdownTemplate = `# <svg xmlns="http://www.w3.org/2000/svg" width="24" h
 
 {{- if gt (len $issues) 10 }}
 
-> **Note:** Showing detailed view for first 10 of {{ len $issues }} {{ $sev }} issues.
+> **{{ t "details.note" }}** {{ t "details.showingDetails" (int (len $issues)) (severity $sev) }}
 
 {{- end }}
 
 </details>
 
+{{- end }}
+{{- end }}
+{{- end }}
+
+{{- define "effortGroups" }}
+| {{ .Title }} | {{ t "col.issues" }} | {{ t "col.effort" }} | {{ t "col.personDays" }} |
+|:-----|:------:|:------:|:-----------:|
+{{- range .Groups }}
+| {{ .Key }} | {{ int .Issues }} | {{ formatEffort .Minutes }} | {{ personDays .Minutes }} |
 {{- end }}
 {{- end }}
 
+{{- if and .Effort .Effort.TotalMinutes (.Shows "remediation") }}
+
 ---
 
-## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M12 22s8-4 8-10V5l-8-3-8 3v7c0 6 8 10 8 10z"/></svg> Security Hotspots
+## {{ icon "clock" "info" }} {{ t "remediation.title" }}
+
+{{ t "remediation.intro" (int .Effort.Issues) (bold (formatEffort .Effort.TotalMinutes)) (bold (t "remediation.personDays" (personDays .Effort.TotalMinutes))) .WorkingDayHours }}
+{{- if .Effort.Truncated }}
+
+> *{{ t "remediation.truncated" (int .Effort.Issues) }}*
+{{- end }}
+
+{{- if .Effort.BySeverity }}
+
+### {{ t "remediation.bySeverity" }}
+{{ template "effortGroups" (effortTable (t "col.severity") (severityGroups .Effort.BySeverity)) }}
+{{- end }}
 
-{{- if gt .TotalHotspots 0 }}
+{{- if .Effort.ByType }}
 
-### Total Hotspots: **{{ .TotalHotspots }}**
+### {{ t "remediation.byType" }}
+{{ template "effortGroups" (effortTable (t "col.type") (typeGroups .Effort.ByType)) }}
+{{- end }}
 
-### Hotspots by Priority
+{{- if .Effort.ByRule }}
 
-| Priority | Count |
+### {{ t "remediation.byRule" }}
+{{ template "effortGroups" (effortTable (t "col.rule") .Effort.ByRule) }}
+{{- end }}
+
+{{- if .Effort.ByFile }}
+
+### {{ t "remediation.byFile" }}
+{{ template "effortGroups" (effortTable (t "col.file") .Effort.ByFile) }}
+{{- end }}
+
+{{- if .Effort.ByAuthor }}
+
+### {{ t "remediation.byAuthor" }}
+{{ template "effortGroups" (effortTable (t "col.author") .Effort.ByAuthor) }}
+{{- end }}
+{{- end }}
+{{- if .Shows "hotspots" }}
+
+---
+
+## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M12 22s8-4 8-10V5l-8-3-8 3v7c0 6 8 10 8 10z"/></svg> {{ t "hotspots.title" }}
+
+{{- if .Historical.IsUnavailable "hotspots" }}
+
+> *{{ t "hotspots.unavailable" }}*
+
+{{- else if gt .TotalHotspots 0 }}
+
+### {{ t "hotspots.total" }}: **{{ int .TotalHotspots }}**
+
+### {{ t "hotspots.byPriority" }}
+
+| {{ t "col.priority" }} | {{ t "col.count" }} |
 |:---------|:-----:|
 {{- range $priority, $count := .HotspotsByPriority }}
-| {{ priorityIcon $priority }} {{ $priority }} | **{{ $count }}** |
+| {{ priorityIcon $priority }} {{ priority $priority }} | **{{ int $count }}** |
 {{- end }}
 
 {{- if .Hotspots }}
 
-### Hotspot Details
+### {{ t "hotspots.details" }}
 
 <details>
-<summary>Click to expand security hotspots</summary>
+<summary>{{ t "hotspots.expand" }}</summary>
 
-| # | Priority | Category | Location | Status |
+| # | {{ t "col.priority" }} | {{ t "col.category" }} | {{ t "col.location" }} | {{ t "col.status" }} |
 |:-:|:--------:|:---------|:---------|:------:|
 {{- range $idx, $hotspot := .Hotspots }}
 {{- if lt $idx 20 }}
-| {{ add $idx 1 }} | {{ priorityIcon .VulnerabilityProbability }} {{ .VulnerabilityProbability }} | {{ .SecurityCategory }} | ` + "`{{ .Component }}:{{ .Line }}`" + ` | {{ .Status }} |
+| {{ add $idx 1 }} | {{ priorityIcon .VulnerabilityProbability }} {{ priority .VulnerabilityProbability }} | {{ .SecurityCategory }} | ` + "`{{ .Component }}:{{ .Line }}`" + ` | {{ hotspotStatus .Status }} |
 {{- end }}
 {{- end }}
 {{- if gt (len .Hotspots) 20 }}
 
-> **Note:** Showing first 20 of {{ len .Hotspots }} hotspots.
+> **{{ t "details.note" }}** {{ t "hotspots.showing" (int (len .Hotspots)) }}
 {{- end }}
 
 </details>
@@ -418,36 +712,67 @@ const markdownTemplate = `# <svg xmlns="http://www.w3.org/2000/svg" width="24" h
 
 {{- else }}
 
-### <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#22c55e" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M22 11.08V12a10 10 0 1 1-5.93-9.14"/><path d="M22 4L12 14.01l-3-3"/></svg> No Security Hotspots Found
+### <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#22c55e" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M22 11.08V12a10 10 0 1 1-5.93-9.14"/><path d="M22 4L12 14.01l-3-3"/></svg> {{ t "hotspots.none" }}
 
-> Great job! No security hotspots detected in this analysis.
+> {{ t "hotspots.noneText" }}
 
 {{- end }}
+{{- end }}
+{{- if .Shows "summary" }}
 
 ---
 
-## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><line x1="8" x2="21" y1="6" y2="6"/><line x1="8" x2="21" y1="12" y2="12"/><line x1="8" x2="21" y1="18" y2="18"/><line x1="3" x2="3.01" y1="6" y2="6"/><line x1="3" x2="3.01" y1="12" y2="12"/><line x1="3" x2="3.01" y1="18" y2="18"/></svg> Summary
+## <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#3b82f6" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><line x1="8" x2="21" y1="6" y2="6"/><line x1="8" x2="21" y1="12" y2="12"/><line x1="8" x2="21" y1="18" y2="18"/><line x1="3" x2="3.01" y1="6" y2="6"/><line x1="3" x2="3.01" y1="12" y2="12"/><line x1="3" x2="3.01" y1="18" y2="18"/></svg> {{ t "summary.title" }}
 
 {{- if eq .QualityGateStatus "OK" }}
 
-| Status | Result |
+| {{ t "col.status" }} | {{ t "col.result" }} |
 |:------:|:------:|
-| Quality Gate | <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#22c55e" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M22 11.08V12a10 10 0 1 1-5.93-9.14"/><path d="M22 4L12 14.01l-3-3"/></svg> **PASSED** |
-| Bugs | {{ .Metrics.Bugs }} ({{ .Metrics.ReliabilityRating }}) |
-| Vulnerabilities | {{ .Metrics.Vulnerabilities }} ({{ .Metrics.SecurityRating }}) |
-| Code Smells | {{ .Metrics.CodeSmells }} ({{ .Metrics.MaintainabilityRating }}) |
+| {{ t "qualityGate.title" }} | <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#22c55e" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="M22 11.08V12a10 10 0 1 1-5.93-9.14"/><path d="M22 4L12 14.01l-3-3"/></svg> **{{ qualityGateText "OK" }}** |
+| {{ t "metrics.bugs" }} | {{ num .Metrics.Bugs }} ({{ .Metrics.ReliabilityRating }}) |
+| {{ t "metrics.vulnerabilities" }} | {{ num .Metrics.Vulnerabilities }} ({{ .Metrics.SecurityRating }}) |
+| {{ t "metrics.codeSmells" }} | {{ num .Metrics.CodeSmells }} ({{ .Metrics.MaintainabilityRating }}) |
 
 {{- else }}
 
-| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m21.73 18-8-14a2 2 0 0 0-3.48 0l-8 14A2 2 0 0 0 4 21h16a2 2 0 0 0 1.73-3Z"/><path d="M12 9v4"/><path d="M12 17h.01"/></svg> **Action Required** |
+| <svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="#f59e0b" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="icon"><path d="m21.73 18-8-14a2 2 0 0 0-3.48 0l-8 14A2 2 0 0 0 4 21h16a2 2 0 0 0 1.73-3Z"/><path d="M12 9v4"/><path d="M12 17h.01"/></svg> **{{ t "summary.actionRequired" }}** |
 |:----------------------:|
-| Quality Gate: **{{ qualityGateText .QualityGateStatus }}** |
-| Please review and fix the issues above |
+| {{ t "summary.qualityGate" (bold (qualityGateText .QualityGateStatus)) }} |
+| {{ t "summary.reviewIssues" }} |
+
+{{- end }}
+{{- end }}
+
+{{- if and .Suppressed (.Shows "suppressed") }}
+{{- if or .Suppressed.Issues .Suppressed.Expired }}
+
+---
+
+## {{ icon "info-circle" "gray" }} {{ t "suppressed.title" }}
+
+{{- if .Suppressed.Issues }}
 
+{{ t "suppressed.intro" (int (len .Suppressed.Issues)) }}
+
+| # | {{ t "col.severity" }} | {{ t "col.type" }} | {{ t "col.file" }} | {{ t "col.line" }} | {{ t "col.message" }} | {{ t "col.reason" }} | {{ t "col.expires" }} |
+|:-:|:---------|:-----|:-----|:----:|:--------|:-------|:-------:|
+{{- range $idx, $issue := .Suppressed.Issues }}
+| {{ add $idx 1 }} | {{ severityIcon .Severity }} | {{ issueType .Type }} | ` + "`{{ .Component }}`" + ` | {{ .Line }} | {{ truncate .Message 50 }} | {{ .Reason }} | {{ .Expires }} |
+{{- end }}
+{{- end }}
+
+{{- if .Suppressed.Expired }}
+
+**{{ t "suppressed.expiredTitle" }}** {{ t "suppressed.expiredNote" }}
+{{ range .Suppressed.Expired }}
+- ` + "`{{ suppressionTarget . }}`" + `: {{ .Reason }} {{ t "suppressed.expired" .Expires }}
+{{- end }}
+{{- end }}
+{{- end }}
 {{- end }}
 
 ---
 
-*Report generated by **SonarQube Report Generator***  
+*{{ t "report.generatedBy" "**SonarQube Report Generator**" }}*  
 *{{ formatTime .GeneratedAt }}*
 `
@@ -0,0 +1,128 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// generateActivity generates a markdown activity report
+func (g *MarkdownGenerator) generateActivity(data *ReportData) ([]byte, error) {
+	tmpl, err := template.New("activity").Funcs(localeFuncs(NewLocale(data.Language, data.Timezone), data.WorkingDayHours)).Funcs(template.FuncMap{
+		"truncate": truncateString,
+		"icon":     icon,
+		"orDash":   orDash,
+		"add": func(a, b int) int {
+			return a + b
+		},
+		"neg": func(a int) int {
+			return -a
+		},
+	}).Parse(markdownActivityTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+const markdownActivityTemplate = `{{- define "issues" }}
+| # | {{ t "col.severity" }} | {{ t "col.type" }} | {{ t "col.file" }} | {{ t "col.line" }} | {{ t "col.effort" }} | {{ t "col.message" }} |
+|:-:|:---------|:-----|:-----|:----:|:------:|:--------|
+{{- range $idx, $issue := . }}
+{{- if lt $idx 50 }}
+| {{ add $idx 1 }} | {{ severityIcon .Severity }} | {{ issueType .Type }} | ` + "`{{ .Component }}`" + ` | {{ .Line }} | {{ orDash (effort .Effort) }} | {{ truncate .Message 60 }} |
+{{- end }}
+{{- end }}
+{{- if gt (len .) 50 }}
+
+> {{ t "common.showing" (int (len .)) }}
+{{- end }}
+{{- end -}}
+
+{{- $a := .Activity -}}
+# {{ icon "activity" "info" }} {{ t "activity.title" }}
+
+### {{ t "activity.subtitle" .ProjectName (shortDate $a.From) (shortDate $a.To) }}
+
+---
+
+## {{ icon "info-circle" "info" }} {{ t "info.title" }}
+
+| | |
+|---|---|
+| **{{ t "info.projectName" }}** | {{ .ProjectName }} |
+| **{{ t "info.projectKey" }}** | ` + "`{{ .ProjectKey }}`" + ` |
+| **{{ t "info.branch" }}** | ` + "`{{ .Branch }}`" + ` |
+| **{{ t "info.period" }}** | {{ t "info.periodValue" (formatTime $a.From) (formatTime $a.To) }} |
+| **{{ t "info.generated" }}** | {{ formatTime .GeneratedAt }} |
+{{- with dateTime .AnalysisDate }}
+| **{{ t "info.lastAnalysisInPeriod" }}** | {{ . }} |
+{{- end }}
+{{- if .Redactions }}
+| **{{ t "info.redacted" }}** | {{ t "info.redactedValue" (int .Redactions.Total) }} |
+{{- end }}
+
+---
+
+## {{ icon "chart-bar" "info" }} {{ t "activity.summary" }}
+{{- if $a.Truncated }}
+
+> *{{ t "activity.truncated" }}*
+{{- end }}
+
+| {{ t "col.activity" }} | {{ t "col.issues" }} |
+|:---------|:------:|
+| {{ t "activity.opened" }} | {{ t "activity.stillOpen" (bold (int (len $a.Opened))) (int $a.StillOpen) }} |
+| {{ t "activity.fixed" }} | **{{ int (len $a.Fixed) }}** |
+| {{ t "activity.falsePositive" }} | **{{ int (len $a.FalsePositive) }}** |
+| {{ t "activity.wontFix" }} | **{{ int (len $a.WontFix) }}** |
+
+| {{ t "activity.debt" }} | {{ t "col.effort" }} |
+|:---------------|:------:|
+| {{ t "activity.debtAdded" }} | {{ formatDebtChange $a.DebtAdded }} |
+| {{ t "activity.debtRemoved" }} | {{ formatDebtChange (neg $a.DebtRemoved) }} |
+| **{{ t "activity.debtNet" }}** | **{{ formatDebtChange $a.NetDebtChange }}** |
+
+{{- if $a.Opened }}
+
+---
+
+## {{ icon "alert-triangle" "danger" }} {{ t "activity.openedTitle" }} ({{ int (len $a.Opened) }})
+{{ template "issues" $a.Opened }}
+{{- end }}
+
+{{- if $a.Fixed }}
+
+---
+
+## {{ icon "check-circle" "success" }} {{ t "activity.fixedTitle" }} ({{ int (len $a.Fixed) }})
+{{ template "issues" $a.Fixed }}
+{{- end }}
+
+{{- if $a.FalsePositive }}
+
+---
+
+## {{ icon "info-circle" "info" }} {{ t "activity.falsePosTitle" }} ({{ int (len $a.FalsePositive) }})
+{{ template "issues" $a.FalsePositive }}
+{{- end }}
+
+{{- if $a.WontFix }}
+
+---
+
+## {{ icon "info-circle" "warning" }} {{ t "activity.wontFixTitle" }} ({{ int (len $a.WontFix) }})
+{{ template "issues" $a.WontFix }}
+{{- end }}
+
+---
+
+*{{ t "activity.generatedBy" "**SonarQube Report Generator**" }}*
+*{{ formatTime .GeneratedAt }}*
+`
@@ -0,0 +1,181 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// GenerateDiff generates a markdown delta report for a comparison of two reports
+func (g *MarkdownGenerator) GenerateDiff(diff *ReportDiff) ([]byte, error) {
+	tmpl, err := template.New("diff").Funcs(localeFuncs(NewLocale(diff.Language, diff.Timezone), 0)).Funcs(template.FuncMap{
+		"qualityGateIcon": qualityGateIcon,
+		"truncate":        truncateString,
+		"icon":            icon,
+		"trendIcon":       trendIcon,
+		"orDash":          orDash,
+		"code": func(s string) string {
+			return "`" + s + "`"
+		},
+		"add": func(a, b int) int {
+			return a + b
+		},
+	}).Parse(markdownDiffTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, diff); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func trendIcon(trend string) string {
+	switch trend {
+	case TrendBetter:
+		return icon("trending-up", "success")
+	case TrendWorse:
+		return icon("alert-triangle", "danger")
+	default:
+		return ""
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+const markdownDiffTemplate = `{{- $branches := eq .Kind "branches" -}}
+# {{ icon "chart-bar" "info" }} {{ if $branches }}{{ t "diff.titleBranches" }}{{ else }}{{ t "diff.titleReports" }}{{ end }}
+
+### {{ .ProjectName }} — {{ if $branches }}{{ t "diff.subtitleBranches" (code .Head.Branch) (code .Base.Branch) }}{{ else }}{{ t "diff.subtitleReports" }}{{ end }}
+
+---
+
+## {{ icon "info-circle" "info" }} {{ if $branches }}{{ t "diff.comparedBranches" }}{{ else }}{{ t "diff.comparedReports" }}{{ end }}
+
+| | {{ t "col.base" }} | {{ t "col.head" }} |
+|---|---|---|
+{{- if .Base.ReportID }}
+| **{{ t "col.report" }}** | ` + "`{{ .Base.ReportID }}`" + ` | ` + "`{{ .Head.ReportID }}`" + ` |
+{{- end }}
+| **{{ t "info.branch" }}** | ` + "`{{ .Base.Branch }}`" + ` | ` + "`{{ .Head.Branch }}`" + ` |
+| **{{ t "col.generated" }}** | {{ formatTime .Base.GeneratedAt }} | {{ formatTime .Head.GeneratedAt }} |
+| **{{ t "info.lastAnalysis" }}** | {{ orDash (dateTime .Base.AnalysisDate) }} | {{ orDash (dateTime .Head.AnalysisDate) }} |
+| **{{ t "issues.total" }}** | {{ int .Base.TotalIssues }} | {{ int .Head.TotalIssues }} |
+
+---
+
+## {{ icon "activity" "info" }} {{ t "qualityGate.title" }}
+
+{{- if $branches }}
+
+| {{ t "col.base" }} | {{ t "col.head" }} |
+|:----:|:----:|
+| {{ qualityGateIcon .QualityGate.From }} **{{ qualityGateText .QualityGate.From }}** | {{ qualityGateIcon .QualityGate.To }} **{{ qualityGateText .QualityGate.To }}** |
+{{- else if .QualityGate.Changed }}
+
+{{ qualityGateIcon .QualityGate.From }} **{{ qualityGateText .QualityGate.From }}** → {{ qualityGateIcon .QualityGate.To }} **{{ qualityGateText .QualityGate.To }}**
+{{- else }}
+
+{{ qualityGateIcon .QualityGate.To }} {{ t "qualityGate.unchanged" (bold (qualityGateText .QualityGate.To)) }}
+{{- end }}
+
+{{- if .ConditionChanges }}
+
+| {{ t "col.metric" }} | {{ t "col.status" }} | {{ t "col.value" }} |
+|:-------|:-------|:------|
+{{- range .ConditionChanges }}
+| {{ .Metric }} | {{ orDash .FromStatus }} → {{ orDash .ToStatus }} | {{ orDash (num .FromValue) }} → {{ orDash (num .ToValue) }} |
+{{- end }}
+{{- end }}
+
+---
+
+## {{ icon "trending-up" "info" }} {{ if $branches }}{{ t "diff.ratingsAndMetrics" }}{{ else }}{{ t "diff.metricDeltas" }}{{ end }}
+
+| {{ t "col.metric" }} | {{ t "col.base" }} | {{ t "col.head" }} | {{ t "col.change" }} | |
+|:-------|:----:|:----:|:------:|:-:|
+{{- range .MetricDeltas }}
+| {{ metricName .Metric }} | {{ orDash (measure .From) }} | {{ orDash (measure .To) }} | {{ if .Delta }}**{{ measure .Delta }}**{{ else if eq .From .To }}-{{ else }}{{ t "diff.changed" }}{{ end }} | {{ trendIcon .Trend }} |
+{{- end }}
+
+---
+
+## {{ icon "search" "info" }} {{ t "diff.issueChanges" }}
+
+| {{ t "col.change" }} | {{ t "col.count" }} |
+|:-------|:-----:|
+{{- if $branches }}
+| {{ t "diff.onlyIn" (code .Head.Branch) }} | **{{ int (len .NewIssues) }}** |
+| {{ t "diff.onlyIn" (code .Base.Branch) }} | **{{ int (len .FixedIssues) }}** |
+| {{ t "diff.differentSeverity" }} | **{{ int (len .SeverityChanges) }}** |
+| {{ t "diff.inBoth" }} | {{ int .UnchangedIssues }} |
+{{- else }}
+| {{ t "diff.newIssues" }} | **{{ int (len .NewIssues) }}** |
+| {{ t "diff.fixedIssues" }} | **{{ int (len .FixedIssues) }}** |
+| {{ t "diff.severityChanges" }} | **{{ int (len .SeverityChanges) }}** |
+| {{ t "diff.unchanged" }} | {{ int .UnchangedIssues }} |
+{{- end }}
+
+{{- if and .MatchedByFingerprint (not $branches) }}
+
+> {{ t "diff.matchedByFingerprint" (int .MatchedByFingerprint) }}
+{{- end }}
+
+{{- if .NewIssues }}
+
+### {{ icon "alert-triangle" "danger" }} {{ if $branches }}{{ t "diff.onlyIn" (code .Head.Branch) }}{{ else }}{{ t "diff.newIssuesTitle" }}{{ end }} ({{ int (len .NewIssues) }})
+
+| # | {{ t "col.severity" }} | {{ t "col.type" }} | {{ t "col.file" }} | {{ t "col.line" }} | {{ t "col.message" }} |
+|:-:|:---------|:-----|:-----|:----:|:--------|
+{{- range $idx, $issue := .NewIssues }}
+{{- if lt $idx 50 }}
+| {{ add $idx 1 }} | {{ severityIcon .Severity }} | {{ issueType .Type }} | ` + "`{{ .Component }}`" + ` | {{ .Line }} | {{ truncate .Message 60 }} |
+{{- end }}
+{{- end }}
+{{- if gt (len .NewIssues) 50 }}
+
+> {{ t "common.showing" (int (len .NewIssues)) }}
+{{- end }}
+{{- end }}
+
+{{- if .FixedIssues }}
+
+### {{ icon "check-circle" "success" }} {{ if $branches }}{{ t "diff.onlyIn" (code .Base.Branch) }}{{ else }}{{ t "diff.fixedIssuesTitle" }}{{ end }} ({{ int (len .FixedIssues) }})
+
+| # | {{ t "col.severity" }} | {{ t "col.type" }} | {{ t "col.file" }} | {{ t "col.line" }} | {{ t "col.message" }} |
+|:-:|:---------|:-----|:-----|:----:|:--------|
+{{- range $idx, $issue := .FixedIssues }}
+{{- if lt $idx 50 }}
+| {{ add $idx 1 }} | {{ severityIcon .Severity }} | {{ issueType .Type }} | ` + "`{{ .Component }}`" + ` | {{ .Line }} | {{ truncate .Message 60 }} |
+{{- end }}
+{{- end }}
+{{- if gt (len .FixedIssues) 50 }}
+
+> {{ t "common.showing" (int (len .FixedIssues)) }}
+{{- end }}
+{{- end }}
+
+{{- if .SeverityChanges }}
+
+### {{ icon "activity" "warning" }} {{ if $branches }}{{ t "diff.differentSeverityTitle" }}{{ else }}{{ t "diff.severityChangesTitle" }}{{ end }} ({{ int (len .SeverityChanges) }})
+
+| # | {{ if $branches }}{{ t "col.base" }}{{ else }}{{ t "col.from" }}{{ end }} | {{ if $branches }}{{ t "col.head" }}{{ else }}{{ t "col.to" }}{{ end }} | {{ t "col.file" }} | {{ t "col.line" }} | {{ t "col.message" }} |
+|:-:|:-----|:---|:-----|:----:|:--------|
+{{- range $idx, $change := .SeverityChanges }}
+| {{ add $idx 1 }} | {{ severityIcon .FromSeverity }} | {{ severityIcon .ToSeverity }} | ` + "`{{ .Issue.Component }}`" + ` | {{ .Issue.Line }} | {{ truncate .Issue.Message 60 }} |
+{{- end }}
+{{- end }}
+
+---
+
+*{{ if $branches }}{{ t "diff.generatedBranches" "**SonarQube Report Generator**" }}{{ else }}{{ t "diff.generatedReports" "**SonarQube Report Generator**" }}{{ end }}*
+*{{ formatTime .GeneratedAt }}*
+`
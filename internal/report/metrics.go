@@ -0,0 +1,187 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// maxSelectedMetrics is the most metrics a report can select
+const maxSelectedMetrics = 50
+
+// metricCatalogTTL is how long metric definitions are cached
+const metricCatalogTTL = time.Hour
+
+// MetricCatalog caches the metric definitions of the server, used to
+// validate and format the metrics selected for a report
+type MetricCatalog struct {
+	client *sonarqube.Client
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	metrics   map[string]sonarqube.Metric
+	fetchedAt time.Time
+}
+
+// NewMetricCatalog creates a metric catalog caching definitions for ttl
+func NewMetricCatalog(client *sonarqube.Client, ttl time.Duration) *MetricCatalog {
+	return &MetricCatalog{client: client, ttl: ttl}
+}
+
+// all returns the metric definitions by key, fetching them when stale
+func (c *MetricCatalog) all() (map[string]sonarqube.Metric, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.metrics != nil && time.Since(c.fetchedAt) < c.ttl {
+		return c.metrics, nil
+	}
+
+	metrics, err := c.client.GetMetrics()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics: %w", err)
+	}
+	c.metrics = make(map[string]sonarqube.Metric, len(metrics))
+	for _, m := range metrics {
+		c.metrics[m.Key] = m
+	}
+	c.fetchedAt = time.Now()
+	return c.metrics, nil
+}
+
+// List returns the visible metrics sorted by domain and name
+func (c *MetricCatalog) List() ([]sonarqube.Metric, error) {
+	metrics, err := c.all()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]sonarqube.Metric, 0, len(metrics))
+	for _, m := range metrics {
+		if !m.Hidden {
+			list = append(list, m)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Domain != list[j].Domain {
+			return list[i].Domain < list[j].Domain
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list, nil
+}
+
+// Validate checks that metric keys exist on the server
+func (c *MetricCatalog) Validate(keys []string) error {
+	if len(keys) > maxSelectedMetrics {
+		return fmt.Errorf("at most %d metrics can be selected", maxSelectedMetrics)
+	}
+
+	metrics, err := c.all()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, ok := metrics[key]; !ok {
+			return fmt.Errorf("unknown metric %q", key)
+		}
+	}
+	return nil
+}
+
+// MetricValue is the value of a metric selected for a report
+type MetricValue struct {
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	Domain    string `json:"domain,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Direction int    `json:"direction,omitempty"`
+	Value     string `json:"value,omitempty"` // raw value, empty when the project has none
+	Formatted string `json:"formatted"`
+}
+
+// measureKeys returns the metrics to fetch: the default ones plus the selected ones
+func measureKeys(selected []string) []string {
+	keys := sonarqube.DefaultMetricKeys()
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		seen[key] = true
+	}
+	for _, key := range selected {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// selectedMetrics returns the values of the selected metrics in the order
+// they were selected. Without definitions, values are shown as they are.
+func selectedMetrics(keys []string, measures []sonarqube.Measure, definitions map[string]sonarqube.Metric, dayHours int) []MetricValue {
+	values := make(map[string]string, len(measures))
+	for _, m := range measures {
+		value := m.Value
+		if value == "" && m.Period != nil {
+			value = m.Period.Value // new code metrics
+		}
+		values[m.Metric] = value
+	}
+
+	selected := make([]MetricValue, 0, len(keys))
+	for _, key := range keys {
+		def, ok := definitions[key]
+		if !ok {
+			def = sonarqube.Metric{Key: key, Name: key}
+		}
+		v := MetricValue{
+			Key:       key,
+			Name:      def.Name,
+			Domain:    def.Domain,
+			Type:      def.Type,
+			Direction: def.Direction,
+			Value:     values[key],
+			Formatted: formatMetricValue(def.Type, values[key], dayHours),
+		}
+		selected = append(selected, v)
+	}
+	return selected
+}
+
+// formatMetricValue formats a raw measure according to its metric type.
+// Booleans stay "true" or "false"; reports show them in their language.
+func formatMetricValue(metricType, value string, dayHours int) string {
+	if value == "" {
+		return "-"
+	}
+
+	switch metricType {
+	case "PERCENT":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return strconv.FormatFloat(math.Round(f*10)/10, 'f', -1, 64) + "%"
+		}
+	case "FLOAT":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+		}
+	case "RATING":
+		return RatingToLetter(value)
+	case "WORK_DUR":
+		return formatDebt(value, dayHours)
+	case "MILLISEC":
+		if ms, err := strconv.Atoi(value); err == nil {
+			if ms < 1000 {
+				return fmt.Sprintf("%dms", ms)
+			}
+			return fmt.Sprintf("%.1fs", float64(ms)/1000)
+		}
+	case "LEVEL":
+		return QualityGateText(value)
+	}
+	return value
+}
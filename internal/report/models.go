@@ -1,15 +1,38 @@
 package report
 
-import "time"
+import (
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
 
 // ReportData contains all data needed for report generation
 type ReportData struct {
 	// Project info
-	ProjectKey   string    `json:"projectKey"`
-	ProjectName  string    `json:"projectName"`
-	Branch       string    `json:"branch"`
-	GeneratedAt  time.Time `json:"generatedAt"`
-	AnalysisDate string    `json:"analysisDate,omitempty"`
+	ProjectKey   string             `json:"projectKey"`
+	ProjectName  string             `json:"projectName"`
+	Branch       string             `json:"branch"`
+	GeneratedAt  time.Time          `json:"generatedAt"`
+	AnalysisDate sonarqube.DateTime `json:"analysisDate,omitempty"`
+	AnalysisKey  string             `json:"analysisKey,omitempty"`
+
+	// Team is set for reports limited to the files owned by one team
+	Team string `json:"team,omitempty"`
+
+	// Profile names the report profile the report was generated with;
+	// Sections lists the sections to render, all when empty
+	Profile  string   `json:"profile,omitempty"`
+	Sections []string `json:"sections,omitempty"`
+
+	// Severities and Types are set for reports limited to issues of these
+	// severities and types
+	Severities []string `json:"severities,omitempty"`
+	Types      []string `json:"types,omitempty"`
+
+	// Language and Timezone are the language and time zone the report is
+	// rendered in; Languages, below, are those of the project's code
+	Language string `json:"language,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
 
 	// Quality Gate
 	QualityGateStatus     string            `json:"qualityGateStatus"` // PASSED, FAILED, WARNING
@@ -18,6 +41,12 @@ type ReportData struct {
 	// Metrics
 	Metrics MetricsSummary `json:"metrics"`
 
+	// SelectedMetrics are the values of the extra metrics chosen for the report
+	SelectedMetrics []MetricValue `json:"selectedMetrics,omitempty"`
+
+	// Languages breaks down the lines of code and issues by language
+	Languages []LanguageSummary `json:"languages,omitempty"`
+
 	// Issues
 	TotalIssues      int                    `json:"totalIssues"`
 	IssuesByType     map[string]int         `json:"issuesByType"`
@@ -27,6 +56,41 @@ type ReportData struct {
 	TotalHotspots      int            `json:"totalHotspots"`
 	Hotspots           []HotspotItem  `json:"hotspots"`
 	HotspotsByPriority map[string]int `json:"hotspotsByPriority"`
+
+	// Historical is set for reports reconstructed for a point in the past
+	Historical *HistoricalInfo `json:"historical,omitempty"`
+
+	// Activity is set for activity reports, which only describe the issues
+	// opened and resolved during a period
+	Activity *ActivityReport `json:"activity,omitempty"`
+
+	// Policy is the verdict of the compliance policy evaluated for the report
+	Policy *PolicyResult `json:"policy,omitempty"`
+
+	// Scoring records the weights of the issue risk scores and TopIssues the
+	// highest scored issues, the things to fix first
+	Scoring   *ScoringInfo `json:"scoring,omitempty"`
+	TopIssues []IssueItem  `json:"topIssues,omitempty"`
+
+	// Effort aggregates the remediation effort of all issues in the report's
+	// scope, including those not listed; efforts are shown in working days
+	// of WorkingDayHours hours
+	Effort          *EffortSummary `json:"effort,omitempty"`
+	WorkingDayHours int            `json:"workingDayHours,omitempty"`
+
+	// Baseline is set for reports that separate the issues accepted in a
+	// baseline from the new ones
+	Baseline *BaselineInfo `json:"baseline,omitempty"`
+
+	// Suppressed lists the issues left out by the suppression list
+	Suppressed *SuppressionInfo `json:"suppressed,omitempty"`
+
+	// Owners summarises the issues per owning team when the project has a
+	// CODEOWNERS file
+	Owners []OwnerSummary `json:"owners,omitempty"`
+
+	// Redactions counts the secrets masked in messages and code snippets
+	Redactions *RedactionInfo `json:"redactions,omitempty"`
 }
 
 // ConditionResult represents a quality gate condition result
@@ -47,6 +111,7 @@ type MetricsSummary struct {
 	DuplicatedLinesDensity string `json:"duplicatedLinesDensity"`
 	LinesOfCode            string `json:"linesOfCode"`
 	TechnicalDebt          string `json:"technicalDebt"`
+	TechnicalDebtMinutes   int    `json:"technicalDebtMinutes"` // sqale_index; TechnicalDebt is formatted for display
 
 	// Ratings (A-E)
 	ReliabilityRating     string `json:"reliabilityRating"`
@@ -75,6 +140,18 @@ type IssueItem struct {
 	CodeSnippet string `json:"codeSnippet,omitempty"` // Source code snippet
 	HowToFix    string `json:"howToFix,omitempty"`    // Rule description / how to fix
 	Language    string `json:"language,omitempty"`    // Programming language for syntax highlighting
+	LanguageKey string `json:"languageKey,omitempty"` // SonarQube language key of the file or rule
+
+	CreationDate  sonarqube.DateTime `json:"creationDate,omitempty"`
+	Tags          []string           `json:"tags,omitempty"`
+	Author        string             `json:"author,omitempty"`
+	EffortMinutes int                `json:"effortMinutes,omitempty"` // Effort parsed into minutes
+	InBaseline    bool               `json:"inBaseline,omitempty"`    // Accepted in the project's baseline
+	Fingerprint   string             `json:"fingerprint,omitempty"`   // Identifies the issue across reports by rule, file and code
+	Owners        []string           `json:"owners,omitempty"`        // Owning teams from CODEOWNERS
+
+	// Score is the risk score (0..100) used to prioritise the issue
+	Score float64 `json:"score,omitempty"`
 }
 
 // HotspotItem represents a security hotspot for display
@@ -94,11 +171,50 @@ type ReportRecord struct {
 	ProjectKey  string    `json:"projectKey"`
 	ProjectName string    `json:"projectName"`
 	Branch      string    `json:"branch"`
-	Format      string    `json:"format"` // md, pdf
+	Format      string    `json:"format"` // md, pdf, html
 	FileName    string    `json:"fileName"`
 	FilePath    string    `json:"filePath"`
 	FileSize    int64     `json:"fileSize"`
 	GeneratedAt time.Time `json:"generatedAt"`
+
+	// SnapshotPath is the stored ReportData the file was rendered from.
+	// Empty for reports saved before snapshots existed.
+	SnapshotPath string `json:"snapshotPath,omitempty"`
+
+	// GenerationKey identifies the request and analysis the report was built
+	// from, so identical requests can reuse it
+	GenerationKey string `json:"generationKey,omitempty"`
+
+	// AsOf is set for historical reports reconstructed for a past point in time
+	AsOf *time.Time `json:"asOf,omitempty"`
+
+	// SourceID is the report this one was re-rendered from, if any
+	SourceID string `json:"sourceId,omitempty"`
+
+	// Language and Timezone the report is rendered in
+	Language string `json:"language,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+
+	// Branch comparisons (Kind "comparison") also store the data of the base
+	// branch; Branch is the head branch
+	Kind             string `json:"kind,omitempty"`
+	BaseBranch       string `json:"baseBranch,omitempty"`
+	BaseSnapshotPath string `json:"baseSnapshotPath,omitempty"`
+}
+
+// Report kinds other than regular reports
+const (
+	ReportKindComparison = "comparison" // branch comparison reports
+	ReportKindActivity   = "activity"   // period activity reports
+)
+
+// SaveOptions contains optional metadata recorded with a saved report
+type SaveOptions struct {
+	GenerationKey string
+	SourceID      string
+
+	// CompareWith is the base branch data of a branch comparison report
+	CompareWith *ReportData
 }
 
 // GenerateRequest represents a report generation request
@@ -13,7 +13,15 @@ func NewPDFGenerator() *PDFGenerator {
 	return &PDFGenerator{}
 }
 
+func (g *PDFGenerator) Format() ReportFormat {
+	return ReportFormat{Name: "pdf", Title: "PDF", Extension: "pdf", ContentType: "application/pdf"}
+}
+
 func (g *PDFGenerator) Generate(data *ReportData) ([]byte, error) {
+	if data.Activity != nil {
+		return g.generateActivity(data)
+	}
+
 	pdf := g.createPDF(data)
 
 	var buf bytes.Buffer
@@ -32,132 +40,452 @@ func (g *PDFGenerator) createPDF(data *ReportData) *gofpdf.Fpdf {
 	pdf.AddPage()
 	pdf.SetFont("Arial", "", 10)
 
-	g.renderHeader(pdf)
-	g.renderProjectInfo(pdf, data)
-	g.renderQualityGate(pdf, data)
-	g.renderMetrics(pdf, data)
-	g.renderIssues(pdf, data)
-	g.renderHotspots(pdf, data)
-	g.renderSummary(pdf, data)
+	loc := NewLocale(data.Language, data.Timezone)
+	g.renderHeader(pdf, loc.T("report.title"))
+	g.renderProjectInfo(pdf, data, loc)
+
+	// Sections in order, rendered when the report's profile includes them
+	for _, s := range []struct {
+		section string
+		render  func(*gofpdf.Fpdf, *ReportData, *Locale)
+	}{
+		{SectionQualityGate, g.renderQualityGate},
+		{SectionPolicy, g.renderPolicy},
+		{SectionTopIssues, g.renderTopIssues},
+		{SectionBaseline, g.renderBaseline},
+		{SectionMetrics, g.renderMetrics},
+		{SectionMetrics, g.renderSelectedMetrics},
+		{SectionLanguages, g.renderLanguages},
+		{SectionIssues, g.renderIssues},
+		{SectionOwners, g.renderOwners},
+		{SectionIssueDetails, g.renderIssueDetails},
+		{SectionRemediation, g.renderRemediationPlan},
+		{SectionHotspots, g.renderHotspots},
+		{SectionSummary, g.renderSummary},
+		{SectionSuppressed, g.renderSuppressed},
+	} {
+		if data.Shows(s.section) {
+			s.render(pdf, data, loc)
+		}
+	}
 
 	return pdf
 }
 
-func (g *PDFGenerator) renderHeader(pdf *gofpdf.Fpdf) {
+func (g *PDFGenerator) renderHeader(pdf *gofpdf.Fpdf, title string) {
 	pdf.SetFont("Arial", "B", 16)
-	pdf.CellFormat(0, 10, "SonarQube Analysis Report", "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 10, title, "", 1, "C", false, 0, "")
 	pdf.Ln(3)
 	pdf.Line(15, pdf.GetY(), 195, pdf.GetY())
 	pdf.Ln(5)
 }
 
-func (g *PDFGenerator) renderProjectInfo(pdf *gofpdf.Fpdf, data *ReportData) {
+func (g *PDFGenerator) renderProjectInfo(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
 	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(0, 8, "Project Information", "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 8, loc.T("info.title"), "", 1, "L", false, 0, "")
 	pdf.Ln(3)
 
 	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(45, 6, "Project Name:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(0, 6, data.ProjectName, "", 1, "L", false, 0, "")
+	info := [][2]string{
+		{loc.T("info.projectName"), data.ProjectName},
+		{loc.T("info.projectKey"), data.ProjectKey},
+		{loc.T("info.branch"), data.Branch},
+	}
+	if data.Team != "" {
+		info = append(info, [2]string{loc.T("info.team"), loc.T("info.teamValue", data.Team)})
+	}
+	if data.Profile != "" {
+		info = append(info, [2]string{loc.T("info.profile"), data.Profile})
+	}
+	if filter := loc.IssueFilter(data); filter != "" {
+		info = append(info, [2]string{loc.T("info.issueFilter"), filter})
+	}
+	info = append(info, [2]string{loc.T("info.generated"), loc.Time(data.GeneratedAt)})
+	if !data.AnalysisDate.IsZero() {
+		info = append(info, [2]string{loc.T("info.lastAnalysis"), loc.DateTime(data.AnalysisDate)})
+	}
+	if data.Redactions != nil {
+		info = append(info, [2]string{loc.T("info.redacted"), redactionText(data.Redactions, loc)})
+	}
+	if data.Historical != nil {
+		info = append(info, [2]string{loc.T("info.asOf"), loc.Time(data.Historical.AsOf) + " (" + data.Historical.Requested + ")"})
+	}
+	g.renderInfoRows(pdf, info)
 
-	pdf.CellFormat(45, 6, "Project Key:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(0, 6, data.ProjectKey, "", 1, "L", false, 0, "")
+	if data.Historical != nil {
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "I", 9)
+		pdf.MultiCell(0, 5, loc.T("report.historicalBanner", loc.Time(data.Historical.AsOf))+" "+loc.T("report.historicalNote"), "", "L", false)
+	}
 
-	pdf.CellFormat(45, 6, "Branch:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(0, 6, data.Branch, "", 1, "L", false, 0, "")
+	pdf.Ln(5)
+}
 
-	pdf.CellFormat(45, 6, "Report Generated:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(0, 6, formatTimeSimple(data.GeneratedAt), "", 1, "L", false, 0, "")
+// renderInfoRows renders label and value pairs
+func (g *PDFGenerator) renderInfoRows(pdf *gofpdf.Fpdf, rows [][2]string) {
+	for _, row := range rows {
+		pdf.CellFormat(45, 6, row[0]+":", "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, row[1], "", 1, "L", false, 0, "")
+	}
+}
 
-	pdf.Ln(5)
+// redactionText describes the secrets masked in a report
+func redactionText(info *RedactionInfo, loc *Locale) string {
+	return loc.T("info.redactedValue", loc.Int(info.Total))
+}
+
+// renderHistoricalNote notes how a section of a historical report was obtained
+func (g *PDFGenerator) renderHistoricalNote(pdf *gofpdf.Fpdf, data *ReportData, section, note string) {
+	if !data.Historical.IsReconstructed(section) && !data.Historical.IsUnavailable(section) {
+		return
+	}
+	pdf.SetFont("Arial", "I", 8)
+	pdf.MultiCell(0, 4, note, "", "L", false)
+	pdf.Ln(2)
 }
 
-func (g *PDFGenerator) renderQualityGate(pdf *gofpdf.Fpdf, data *ReportData) {
+func (g *PDFGenerator) renderQualityGate(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
 	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(0, 8, "Quality Gate", "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 8, loc.T("qualityGate.title"), "", 1, "L", false, 0, "")
 	pdf.Ln(3)
 
-	status := QualityGateText(data.QualityGateStatus)
+	status := loc.QualityGate(data.QualityGateStatus)
 	pdf.SetFont("Arial", "B", 12)
 	pdf.CellFormat(0, 6, status, "", 1, "L", false, 0, "")
 	pdf.Ln(3)
 
+	g.renderHistoricalNote(pdf, data, SectionQualityGate, loc.T("qualityGate.reconstructed"))
+
 	if len(data.QualityGateConditions) > 0 {
 		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(0, 6, "Conditions:", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 6, loc.T("qualityGate.conditions")+":", "", 1, "L", false, 0, "")
 		pdf.Ln(2)
 
 		colW := []float64{60.0, 25.0, 40.0, 40.0}
-		g.renderSimpleTable(pdf, []string{"Metric", "Status", "Value", "Threshold"}, []string{}, colW)
+		g.renderSimpleTable(pdf, loc.columns("metric", "status", "value", "threshold"), []string{}, colW)
 
 		for _, cond := range data.QualityGateConditions {
-			statusIcon := "OK"
+			statusIcon := loc.T("qualityGate.conditionOK")
 			if cond.Status != "OK" {
-				statusIcon = "FAIL"
+				statusIcon = loc.T("qualityGate.conditionFail")
+			}
+			row := []string{cond.Metric, statusIcon, loc.Number(cond.ActualValue), cond.Comparator + " " + loc.Number(cond.ErrorThreshold)}
+			g.renderSimpleTable(pdf, []string{}, row, colW)
+		}
+	}
+
+	pdf.Ln(5)
+}
+
+func (g *PDFGenerator) renderPolicy(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
+	if data.Policy == nil {
+		return
+	}
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("policy.title"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	pdf.SetFont("Arial", "B", 11)
+	if data.Policy.Passed {
+		pdf.CellFormat(0, 6, loc.T("policy.compliant", data.Policy.Policy), "", 1, "L", false, 0, "")
+	} else {
+		pdf.CellFormat(0, 6, loc.T("policy.notCompliant", data.Policy.Policy, loc.Int(data.Policy.Failed())), "", 1, "L", false, 0, "")
+	}
+	if data.Policy.Description != "" {
+		pdf.SetFont("Arial", "I", 9)
+		pdf.MultiCell(0, 5, data.Policy.Description, "", "L", false)
+	}
+	pdf.Ln(3)
+
+	colW := []float64{55.0, 20.0, 105.0}
+	g.renderSimpleTable(pdf, loc.columns("rule", "result", "explanation"), []string{}, colW)
+	for _, rule := range data.Policy.Rules {
+		explanation := loc.PolicyExplanation(rule)
+		if len(rule.Issues) > 0 {
+			explanation += " (" + loc.JoinKeys(rule.Issues, 3) + ")"
+		}
+		g.renderSimpleTable(pdf, []string{}, []string{truncateStr(rule.Name, 32), loc.PolicyStatus(rule.Status), truncateStr(explanation, 62)}, colW)
+	}
+
+	pdf.Ln(5)
+}
+
+func (g *PDFGenerator) renderTopIssues(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
+	if len(data.TopIssues) == 0 {
+		return
+	}
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("topIssues.title", len(data.TopIssues)), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	colW := []float64{8.0, 14.0, 22.0, 58.0, 12.0, 18.0, 48.0}
+	g.renderSimpleTable(pdf, loc.columns("number", "score", "severity", "file", "line", "effort", "message"), []string{}, colW)
+	for i, issue := range data.TopIssues {
+		row := []string{
+			fmt.Sprintf("%d", i+1),
+			loc.Float(issue.Score, 1),
+			loc.Severity(issue.Severity),
+			truncateStr(issue.Component, 34),
+			fmt.Sprintf("%d", issue.Line),
+			orDash(loc.EffortText(issue.Effort)),
+			truncateStr(issue.Message, 28),
+		}
+		g.renderSimpleTable(pdf, []string{}, row, colW)
+	}
+
+	if data.Scoring != nil {
+		note := loc.T("topIssues.ranking", loc.ScoreWeights(data.Scoring.Weights))
+		if data.Scoring.Weights.LineCoverage > 0 && !data.Scoring.CoverageKnown {
+			note += " " + loc.T("topIssues.noCoverage")
+		}
+		pdf.SetFont("Arial", "I", 8)
+		pdf.MultiCell(0, 5, note, "", "L", false)
+	}
+
+	pdf.Ln(5)
+}
+
+func (g *PDFGenerator) renderBaseline(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
+	if data.Baseline == nil {
+		return
+	}
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("baseline.title"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.MultiCell(0, 6, loc.T("baseline.summary", loc.Int(data.Baseline.NewIssues), loc.Int(data.Baseline.BaselineIssues)), "", "L", false)
+	note := loc.T("baseline.frozen", loc.Time(data.Baseline.CreatedAt))
+	if !data.Baseline.RefreshedAt.Equal(data.Baseline.CreatedAt) {
+		note += loc.T("baseline.refreshed", loc.Time(data.Baseline.RefreshedAt))
+	}
+	note += "."
+	if data.Baseline.Truncated {
+		note += " " + loc.T("baseline.truncated")
+	}
+	pdf.SetFont("Arial", "I", 9)
+	pdf.MultiCell(0, 5, note, "", "L", false)
+	pdf.Ln(3)
+
+	colW := []float64{50.0, 30.0, 30.0}
+	g.renderSimpleTable(pdf, []string{loc.T("col.severity"), loc.T("baseline.new"), loc.T("baseline.inBaseline")}, []string{}, colW)
+	for _, sev := range data.Baseline.Severities() {
+		row := []string{loc.Severity(sev), loc.Int(data.Baseline.NewBySeverity[sev]), loc.Int(data.Baseline.BaselineBySeverity[sev])}
+		g.renderSimpleTable(pdf, []string{}, row, colW)
+	}
+	pdf.Ln(3)
+
+	g.renderDiffIssueList(pdf, loc, loc.T("baseline.newIssues"), data.NewIssues())
+}
+
+// renderOwners lists the issues per owning team
+func (g *PDFGenerator) renderOwners(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
+	if len(data.Owners) == 0 {
+		return
+	}
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("owners.title"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	severities := []string{"BLOCKER", "CRITICAL", "MAJOR", "MINOR", "INFO"}
+	headers := loc.columns("owner", "issues")
+	for _, sev := range severities {
+		headers = append(headers, loc.SeverityTitle(sev))
+	}
+	headers = append(headers, loc.T("col.effort"))
+
+	colW := []float64{50.0, 18.0, 18.0, 18.0, 18.0, 18.0, 18.0, 22.0}
+	g.renderSimpleTable(pdf, headers, []string{}, colW)
+	for _, owner := range data.Owners {
+		row := []string{truncateStr(owner.Owner, 30), loc.Int(owner.Issues)}
+		for _, sev := range severities {
+			row = append(row, loc.Int(owner.BySeverity[sev]))
+		}
+		row = append(row, loc.Effort(owner.EffortMinutes, data.WorkingDayHours))
+		g.renderSimpleTable(pdf, []string{}, row, colW)
+	}
+
+	pdf.Ln(2)
+	pdf.SetFont("Arial", "I", 9)
+	pdf.CellFormat(0, 5, loc.T("owners.note"), "", 1, "L", false, 0, "")
+	pdf.Ln(5)
+}
+
+func (g *PDFGenerator) renderRemediationPlan(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
+	if data.Effort == nil || data.Effort.TotalMinutes == 0 {
+		return
+	}
+	dayHours := data.WorkingDayHours
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("remediation.title"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.MultiCell(0, 6, loc.T("remediation.intro", loc.Int(data.Effort.Issues), loc.Effort(data.Effort.TotalMinutes, dayHours),
+		loc.T("remediation.personDays", loc.PersonDays(data.Effort.TotalMinutes, dayHours)), workingDayHours(dayHours)), "", "L", false)
+	if data.Effort.Truncated {
+		pdf.SetFont("Arial", "I", 9)
+		pdf.MultiCell(0, 5, loc.T("remediation.truncated", loc.Int(data.Effort.Issues)), "", "L", false)
+	}
+	pdf.Ln(3)
+
+	keep := func(key string) string { return key }
+	colW := []float64{80.0, 25.0, 35.0, 30.0}
+	for _, table := range []struct {
+		column string
+		groups []EffortGroup
+		name   func(string) string
+	}{
+		{"severity", data.Effort.BySeverity, loc.Severity},
+		{"type", data.Effort.ByType, loc.IssueType},
+		{"rule", data.Effort.ByRule, keep},
+		{"file", data.Effort.ByFile, keep},
+		{"author", data.Effort.ByAuthor, keep},
+	} {
+		if len(table.groups) == 0 {
+			continue
+		}
+		g.renderSimpleTable(pdf, loc.columns(table.column, "issues", "effort", "personDays"), []string{}, colW)
+		for _, group := range table.groups {
+			row := []string{
+				truncateStr(table.name(group.Key), 48),
+				loc.Int(group.Issues),
+				loc.Effort(group.Minutes, dayHours),
+				loc.PersonDays(group.Minutes, dayHours),
 			}
-			row := []string{cond.Metric, statusIcon, cond.ActualValue, cond.Comparator + " " + cond.ErrorThreshold}
 			g.renderSimpleTable(pdf, []string{}, row, colW)
 		}
+		pdf.Ln(3)
+	}
+
+	pdf.Ln(2)
+}
+
+// renderSelectedMetrics lists the extra metrics chosen for the report
+func (g *PDFGenerator) renderSelectedMetrics(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
+	if len(data.SelectedMetrics) == 0 {
+		return
+	}
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("metrics.selected"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	colW := []float64{85.0, 50.0, 35.0}
+	g.renderSimpleTable(pdf, loc.columns("metric", "domain", "value"), []string{}, colW)
+	for _, m := range data.SelectedMetrics {
+		g.renderSimpleTable(pdf, []string{}, []string{truncateStr(m.Name, 50), truncateStr(orDash(m.Domain), 28), loc.MetricValue(m)}, colW)
+	}
+
+	pdf.Ln(5)
+}
+
+// renderLanguages lists the lines of code and issues per language
+func (g *PDFGenerator) renderLanguages(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
+	if len(data.Languages) == 0 {
+		return
+	}
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("languages.title"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	colW := []float64{50.0, 30.0, 25.0, 25.0, 35.0}
+	g.renderSimpleTable(pdf, loc.columns("language", "linesOfCode", "share", "issues", "issueDensity"), []string{}, colW)
+	for _, lang := range data.Languages {
+		density := "-"
+		if lang.Lines > 0 {
+			density = loc.Float(lang.IssuesPerKLoc, 1)
+		}
+		name := lang.Name
+		if lang.Key == "" {
+			name = loc.T("languages.unknown")
+		}
+		row := []string{
+			truncateStr(name, 30),
+			loc.Int(lang.Lines),
+			loc.Percent(lang.Share),
+			loc.Int(lang.Issues),
+			density,
+		}
+		g.renderSimpleTable(pdf, []string{}, row, colW)
 	}
 
 	pdf.Ln(5)
 }
 
-func (g *PDFGenerator) renderMetrics(pdf *gofpdf.Fpdf, data *ReportData) {
+func (g *PDFGenerator) renderMetrics(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
 	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(0, 8, "Metrics Overview", "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 8, loc.T("metrics.title"), "", 1, "L", false, 0, "")
 	pdf.Ln(3)
 
+	g.renderHistoricalNote(pdf, data, SectionMetrics, loc.T("metrics.reconstructed"))
+
 	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(50, 6, "Bugs:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(30, 6, data.Metrics.Bugs, "", 0, "L", false, 0, "")
+	pdf.CellFormat(50, 6, loc.T("metrics.bugs")+":", "", 0, "L", false, 0, "")
+	pdf.CellFormat(30, 6, loc.Measure(data.Metrics.Bugs), "", 0, "L", false, 0, "")
 	pdf.CellFormat(30, 6, "("+RatingToLetter(data.Metrics.ReliabilityRating)+")", "", 1, "L", false, 0, "")
 
-	pdf.CellFormat(50, 6, "Vulnerabilities:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(30, 6, data.Metrics.Vulnerabilities, "", 0, "L", false, 0, "")
+	pdf.CellFormat(50, 6, loc.T("metrics.vulnerabilities")+":", "", 0, "L", false, 0, "")
+	pdf.CellFormat(30, 6, loc.Measure(data.Metrics.Vulnerabilities), "", 0, "L", false, 0, "")
 	pdf.CellFormat(30, 6, "("+RatingToLetter(data.Metrics.SecurityRating)+")", "", 1, "L", false, 0, "")
 
-	pdf.CellFormat(50, 6, "Code Smells:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(30, 6, data.Metrics.CodeSmells, "", 0, "L", false, 0, "")
+	pdf.CellFormat(50, 6, loc.T("metrics.codeSmells")+":", "", 0, "L", false, 0, "")
+	pdf.CellFormat(30, 6, loc.Measure(data.Metrics.CodeSmells), "", 0, "L", false, 0, "")
 	pdf.CellFormat(30, 6, "("+RatingToLetter(data.Metrics.MaintainabilityRating)+")", "", 1, "L", false, 0, "")
 
 	pdf.Ln(3)
 
-	pdf.CellFormat(50, 6, "Lines of Code:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(0, 6, data.Metrics.LinesOfCode, "", 1, "L", false, 0, "")
+	pdf.CellFormat(50, 6, loc.T("metrics.linesOfCode")+":", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 6, loc.Measure(data.Metrics.LinesOfCode), "", 1, "L", false, 0, "")
 
-	pdf.CellFormat(50, 6, "Coverage:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(0, 6, data.Metrics.Coverage, "", 1, "L", false, 0, "")
+	pdf.CellFormat(50, 6, loc.T("metrics.coverage")+":", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 6, loc.Measure(data.Metrics.Coverage), "", 1, "L", false, 0, "")
 
-	pdf.CellFormat(50, 6, "Duplications:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(0, 6, data.Metrics.DuplicatedLinesDensity, "", 1, "L", false, 0, "")
+	pdf.CellFormat(50, 6, loc.T("metrics.duplications")+":", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 6, loc.Measure(data.Metrics.DuplicatedLinesDensity), "", 1, "L", false, 0, "")
 
-	pdf.CellFormat(50, 6, "Technical Debt:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(0, 6, data.Metrics.TechnicalDebt, "", 1, "L", false, 0, "")
+	pdf.CellFormat(50, 6, loc.T("metrics.technicalDebt")+":", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 6, loc.Measure(data.Metrics.TechnicalDebt), "", 1, "L", false, 0, "")
 
 	pdf.Ln(5)
 }
 
-func (g *PDFGenerator) renderIssues(pdf *gofpdf.Fpdf, data *ReportData) {
+func (g *PDFGenerator) renderIssues(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
 	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(0, 8, "Issues Analysis", "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 8, loc.T("issues.title"), "", 1, "L", false, 0, "")
 	pdf.Ln(3)
 
+	g.renderHistoricalNote(pdf, data, SectionIssues, loc.T("issues.reconstructed"))
+
 	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(0, 6, fmt.Sprintf("Total Issues: %d", data.TotalIssues), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, loc.T("issues.totalValue", loc.Int(data.TotalIssues)), "", 1, "L", false, 0, "")
+	if data.Suppressed != nil && len(data.Suppressed.Issues) > 0 {
+		pdf.SetFont("Arial", "I", 9)
+		pdf.CellFormat(0, 5, loc.T("issues.suppressedNote", loc.Int(len(data.Suppressed.Issues))), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+	}
 	pdf.Ln(3)
 
 	colW := []float64{50.0, 30.0, 30.0}
-	g.renderSimpleTable(pdf, []string{"Type", "Count", "%"}, []string{}, colW)
+	g.renderSimpleTable(pdf, []string{loc.T("col.type"), loc.T("col.count"), "%"}, []string{}, colW)
 
 	for issueType, count := range data.IssuesByType {
-		percentage := fmt.Sprintf("%.1f%%", float64(count)/float64(data.TotalIssues)*100)
-		row := []string{issueType, fmt.Sprintf("%d", count), percentage}
+		percentage := loc.Percent(float64(count) / float64(data.TotalIssues) * 100)
+		row := []string{loc.IssueType(issueType), loc.Int(count), percentage}
 		g.renderSimpleTable(pdf, []string{}, row, colW)
 	}
 
 	pdf.Ln(5)
+}
 
+// renderIssueDetails lists the first issues of each severity
+func (g *PDFGenerator) renderIssueDetails(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
 	severities := []string{"BLOCKER", "CRITICAL", "MAJOR", "MINOR", "INFO"}
 	for _, severity := range severities {
 		issues := []IssueItem{}
@@ -169,7 +497,7 @@ func (g *PDFGenerator) renderIssues(pdf *gofpdf.Fpdf, data *ReportData) {
 		}
 
 		pdf.SetFont("Arial", "B", 11)
-		pdf.CellFormat(0, 7, fmt.Sprintf("%s Issues (%d)", severity, len(issues)), "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 7, loc.T("details.title", loc.Severity(severity), loc.Int(len(issues))), "", 1, "L", false, 0, "")
 		pdf.Ln(2)
 
 		pdf.SetFont("Arial", "", 9)
@@ -181,11 +509,11 @@ func (g *PDFGenerator) renderIssues(pdf *gofpdf.Fpdf, data *ReportData) {
 			pdf.CellFormat(0, 5, fmt.Sprintf("%d. %s", idx+1, truncateStr(issue.Message, 80)), "", 1, "L", false, 0, "")
 			pdf.SetFont("Arial", "", 8)
 			pdf.CellFormat(5, 4, "", "", 0, "L", false, 0, "")
-			pdf.CellFormat(0, 4, fmt.Sprintf("File: %s | Line: %d | Rule: %s", truncateStr(issue.Component, 40), issue.Line, truncateStr(issue.Rule, 30)), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 4, loc.T("common.issueLocation", truncateStr(issue.Component, 40), issue.Line, truncateStr(issue.Rule, 30)), "", 1, "L", false, 0, "")
 
 			if issue.Effort != "" {
 				pdf.CellFormat(5, 4, "", "", 0, "L", false, 0, "")
-				pdf.CellFormat(0, 4, fmt.Sprintf("Effort: %s", issue.Effort), "", 1, "L", false, 0, "")
+				pdf.CellFormat(0, 4, loc.T("common.issueEffort", loc.EffortText(issue.Effort)), "", 1, "L", false, 0, "")
 			}
 
 			pdf.SetFont("Arial", "", 9)
@@ -198,27 +526,33 @@ func (g *PDFGenerator) renderIssues(pdf *gofpdf.Fpdf, data *ReportData) {
 	pdf.Ln(3)
 }
 
-func (g *PDFGenerator) renderHotspots(pdf *gofpdf.Fpdf, data *ReportData) {
+func (g *PDFGenerator) renderHotspots(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
 	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(0, 8, "Security Hotspots", "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 8, loc.T("hotspots.title"), "", 1, "L", false, 0, "")
 	pdf.Ln(3)
 
+	if data.Historical.IsUnavailable(SectionHotspots) {
+		g.renderHistoricalNote(pdf, data, SectionHotspots, loc.T("hotspots.unavailable"))
+		pdf.Ln(3)
+		return
+	}
+
 	if data.TotalHotspots == 0 {
 		pdf.SetFont("Arial", "", 10)
-		pdf.CellFormat(0, 6, "No Security Hotspots Found", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 6, loc.T("hotspots.none"), "", 1, "L", false, 0, "")
 		pdf.Ln(5)
 		return
 	}
 
 	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(0, 6, fmt.Sprintf("Total Hotspots: %d", data.TotalHotspots), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, loc.T("hotspots.totalValue", loc.Int(data.TotalHotspots)), "", 1, "L", false, 0, "")
 	pdf.Ln(3)
 
 	colW := []float64{40.0, 30.0}
-	g.renderSimpleTable(pdf, []string{"Priority", "Count"}, []string{}, colW)
+	g.renderSimpleTable(pdf, loc.columns("priority", "count"), []string{}, colW)
 
 	for priority, count := range data.HotspotsByPriority {
-		row := []string{priority, fmt.Sprintf("%d", count)}
+		row := []string{loc.Priority(priority), loc.Int(count)}
 		g.renderSimpleTable(pdf, []string{}, row, colW)
 	}
 
@@ -226,11 +560,11 @@ func (g *PDFGenerator) renderHotspots(pdf *gofpdf.Fpdf, data *ReportData) {
 
 	if len(data.Hotspots) > 0 {
 		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(0, 6, "Details:", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 6, loc.T("hotspots.detailsShort"), "", 1, "L", false, 0, "")
 		pdf.Ln(2)
 
 		colW := []float64{10.0, 30.0, 40.0, 40.0}
-		g.renderSimpleTable(pdf, []string{"#", "Priority", "Category", "Location"}, []string{}, colW)
+		g.renderSimpleTable(pdf, loc.columns("number", "priority", "category", "location"), []string{}, colW)
 
 		for idx, hotspot := range data.Hotspots {
 			if idx >= 10 {
@@ -238,7 +572,7 @@ func (g *PDFGenerator) renderHotspots(pdf *gofpdf.Fpdf, data *ReportData) {
 			}
 			row := []string{
 				fmt.Sprintf("%d", idx+1),
-				hotspot.VulnerabilityProbability,
+				loc.Priority(hotspot.VulnerabilityProbability),
 				hotspot.SecurityCategory,
 				fmt.Sprintf("%s:%d", truncateStr(hotspot.Component, 30), hotspot.Line),
 			}
@@ -249,22 +583,22 @@ func (g *PDFGenerator) renderHotspots(pdf *gofpdf.Fpdf, data *ReportData) {
 	pdf.Ln(5)
 }
 
-func (g *PDFGenerator) renderSummary(pdf *gofpdf.Fpdf, data *ReportData) {
+func (g *PDFGenerator) renderSummary(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
 	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(0, 8, "Summary", "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 8, loc.T("summary.title"), "", 1, "L", false, 0, "")
 	pdf.Ln(3)
 
-	status := QualityGateText(data.QualityGateStatus)
+	status := loc.QualityGate(data.QualityGateStatus)
 
 	colW := []float64{40.0, 60.0}
 	if data.QualityGateStatus == "OK" {
-		g.renderSimpleTable(pdf, []string{"Quality Gate", status}, []string{}, colW)
-		g.renderSimpleTable(pdf, []string{}, []string{"Bugs", data.Metrics.Bugs}, colW)
-		g.renderSimpleTable(pdf, []string{}, []string{"Vulnerabilities", data.Metrics.Vulnerabilities}, colW)
-		g.renderSimpleTable(pdf, []string{}, []string{"Code Smells", data.Metrics.CodeSmells}, colW)
+		g.renderSimpleTable(pdf, []string{loc.T("qualityGate.title"), status}, []string{}, colW)
+		g.renderSimpleTable(pdf, []string{}, []string{loc.T("metrics.bugs"), loc.Measure(data.Metrics.Bugs)}, colW)
+		g.renderSimpleTable(pdf, []string{}, []string{loc.T("metrics.vulnerabilities"), loc.Measure(data.Metrics.Vulnerabilities)}, colW)
+		g.renderSimpleTable(pdf, []string{}, []string{loc.T("metrics.codeSmells"), loc.Measure(data.Metrics.CodeSmells)}, colW)
 	} else {
-		g.renderSimpleTable(pdf, []string{}, []string{"Action Required", ""}, colW)
-		g.renderSimpleTable(pdf, []string{}, []string{"Quality Gate", status}, colW)
+		g.renderSimpleTable(pdf, []string{}, []string{loc.T("summary.actionRequired"), ""}, colW)
+		g.renderSimpleTable(pdf, []string{}, []string{loc.T("qualityGate.title"), status}, colW)
 	}
 
 	pdf.Ln(5)
@@ -273,8 +607,8 @@ func (g *PDFGenerator) renderSummary(pdf *gofpdf.Fpdf, data *ReportData) {
 	pdf.Ln(3)
 
 	pdf.SetFont("Arial", "I", 8)
-	pdf.CellFormat(0, 5, "Report generated by SonarQube Report Generator", "", 1, "C", false, 0, "")
-	pdf.CellFormat(0, 5, formatTimeSimple(data.GeneratedAt), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 5, loc.T("report.generatedBy", "SonarQube Report Generator"), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 5, loc.Time(data.GeneratedAt), "", 1, "C", false, 0, "")
 }
 
 func (g *PDFGenerator) renderSimpleTable(pdf *gofpdf.Fpdf, headers []string, row []string, colWidths []float64) {
@@ -307,11 +641,43 @@ func truncateStr(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
-func formatTimeSimple(t interface{}) string {
-	switch v := t.(type) {
-	case string:
-		return v
-	default:
-		return fmt.Sprintf("%v", t)
+func (g *PDFGenerator) renderSuppressed(pdf *gofpdf.Fpdf, data *ReportData, loc *Locale) {
+	if data.Suppressed == nil || (len(data.Suppressed.Issues) == 0 && len(data.Suppressed.Expired) == 0) {
+		return
+	}
+
+	pdf.Ln(5)
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("suppressed.title"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	if len(data.Suppressed.Issues) > 0 {
+		pdf.SetFont("Arial", "", 10)
+		pdf.MultiCell(0, 6, loc.T("suppressed.intro", loc.Int(len(data.Suppressed.Issues))), "", "L", false)
+		pdf.Ln(2)
+
+		colW := []float64{20.0, 55.0, 12.0, 50.0, 25.0, 18.0}
+		g.renderSimpleTable(pdf, loc.columns("severity", "file", "line", "message", "reason", "expires"), []string{}, colW)
+		for _, issue := range data.Suppressed.Issues {
+			row := []string{
+				loc.Severity(issue.Severity),
+				truncateStr(issue.Component, 32),
+				fmt.Sprintf("%d", issue.Line),
+				truncateStr(issue.Message, 30),
+				truncateStr(issue.Reason, 14),
+				issue.Expires,
+			}
+			g.renderSimpleTable(pdf, []string{}, row, colW)
+		}
+		pdf.Ln(3)
+	}
+
+	if len(data.Suppressed.Expired) > 0 {
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(0, 6, loc.T("suppressed.expiredTitle")+" "+loc.T("suppressed.expiredNote"), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 9)
+		for _, s := range data.Suppressed.Expired {
+			pdf.MultiCell(0, 5, fmt.Sprintf("- %s: %s ", loc.SuppressionTarget(s), s.Reason)+loc.T("suppressed.expired", s.Expires), "", "L", false)
+		}
 	}
 }
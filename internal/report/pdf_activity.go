@@ -0,0 +1,87 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// generateActivity generates a PDF activity report
+func (g *PDFGenerator) generateActivity(data *ReportData) ([]byte, error) {
+	a := data.Activity
+	loc := NewLocale(data.Language, data.Timezone)
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AddPage()
+
+	pdf.SetFont("Arial", "B", 16)
+	pdf.CellFormat(0, 10, loc.T("activity.title"), "", 1, "C", false, 0, "")
+	pdf.Ln(3)
+	pdf.Line(15, pdf.GetY(), 195, pdf.GetY())
+	pdf.Ln(5)
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("info.title"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	pdf.SetFont("Arial", "", 10)
+	info := [][2]string{
+		{loc.T("info.projectName"), data.ProjectName},
+		{loc.T("info.projectKey"), data.ProjectKey},
+		{loc.T("info.branch"), data.Branch},
+		{loc.T("info.period"), loc.T("info.periodValue", loc.Time(a.From), loc.Time(a.To))},
+		{loc.T("info.generated"), loc.Time(data.GeneratedAt)},
+	}
+	if !data.AnalysisDate.IsZero() {
+		info = append(info, [2]string{loc.T("info.lastAnalysisInPeriod"), loc.DateTime(data.AnalysisDate)})
+	}
+	if data.Redactions != nil {
+		info = append(info, [2]string{loc.T("info.redacted"), redactionText(data.Redactions, loc)})
+	}
+	g.renderInfoRows(pdf, info)
+	pdf.Ln(5)
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("activity.summary"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+	if a.Truncated {
+		pdf.SetFont("Arial", "I", 9)
+		pdf.MultiCell(0, 5, loc.T("activity.truncated"), "", "L", false)
+		pdf.Ln(3)
+	}
+
+	colW := []float64{70.0, 40.0}
+	g.renderSimpleTable(pdf, loc.columns("activity", "issues"), []string{}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{loc.T("activity.opened"), loc.T("activity.stillOpen", loc.Int(len(a.Opened)), loc.Int(a.StillOpen))}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{loc.T("activity.fixed"), loc.Int(len(a.Fixed))}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{loc.T("activity.falsePositive"), loc.Int(len(a.FalsePositive))}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{loc.T("activity.wontFix"), loc.Int(len(a.WontFix))}, colW)
+	pdf.Ln(3)
+
+	g.renderSimpleTable(pdf, []string{loc.T("activity.debt"), loc.T("col.effort")}, []string{}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{loc.T("activity.debtAdded"), loc.DebtChange(a.DebtAdded, data.WorkingDayHours)}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{loc.T("activity.debtRemoved"), loc.DebtChange(-a.DebtRemoved, data.WorkingDayHours)}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{loc.T("activity.debtNet"), loc.DebtChange(a.NetDebtChange(), data.WorkingDayHours)}, colW)
+	pdf.Ln(5)
+
+	g.renderDiffIssueList(pdf, loc, loc.T("activity.openedList"), a.Opened)
+	g.renderDiffIssueList(pdf, loc, loc.T("activity.fixedList"), a.Fixed)
+	g.renderDiffIssueList(pdf, loc, loc.T("activity.falsePositive"), a.FalsePositive)
+	g.renderDiffIssueList(pdf, loc, loc.T("activity.wontFix"), a.WontFix)
+
+	pdf.Line(15, pdf.GetY(), 195, pdf.GetY())
+	pdf.Ln(3)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.CellFormat(0, 5, loc.T("report.generatedBy", "SonarQube Report Generator"), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 5, loc.Time(data.GeneratedAt), "", 1, "C", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
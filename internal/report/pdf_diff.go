@@ -0,0 +1,200 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// GenerateDiff generates a PDF delta report for a comparison of two reports
+func (g *PDFGenerator) GenerateDiff(diff *ReportDiff) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AddPage()
+
+	loc := NewLocale(diff.Language, diff.Timezone)
+	title := loc.T("diff.titleReports")
+	if diff.Kind == DiffKindBranches {
+		title = loc.T("diff.titleBranches")
+	}
+
+	pdf.SetFont("Arial", "B", 16)
+	pdf.CellFormat(0, 10, title, "", 1, "C", false, 0, "")
+	pdf.Ln(3)
+	pdf.Line(15, pdf.GetY(), 195, pdf.GetY())
+	pdf.Ln(5)
+
+	g.renderDiffSides(pdf, diff, loc)
+	g.renderDiffQualityGate(pdf, diff, loc)
+	g.renderDiffMetrics(pdf, diff, loc)
+	g.renderDiffIssues(pdf, diff, loc)
+
+	pdf.Line(15, pdf.GetY(), 195, pdf.GetY())
+	pdf.Ln(3)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.CellFormat(0, 5, loc.T("report.generatedBy", "SonarQube Report Generator"), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 5, loc.Time(diff.GeneratedAt), "", 1, "C", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (g *PDFGenerator) renderDiffSides(pdf *gofpdf.Fpdf, diff *ReportDiff, loc *Locale) {
+	pdf.SetFont("Arial", "B", 12)
+	if diff.Kind == DiffKindBranches {
+		pdf.CellFormat(0, 8, loc.T("diff.comparedBranches"), "", 1, "L", false, 0, "")
+	} else {
+		pdf.CellFormat(0, 8, loc.T("diff.comparedReports"), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(3)
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(45, 6, loc.T("info.project")+":", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 6, diff.ProjectName+" ("+diff.ProjectKey+")", "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	colW := []float64{45.0, 65.0, 65.0}
+	g.renderSimpleTable(pdf, []string{"", loc.T("col.base"), loc.T("col.head")}, []string{}, colW)
+	if diff.Base.ReportID != "" {
+		g.renderSimpleTable(pdf, []string{}, []string{loc.T("col.report"), diff.Base.ReportID, diff.Head.ReportID}, colW)
+	}
+	g.renderSimpleTable(pdf, []string{}, []string{loc.T("info.branch"), diff.Base.Branch, diff.Head.Branch}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{loc.T("col.generated"), loc.Time(diff.Base.GeneratedAt), loc.Time(diff.Head.GeneratedAt)}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{loc.T("info.lastAnalysis"), orDash(loc.DateTime(diff.Base.AnalysisDate)), orDash(loc.DateTime(diff.Head.AnalysisDate))}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{loc.T("issues.total"), loc.Int(diff.Base.TotalIssues), loc.Int(diff.Head.TotalIssues)}, colW)
+
+	pdf.Ln(5)
+}
+
+func (g *PDFGenerator) renderDiffQualityGate(pdf *gofpdf.Fpdf, diff *ReportDiff, loc *Locale) {
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("qualityGate.title"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	pdf.SetFont("Arial", "B", 11)
+	if diff.Kind == DiffKindBranches {
+		pdf.CellFormat(0, 6, loc.T("col.base")+": "+loc.QualityGate(diff.QualityGate.From)+"    "+loc.T("col.head")+": "+loc.QualityGate(diff.QualityGate.To), "", 1, "L", false, 0, "")
+	} else if diff.QualityGate.Changed {
+		pdf.CellFormat(0, 6, loc.QualityGate(diff.QualityGate.From)+" -> "+loc.QualityGate(diff.QualityGate.To), "", 1, "L", false, 0, "")
+	} else {
+		pdf.CellFormat(0, 6, loc.T("qualityGate.unchanged", loc.QualityGate(diff.QualityGate.To)), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(3)
+
+	if len(diff.ConditionChanges) > 0 {
+		colW := []float64{60.0, 55.0, 55.0}
+		g.renderSimpleTable(pdf, loc.columns("metric", "status", "value"), []string{}, colW)
+		for _, c := range diff.ConditionChanges {
+			row := []string{
+				c.Metric,
+				orDash(c.FromStatus) + " -> " + orDash(c.ToStatus),
+				orDash(loc.Number(c.FromValue)) + " -> " + orDash(loc.Number(c.ToValue)),
+			}
+			g.renderSimpleTable(pdf, []string{}, row, colW)
+		}
+	}
+
+	pdf.Ln(5)
+}
+
+func (g *PDFGenerator) renderDiffMetrics(pdf *gofpdf.Fpdf, diff *ReportDiff, loc *Locale) {
+	pdf.SetFont("Arial", "B", 12)
+	if diff.Kind == DiffKindBranches {
+		pdf.CellFormat(0, 8, loc.T("diff.ratingsAndMetrics"), "", 1, "L", false, 0, "")
+	} else {
+		pdf.CellFormat(0, 8, loc.T("diff.metricDeltas"), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(3)
+
+	colW := []float64{50.0, 35.0, 35.0, 30.0, 30.0}
+	g.renderSimpleTable(pdf, loc.columns("metric", "base", "head", "change", "trend"), []string{}, colW)
+	for _, d := range diff.MetricDeltas {
+		change := loc.Measure(d.Delta)
+		if change == "" && d.From != d.To {
+			change = loc.T("diff.changed")
+		}
+		trend := ""
+		if d.Trend != "" {
+			trend = loc.Trend(d.Trend)
+		}
+		g.renderSimpleTable(pdf, []string{}, []string{loc.MetricName(d.Metric), orDash(loc.Measure(d.From)), orDash(loc.Measure(d.To)), orDash(change), orDash(trend)}, colW)
+	}
+
+	pdf.Ln(5)
+}
+
+func (g *PDFGenerator) renderDiffIssues(pdf *gofpdf.Fpdf, diff *ReportDiff, loc *Locale) {
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, loc.T("diff.issueChanges"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	newTitle, fixedTitle, changedTitle, unchangedTitle := loc.T("diff.newIssues"), loc.T("diff.fixedIssues"), loc.T("diff.severityChanges"), loc.T("diff.unchanged")
+	if diff.Kind == DiffKindBranches {
+		newTitle = loc.T("diff.onlyIn", diff.Head.Branch)
+		fixedTitle = loc.T("diff.onlyIn", diff.Base.Branch)
+		changedTitle = loc.T("diff.differentSeverity")
+		unchangedTitle = loc.T("diff.inBoth")
+	}
+
+	colW := []float64{60.0, 30.0}
+	g.renderSimpleTable(pdf, loc.columns("change", "count"), []string{}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{newTitle, loc.Int(len(diff.NewIssues))}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{fixedTitle, loc.Int(len(diff.FixedIssues))}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{changedTitle, loc.Int(len(diff.SeverityChanges))}, colW)
+	g.renderSimpleTable(pdf, []string{}, []string{unchangedTitle, loc.Int(diff.UnchangedIssues)}, colW)
+	pdf.Ln(5)
+
+	g.renderDiffIssueList(pdf, loc, newTitle, diff.NewIssues)
+	g.renderDiffIssueList(pdf, loc, fixedTitle, diff.FixedIssues)
+
+	if len(diff.SeverityChanges) > 0 {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.CellFormat(0, 7, fmt.Sprintf("%s (%s)", changedTitle, loc.Int(len(diff.SeverityChanges))), "", 1, "L", false, 0, "")
+		pdf.Ln(2)
+
+		for idx, change := range diff.SeverityChanges {
+			if idx >= 25 {
+				break
+			}
+			pdf.SetFont("Arial", "", 9)
+			pdf.CellFormat(0, 5, fmt.Sprintf("%d. %s -> %s: %s", idx+1, loc.Severity(change.FromSeverity), loc.Severity(change.ToSeverity), truncateStr(change.Issue.Message, 70)), "", 1, "L", false, 0, "")
+			pdf.SetFont("Arial", "", 8)
+			pdf.CellFormat(5, 4, "", "", 0, "L", false, 0, "")
+			pdf.CellFormat(0, 4, loc.T("common.changeLocation", truncateStr(change.Issue.Component, 60), change.Issue.Line), "", 1, "L", false, 0, "")
+			pdf.Ln(1)
+		}
+		pdf.Ln(3)
+	}
+}
+
+func (g *PDFGenerator) renderDiffIssueList(pdf *gofpdf.Fpdf, loc *Locale, title string, issues []IssueItem) {
+	if len(issues) == 0 {
+		return
+	}
+
+	pdf.SetFont("Arial", "B", 11)
+	pdf.CellFormat(0, 7, fmt.Sprintf("%s (%s)", title, loc.Int(len(issues))), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	for idx, issue := range issues {
+		if idx >= 25 {
+			pdf.SetFont("Arial", "I", 8)
+			pdf.CellFormat(0, 5, loc.T("common.showingShort", loc.Int(len(issues))), "", 1, "L", false, 0, "")
+			break
+		}
+		pdf.SetFont("Arial", "", 9)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%d. [%s] %s", idx+1, loc.Severity(issue.Severity), truncateStr(issue.Message, 75)), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 8)
+		pdf.CellFormat(5, 4, "", "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 4, loc.T("common.issueLocation", truncateStr(issue.Component, 40), issue.Line, truncateStr(issue.Rule, 30)), "", 1, "L", false, 0, "")
+		pdf.Ln(1)
+	}
+
+	pdf.Ln(3)
+}
@@ -0,0 +1,413 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// Policy rule types
+const (
+	PolicyRuleMetric      = "metric"       // a metric compared against a value
+	PolicyRuleIssues      = "issues"       // at most Max issues matching the filters
+	PolicyRuleQualityGate = "quality_gate" // the SonarQube gate status is allowed
+)
+
+// Policy rule outcomes. A rule that cannot be checked, e.g. because the
+// metric has no value, fails: a policy only passes when every rule is met.
+const (
+	PolicyPassed = "passed"
+	PolicyFailed = "failed"
+)
+
+// PolicySet is the set of policies loaded from a policy file
+type PolicySet struct {
+	Policies []Policy `json:"policies"`
+}
+
+// Policy is a named set of compliance rules evaluated against report data
+type Policy struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Projects are the project key patterns (path.Match syntax) the policy
+	// applies to when a request does not name one
+	Projects []string `json:"projects,omitempty"`
+
+	Rules []PolicyRule `json:"rules"`
+}
+
+// PolicyRule is a single compliance rule
+type PolicyRule struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// Metric rules: Metric Operator Value, e.g. new_coverage >= 85. Ratings
+	// may be given as letters.
+	Metric   string      `json:"metric,omitempty"`
+	Operator string      `json:"operator,omitempty"` // <, <=, >, >=, ==, !=
+	Value    PolicyValue `json:"value,omitempty"`
+
+	// Issue rules: at most Max issues matching all given filters
+	Severities    []string `json:"severities,omitempty"`
+	Types         []string `json:"types,omitempty"`
+	Rules         []string `json:"rules,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	OlderThanDays int      `json:"olderThanDays,omitempty"`
+	Max           int      `json:"max,omitempty"`
+
+	// Quality gate rules: allowed gate statuses (default: OK)
+	Allow []string `json:"allow,omitempty"`
+}
+
+// PolicyValue is a rule value written as a JSON number or string
+type PolicyValue string
+
+// UnmarshalJSON accepts numbers as well as strings
+func (v *PolicyValue) UnmarshalJSON(raw []byte) error {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		*v = PolicyValue(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return fmt.Errorf("value must be a number or string")
+	}
+	*v = PolicyValue(n.String())
+	return nil
+}
+
+// PolicyResult is the verdict of a policy evaluated against a report
+type PolicyResult struct {
+	Policy      string             `json:"policy"`
+	Description string             `json:"description,omitempty"`
+	Passed      bool               `json:"passed"`
+	EvaluatedAt time.Time          `json:"evaluatedAt"`
+	Rules       []PolicyRuleResult `json:"rules"`
+}
+
+// PolicyRuleResult is the outcome of one rule and the values it was decided
+// on. Renderers explain it in the language of the report.
+type PolicyRuleResult struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+
+	// Metric rules: Metric Operator Threshold, checked against the value of
+	// the metric in the report. Actual is empty when the metric has none.
+	Metric    string `json:"metric,omitempty"`
+	Operator  string `json:"operator,omitempty"`
+	Threshold string `json:"threshold,omitempty"`
+	Actual    string `json:"actual,omitempty"`
+
+	// Issue rules: Found issues matching the filters among the Checked ones,
+	// at most Max allowed. Incomplete is set when the rule failed only
+	// because the other open issues could not be checked.
+	Severities    []string `json:"severities,omitempty"`
+	Types         []string `json:"types,omitempty"`
+	Rules         []string `json:"rules,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	OlderThanDays int      `json:"olderThanDays,omitempty"`
+	Found         int      `json:"found,omitempty"`
+	Checked       int      `json:"checked,omitempty"`
+	Max           int      `json:"max,omitempty"`
+	Incomplete    bool     `json:"incomplete,omitempty"`
+	Issues        []string `json:"issues,omitempty"` // keys of offending issues
+
+	// Quality gate rules: the gate status and the statuses allowed
+	GateStatus string   `json:"gateStatus,omitempty"`
+	Allow      []string `json:"allow,omitempty"`
+}
+
+// Failed counts the failed rules
+func (r *PolicyResult) Failed() int {
+	count := 0
+	for _, rule := range r.Rules {
+		if rule.Status == PolicyFailed {
+			count++
+		}
+	}
+	return count
+}
+
+// policyMetrics maps the metric keys usable in metric rules to report values
+var policyMetrics = map[string]func(MetricsSummary) string{
+	"bugs":                         func(m MetricsSummary) string { return m.Bugs },
+	"vulnerabilities":              func(m MetricsSummary) string { return m.Vulnerabilities },
+	"code_smells":                  func(m MetricsSummary) string { return m.CodeSmells },
+	"coverage":                     func(m MetricsSummary) string { return m.Coverage },
+	"duplicated_lines_density":     func(m MetricsSummary) string { return m.DuplicatedLinesDensity },
+	"ncloc":                        func(m MetricsSummary) string { return m.LinesOfCode },
+	"reliability_rating":           func(m MetricsSummary) string { return m.ReliabilityRating },
+	"security_rating":              func(m MetricsSummary) string { return m.SecurityRating },
+	"sqale_rating":                 func(m MetricsSummary) string { return m.MaintainabilityRating },
+	"new_bugs":                     func(m MetricsSummary) string { return m.NewBugs },
+	"new_vulnerabilities":          func(m MetricsSummary) string { return m.NewVulnerabilities },
+	"new_code_smells":              func(m MetricsSummary) string { return m.NewCodeSmells },
+	"new_coverage":                 func(m MetricsSummary) string { return m.NewCoverage },
+	"new_duplicated_lines_density": func(m MetricsSummary) string { return m.NewDuplicatedLines },
+}
+
+// LoadPolicies reads a policy file. An empty path yields an empty set.
+func LoadPolicies(file string) (*PolicySet, error) {
+	set := &PolicySet{}
+	if file == "" {
+		return set, nil
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	if err := json.Unmarshal(raw, set); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+	if err := set.validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file: %w", err)
+	}
+
+	return set, nil
+}
+
+func (s *PolicySet) validate() error {
+	names := make(map[string]bool)
+	for _, p := range s.Policies {
+		if p.Name == "" {
+			return fmt.Errorf("policy without name")
+		}
+		if names[p.Name] {
+			return fmt.Errorf("duplicate policy %q", p.Name)
+		}
+		names[p.Name] = true
+
+		for _, pattern := range p.Projects {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("policy %q: invalid project pattern %q", p.Name, pattern)
+			}
+		}
+		for i, r := range p.Rules {
+			if err := r.validate(); err != nil {
+				return fmt.Errorf("policy %q rule %d: %w", p.Name, i+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r PolicyRule) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	switch r.Type {
+	case PolicyRuleMetric:
+		if _, ok := policyMetrics[r.Metric]; !ok {
+			return fmt.Errorf("unsupported metric %q", r.Metric)
+		}
+		if _, ok := compareOperators[r.Operator]; !ok {
+			return fmt.Errorf("unsupported operator %q", r.Operator)
+		}
+		if _, err := policyNumber(string(r.Value)); err != nil {
+			return fmt.Errorf("value %q is not a number or rating", r.Value)
+		}
+	case PolicyRuleIssues:
+		if r.Max < 0 || r.OlderThanDays < 0 {
+			return fmt.Errorf("max and olderThanDays must not be negative")
+		}
+	case PolicyRuleQualityGate:
+	default:
+		return fmt.Errorf("unsupported type %q", r.Type)
+	}
+	return nil
+}
+
+// Find returns the policy with the given name
+func (s *PolicySet) Find(name string) (*Policy, bool) {
+	for i := range s.Policies {
+		if s.Policies[i].Name == name {
+			return &s.Policies[i], true
+		}
+	}
+	return nil, false
+}
+
+// ForProject returns the first policy whose project patterns match the key
+func (s *PolicySet) ForProject(projectKey string) (*Policy, bool) {
+	for i := range s.Policies {
+		for _, pattern := range s.Policies[i].Projects {
+			if ok, _ := path.Match(pattern, projectKey); ok {
+				return &s.Policies[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Evaluate checks stored report data against the policy. Issue rules count
+// the issues listed in the report; when the report does not list all open
+// issues of the project, e.g. because it is filtered, they can only fail.
+func (p *Policy) Evaluate(data *ReportData) *PolicyResult {
+	now := data.GeneratedAt
+	if data.Historical != nil {
+		now = data.Historical.AsOf
+	}
+
+	var issues []IssueItem
+	for _, sev := range GetSortedSeverities(data.IssuesBySeverity) {
+		issues = append(issues, data.IssuesBySeverity[sev]...)
+	}
+	complete := len(issues) >= data.TotalIssues && data.Team == "" &&
+		len(data.Severities) == 0 && len(data.Types) == 0 &&
+		(data.Suppressed == nil || len(data.Suppressed.Issues) == 0)
+
+	return p.evaluate(data, issues, complete, now)
+}
+
+// evaluate checks report data against the policy, counting the given open
+// issues of the project in issue rules. complete tells whether these are all
+// of them. Issue ages are measured at now, the point in time the report
+// describes.
+func (p *Policy) evaluate(data *ReportData, issues []IssueItem, complete bool, now time.Time) *PolicyResult {
+	result := &PolicyResult{
+		Policy:      p.Name,
+		Description: p.Description,
+		Passed:      true,
+		EvaluatedAt: time.Now(),
+	}
+	for _, rule := range p.Rules {
+		var r PolicyRuleResult
+		switch rule.Type {
+		case PolicyRuleMetric:
+			r = rule.evaluateMetric(data)
+		case PolicyRuleIssues:
+			r = rule.evaluateIssues(issues, complete, now)
+		case PolicyRuleQualityGate:
+			r = rule.evaluateQualityGate(data)
+		}
+		r.Name = rule.Name
+		r.Type = rule.Type
+		if r.Status == PolicyFailed {
+			result.Passed = false
+		}
+		result.Rules = append(result.Rules, r)
+	}
+	return result
+}
+
+var compareOperators = map[string]func(a, b float64) bool{
+	"<":  func(a, b float64) bool { return a < b },
+	"<=": func(a, b float64) bool { return a <= b },
+	">":  func(a, b float64) bool { return a > b },
+	">=": func(a, b float64) bool { return a >= b },
+	"==": func(a, b float64) bool { return a == b },
+	"!=": func(a, b float64) bool { return a != b },
+}
+
+func (r PolicyRule) evaluateMetric(data *ReportData) PolicyRuleResult {
+	result := PolicyRuleResult{
+		Status:    PolicyFailed,
+		Metric:    r.Metric,
+		Operator:  r.Operator,
+		Threshold: string(r.Value),
+		Actual:    policyMetrics[r.Metric](data.Metrics),
+	}
+
+	a, err := policyNumber(result.Actual)
+	if err != nil {
+		return result
+	}
+	b, _ := policyNumber(string(r.Value))
+	if compareOperators[r.Operator](a, b) {
+		result.Status = PolicyPassed
+	}
+	return result
+}
+
+// policyNumber parses metric values, percentages and rating letters (A=1 .. E=5)
+func policyNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 1 && s >= "A" && s <= "E" {
+		return float64(s[0]-'A') + 1, nil
+	}
+	return parseMetricNumber(s)
+}
+
+func (r PolicyRule) evaluateIssues(issues []IssueItem, complete bool, now time.Time) PolicyRuleResult {
+	var keys []string
+	for _, issue := range issues {
+		if r.matchesIssue(issue, now) {
+			keys = append(keys, issue.Key)
+		}
+	}
+
+	result := PolicyRuleResult{
+		Status:        PolicyPassed,
+		Severities:    r.Severities,
+		Types:         r.Types,
+		Rules:         r.Rules,
+		Tags:          r.Tags,
+		OlderThanDays: r.OlderThanDays,
+		Found:         len(keys),
+		Checked:       len(issues),
+		Max:           r.Max,
+	}
+	switch {
+	case len(keys) > r.Max:
+		result.Status = PolicyFailed
+		result.Issues = keys
+	case !complete:
+		result.Status = PolicyFailed
+		result.Incomplete = true
+	}
+	return result
+}
+
+func (r PolicyRule) matchesIssue(issue IssueItem, now time.Time) bool {
+	if len(r.Severities) > 0 && !containsString(r.Severities, issue.Severity) {
+		return false
+	}
+	if len(r.Types) > 0 && !containsString(r.Types, issue.Type) {
+		return false
+	}
+	if len(r.Rules) > 0 && !containsString(r.Rules, issue.Rule) {
+		return false
+	}
+	if len(r.Tags) > 0 {
+		tagged := false
+		for _, tag := range issue.Tags {
+			if containsString(r.Tags, tag) {
+				tagged = true
+				break
+			}
+		}
+		if !tagged {
+			return false
+		}
+	}
+	if r.OlderThanDays > 0 {
+		if issue.CreationDate.IsZero() || now.Sub(issue.CreationDate.Time) <= time.Duration(r.OlderThanDays)*24*time.Hour {
+			return false
+		}
+	}
+	return true
+}
+
+func (r PolicyRule) evaluateQualityGate(data *ReportData) PolicyRuleResult {
+	allow := r.Allow
+	if len(allow) == 0 {
+		allow = []string{"OK"}
+	}
+
+	status := PolicyFailed
+	if containsString(allow, data.QualityGateStatus) {
+		status = PolicyPassed
+	}
+	return PolicyRuleResult{
+		Status:     status,
+		GateStatus: data.QualityGateStatus,
+		Allow:      allow,
+	}
+}
@@ -0,0 +1,361 @@
+package report
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+func TestPolicyNumber(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{in: "85", want: 85},
+		{in: "85.5%", want: 85.5},
+		{in: " 12 ", want: 12},
+		{in: "A", want: 1},
+		{in: "C", want: 3},
+		{in: "E", want: 5},
+		{in: "F", wantErr: true},
+		{in: "a", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "3d 1h", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := policyNumber(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("policyNumber(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("policyNumber(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyValueUnmarshal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    PolicyValue
+		wantErr bool
+	}{
+		{in: `85`, want: "85"},
+		{in: `85.5`, want: "85.5"},
+		{in: `"B"`, want: "B"},
+		{in: `true`, wantErr: true},
+		{in: `[1]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var v PolicyValue
+		err := json.Unmarshal([]byte(tt.in), &v)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("unmarshal %s error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && v != tt.want {
+			t.Errorf("unmarshal %s = %q, want %q", tt.in, v, tt.want)
+		}
+	}
+}
+
+func TestPolicySetValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			json: `{"policies": [{"name": "p", "projects": ["pay-*"], "rules": [
+				{"name": "cov", "type": "metric", "metric": "new_coverage", "operator": ">=", "value": 85},
+				{"name": "rating", "type": "metric", "metric": "security_rating", "operator": "<=", "value": "A"},
+				{"name": "blockers", "type": "issues", "severities": ["BLOCKER"], "olderThanDays": 7},
+				{"name": "gate", "type": "quality_gate"}]}]}`,
+		},
+		{name: "no name", json: `{"policies": [{"rules": []}]}`, wantErr: "policy without name"},
+		{name: "duplicate", json: `{"policies": [{"name": "p"}, {"name": "p"}]}`, wantErr: "duplicate policy"},
+		{name: "bad pattern", json: `{"policies": [{"name": "p", "projects": ["["]}]}`, wantErr: "invalid project pattern"},
+		{name: "rule without name", json: `{"policies": [{"name": "p", "rules": [{"type": "quality_gate"}]}]}`, wantErr: "name is required"},
+		{name: "unknown type", json: `{"policies": [{"name": "p", "rules": [{"name": "r", "type": "x"}]}]}`, wantErr: "unsupported type"},
+		{name: "unknown metric", json: `{"policies": [{"name": "p", "rules": [{"name": "r", "type": "metric", "metric": "x", "operator": ">", "value": 1}]}]}`, wantErr: "unsupported metric"},
+		{name: "unknown operator", json: `{"policies": [{"name": "p", "rules": [{"name": "r", "type": "metric", "metric": "bugs", "operator": "=>", "value": 1}]}]}`, wantErr: "unsupported operator"},
+		{name: "bad value", json: `{"policies": [{"name": "p", "rules": [{"name": "r", "type": "metric", "metric": "bugs", "operator": ">", "value": "many"}]}]}`, wantErr: "not a number or rating"},
+		{name: "negative max", json: `{"policies": [{"name": "p", "rules": [{"name": "r", "type": "issues", "max": -1}]}]}`, wantErr: "must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var set PolicySet
+			if err := json.Unmarshal([]byte(tt.json), &set); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			err := set.validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validate() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) sonarqube.DateTime {
+		return sonarqube.DateTime{Time: now.AddDate(0, 0, -days)}
+	}
+	data := &ReportData{
+		QualityGateStatus: "OK",
+		Metrics: MetricsSummary{
+			Coverage:       "80.0%",
+			NewCoverage:    "90.5%",
+			SecurityRating: "B",
+		},
+	}
+	issues := []IssueItem{
+		{Key: "old-blocker", Severity: "BLOCKER", Type: "BUG", CreationDate: daysAgo(30)},
+		{Key: "new-blocker", Severity: "BLOCKER", Type: "BUG", CreationDate: daysAgo(2)},
+		{Key: "vuln", Severity: "MAJOR", Type: "VULNERABILITY", Tags: []string{"owasp-a3"}, Rule: "java:S2077"},
+	}
+
+	tests := []struct {
+		name     string
+		rule     PolicyRule
+		issues   []IssueItem
+		complete bool
+		want     string
+	}{
+		{name: "metric passes", rule: PolicyRule{Type: PolicyRuleMetric, Metric: "new_coverage", Operator: ">=", Value: "85"}, want: PolicyPassed},
+		{name: "metric fails", rule: PolicyRule{Type: PolicyRuleMetric, Metric: "coverage", Operator: ">=", Value: "85"}, want: PolicyFailed},
+		{name: "rating as letter", rule: PolicyRule{Type: PolicyRuleMetric, Metric: "security_rating", Operator: "<=", Value: "A"}, want: PolicyFailed},
+		{name: "rating as number", rule: PolicyRule{Type: PolicyRuleMetric, Metric: "security_rating", Operator: "<=", Value: "2"}, want: PolicyPassed},
+		{name: "metric without value fails", rule: PolicyRule{Type: PolicyRuleMetric, Metric: "new_bugs", Operator: "==", Value: "0"}, want: PolicyFailed},
+		{name: "old blockers", rule: PolicyRule{Type: PolicyRuleIssues, Severities: []string{"BLOCKER"}, OlderThanDays: 7}, issues: issues, complete: true, want: PolicyFailed},
+		{name: "blockers within max", rule: PolicyRule{Type: PolicyRuleIssues, Severities: []string{"BLOCKER"}, Max: 2}, issues: issues, complete: true, want: PolicyPassed},
+		{name: "tagged", rule: PolicyRule{Type: PolicyRuleIssues, Types: []string{"VULNERABILITY"}, Tags: []string{"owasp-a3"}}, issues: issues, complete: true, want: PolicyFailed},
+		{name: "other rule", rule: PolicyRule{Type: PolicyRuleIssues, Rules: []string{"java:S1234"}}, issues: issues, complete: true, want: PolicyPassed},
+		{name: "incomplete issues cannot pass", rule: PolicyRule{Type: PolicyRuleIssues, Rules: []string{"java:S1234"}}, issues: issues, want: PolicyFailed},
+		{name: "incomplete issues still fail", rule: PolicyRule{Type: PolicyRuleIssues, Severities: []string{"BLOCKER"}}, issues: issues, want: PolicyFailed},
+		{name: "gate ok", rule: PolicyRule{Type: PolicyRuleQualityGate}, want: PolicyPassed},
+		{name: "gate not allowed", rule: PolicyRule{Type: PolicyRuleQualityGate, Allow: []string{"ERROR"}}, want: PolicyFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.Name = tt.name
+			policy := &Policy{Name: "p", Rules: []PolicyRule{tt.rule}}
+			result := policy.evaluate(data, tt.issues, tt.complete, now)
+
+			if got := result.Rules[0].Status; got != tt.want {
+				t.Fatalf("status = %s (%s), want %s", got, NewLocale(DefaultLanguage, DefaultTimezone).PolicyExplanation(result.Rules[0]), tt.want)
+			}
+			if result.Passed != (tt.want == PolicyPassed) {
+				t.Fatalf("passed = %v with rule %s", result.Passed, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyExplanation(t *testing.T) {
+	data := &ReportData{
+		QualityGateStatus: "ERROR",
+		Metrics:           MetricsSummary{Coverage: "80.5%", SecurityRating: "B", NewBugs: "n/a"},
+	}
+	issues := []IssueItem{
+		{Key: "a", Severity: "BLOCKER", Type: "BUG"},
+		{Key: "b", Severity: "BLOCKER", Type: "BUG"},
+	}
+	en := NewLocale("en", DefaultTimezone)
+	id := NewLocale("id", DefaultTimezone)
+
+	tests := []struct {
+		name     string
+		rule     PolicyRule
+		complete bool
+		en       string
+		id       string
+	}{
+		{
+			name: "metric",
+			rule: PolicyRule{Type: PolicyRuleMetric, Metric: "coverage", Operator: ">=", Value: "85.5"},
+			en:   "coverage is 80.5%, required >= 85.5",
+			id:   "coverage bernilai 80,5%, disyaratkan >= 85,5",
+		},
+		{
+			name: "rating",
+			rule: PolicyRule{Type: PolicyRuleMetric, Metric: "security_rating", Operator: "<=", Value: "A"},
+			en:   "security_rating is B, required <= A",
+			id:   "security_rating bernilai B, disyaratkan <= A",
+		},
+		{
+			name: "no value",
+			rule: PolicyRule{Type: PolicyRuleMetric, Metric: "new_coverage", Operator: ">=", Value: "85"},
+			en:   "new_coverage has no value in this report, so it cannot be verified",
+			id:   "new_coverage tidak memiliki nilai dalam laporan ini, sehingga tidak dapat diverifikasi",
+		},
+		{
+			name: "not numeric",
+			rule: PolicyRule{Type: PolicyRuleMetric, Metric: "new_bugs", Operator: "==", Value: "0"},
+			en:   `new_bugs value "n/a" is not numeric`,
+			id:   `nilai new_bugs "n/a" bukan angka`,
+		},
+		{
+			name:     "issues",
+			rule:     PolicyRule{Type: PolicyRuleIssues, Severities: []string{"BLOCKER"}, Types: []string{"BUG"}, Tags: []string{"cwe"}, Rules: []string{"go:S1"}, OlderThanDays: 7, Max: 1},
+			complete: true,
+			en:       "0 BLOCKER BUG issues of rule go:S1 tagged cwe older than 7 days, at most 1 allowed",
+			id:       "0 isu " + id.Severity("BLOCKER") + " " + id.IssueType("BUG") + " dari aturan go:S1 bertag cwe lebih lama dari 7 hari, paling banyak 1 diizinkan",
+		},
+		{
+			name: "issues partial",
+			rule: PolicyRule{Type: PolicyRuleIssues, Rules: []string{"go:S1"}},
+			en:   "0 issues of rule go:S1 among the 2 issues checked, at most 0 allowed; the remaining open issues could not be checked",
+			id:   "0 isu dari aturan go:S1 di antara 2 isu yang diperiksa, paling banyak 0 diizinkan; isu terbuka lainnya tidak dapat diperiksa",
+		},
+		{
+			name: "quality gate",
+			rule: PolicyRule{Type: PolicyRuleQualityGate, Allow: []string{"OK", "WARN"}},
+			en:   "quality gate is " + en.QualityGate("ERROR") + ", required " + en.QualityGate("OK") + " or " + en.QualityGate("WARN"),
+			id:   "quality gate " + id.QualityGate("ERROR") + ", disyaratkan " + id.QualityGate("OK") + " atau " + id.QualityGate("WARN"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.Name = tt.name
+			policy := &Policy{Name: "p", Rules: []PolicyRule{tt.rule}}
+			rule := policy.evaluate(data, issues, tt.complete, time.Now()).Rules[0]
+
+			if got := en.PolicyExplanation(rule); got != tt.en {
+				t.Errorf("en = %q, want %q", got, tt.en)
+			}
+			if got := id.PolicyExplanation(rule); got != tt.id {
+				t.Errorf("id = %q, want %q", got, tt.id)
+			}
+		})
+	}
+}
+
+// TestPolicyRenderIndonesian renders policy results, expired suppressions
+// and a boolean metric in Indonesian and checks no English text is left
+func TestPolicyRenderIndonesian(t *testing.T) {
+	var keys []string
+	var issues []IssueItem
+	for _, k := range []string{"k1", "k2", "k3", "k4", "k5", "k6", "k7"} {
+		keys = append(keys, k)
+		issues = append(issues, IssueItem{Key: k, Severity: "BLOCKER", Type: "BUG", Component: "src/a.go", Rule: "go:S1"})
+	}
+
+	data := &ReportData{
+		ProjectKey:        "proj",
+		ProjectName:       "Proj",
+		Language:          "id",
+		GeneratedAt:       time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		QualityGateStatus: "ERROR",
+		TotalIssues:       len(issues),
+		IssuesBySeverity:  map[string][]IssueItem{"BLOCKER": issues},
+		IssuesByType:      map[string]int{"BUG": len(issues)},
+		Metrics:           MetricsSummary{Coverage: "80.5%", NewBugs: "n/a"},
+		SelectedMetrics: []MetricValue{
+			{Key: "flag", Name: "flag", Type: "BOOL", Value: "true", Formatted: formatMetricValue("BOOL", "true", 8)},
+			{Key: "other", Name: "other", Type: "BOOL", Value: "false", Formatted: formatMetricValue("BOOL", "false", 8)},
+		},
+		Suppressed: &SuppressionInfo{Expired: []Suppression{
+			{Issue: "AX-1", Reason: "r1", Expires: "2026-01-01"},
+			{Fingerprint: "abc123", Reason: "r2", Expires: "2026-01-01"},
+			{Rule: "go:S2", Path: "gen/**", Reason: "r3", Expires: "2026-01-01"},
+			{Rule: "go:S3", Reason: "r4", Expires: "2026-01-01"},
+			{Path: "vendor/**", Reason: "r5", Expires: "2026-01-01"},
+		}},
+	}
+	policy := &Policy{Name: "p", Rules: []PolicyRule{
+		{Name: "r-metric", Type: PolicyRuleMetric, Metric: "coverage", Operator: ">=", Value: "85"},
+		{Name: "r-novalue", Type: PolicyRuleMetric, Metric: "new_coverage", Operator: ">=", Value: "85"},
+		{Name: "r-numeric", Type: PolicyRuleMetric, Metric: "new_bugs", Operator: "==", Value: "0"},
+		{Name: "r-issues", Type: PolicyRuleIssues, Severities: []string{"BLOCKER"}, Tags: []string{"cwe"}},
+		{Name: "r-blockers", Type: PolicyRuleIssues, Severities: []string{"BLOCKER"}, Rules: []string{"go:S1"}},
+		{Name: "r-partial", Type: PolicyRuleIssues, Rules: []string{"go:S9"}, OlderThanDays: 3},
+		{Name: "r-gate", Type: PolicyRuleQualityGate, Allow: []string{"OK", "WARN"}},
+	}}
+	data.Policy = policy.evaluate(data, issues[:6], false, data.GeneratedAt)
+	if got := len(data.Policy.Rules[4].Issues); got != len(keys)-1 {
+		t.Fatalf("offending issues = %d", got)
+	}
+
+	english := []string{
+		"has no value", "cannot be verified", "is not numeric", "required", "at most", "allowed",
+		"issues checked", "could not be checked", "of rule", "tagged", "older than", " more)",
+		"quality gate is", " or ", "issue AX-1", "fingerprint abc123", "rule go:S", "path vendor",
+		">Yes<", ">No<", "| Yes |", "| No |",
+	}
+	for name, gen := range map[string]interface {
+		Generate(*ReportData) ([]byte, error)
+	}{"markdown": NewMarkdownGenerator(), "html": NewHTMLGenerator()} {
+		out, err := gen.Generate(data)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, s := range english {
+			if strings.Contains(string(out), s) {
+				t.Errorf("%s report contains English %q", name, s)
+			}
+		}
+		for _, s := range []string{"disyaratkan", "dan 1 lainnya", "sidik jari abc123", "aturan go:S2 di gen/**", "jalur vendor/**", "Ya", "Tidak"} {
+			if !strings.Contains(string(out), s) {
+				t.Errorf("%s report lacks %q", name, s)
+			}
+		}
+	}
+}
+
+func TestPolicyEvaluateStoredReport(t *testing.T) {
+	policy := &Policy{Name: "p", Rules: []PolicyRule{{Name: "no blockers", Type: PolicyRuleIssues, Severities: []string{"BLOCKER"}}}}
+	listed := map[string][]IssueItem{"MAJOR": {{Key: "a", Severity: "MAJOR"}}}
+
+	tests := []struct {
+		name string
+		data *ReportData
+		want bool
+	}{
+		{name: "all issues listed", data: &ReportData{TotalIssues: 1, IssuesBySeverity: listed}, want: true},
+		{name: "truncated", data: &ReportData{TotalIssues: 600, IssuesBySeverity: listed}, want: false},
+		{name: "team report", data: &ReportData{TotalIssues: 1, IssuesBySeverity: listed, Team: "@org/a"}, want: false},
+		{name: "filtered", data: &ReportData{TotalIssues: 1, IssuesBySeverity: listed, Severities: []string{"MAJOR"}}, want: false},
+		{name: "suppressed", data: &ReportData{TotalIssues: 1, IssuesBySeverity: listed, Suppressed: &SuppressionInfo{Issues: []SuppressedIssue{{IssueItem: IssueItem{Key: "b"}}}}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Evaluate(tt.data).Passed; got != tt.want {
+				t.Fatalf("passed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildMetricsSummaryNewCode(t *testing.T) {
+	measures := []sonarqube.Measure{
+		{Metric: "coverage", Value: "80.0"},
+		{Metric: "new_coverage", Period: &sonarqube.Period{Value: "91.2"}},
+		{Metric: "new_bugs", Period: &sonarqube.Period{Value: "3"}},
+	}
+	summary := buildMetricsSummary(measures, 8)
+
+	if summary.Coverage != "80.0%" {
+		t.Errorf("Coverage = %q, want 80.0%%", summary.Coverage)
+	}
+	if summary.NewCoverage != "91.2%" {
+		t.Errorf("NewCoverage = %q, want 91.2%%", summary.NewCoverage)
+	}
+	if summary.NewBugs != "3" {
+		t.Errorf("NewBugs = %q, want 3", summary.NewBugs)
+	}
+	if summary.NewVulnerabilities != "" {
+		t.Errorf("NewVulnerabilities = %q, want empty", summary.NewVulnerabilities)
+	}
+}
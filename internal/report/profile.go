@@ -0,0 +1,372 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+const (
+	profilesFileName = "profiles.json"
+)
+
+// Report sections that profiles select, besides those marked in historical reports
+const (
+	SectionPolicy       = "policy"
+	SectionTopIssues    = "topIssues"
+	SectionBaseline     = "baseline"
+	SectionLanguages    = "languages"
+	SectionOwners       = "owners"
+	SectionIssueDetails = "issueDetails"
+	SectionRemediation  = "remediation"
+	SectionSummary      = "summary"
+	SectionSuppressed   = "suppressed"
+)
+
+// ReportSections lists the sections of full reports in the order they are rendered
+var ReportSections = []string{
+	SectionQualityGate,
+	SectionPolicy,
+	SectionTopIssues,
+	SectionBaseline,
+	SectionMetrics,
+	SectionLanguages,
+	SectionIssues,
+	SectionOwners,
+	SectionIssueDetails,
+	SectionRemediation,
+	SectionHotspots,
+	SectionSummary,
+	SectionSuppressed,
+}
+
+// EffortGroupings lists the breakdowns of the remediation plan
+var EffortGroupings = []string{"severity", "type", "rule", "file", "author"}
+
+// IssueSeverities and IssueTypes list the values issues can be filtered by
+var (
+	IssueSeverities = []string{"BLOCKER", "CRITICAL", "MAJOR", "MINOR", "INFO"}
+	IssueTypes      = []string{"BUG", "VULNERABILITY", "CODE_SMELL"}
+)
+
+// ReportProfile is a named set of report options, so that a kind of report
+// is one click away. Request options left unset take the profile's values.
+type ReportProfile struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	BuiltIn     bool   `json:"builtIn,omitempty"` // shipped with the server, read-only
+
+	// Sections lists the sections to include, all when empty
+	Sections []string `json:"sections,omitempty"`
+
+	// Severities and Types keep only the issues of these severities and types
+	Severities []string `json:"severities,omitempty"`
+	Types      []string `json:"types,omitempty"`
+
+	// Groupings lists the breakdowns of the remediation plan, all when empty
+	Groupings []string `json:"groupings,omitempty"`
+
+	// Defaults of the generation options
+	Format                 string   `json:"format,omitempty"`
+	IncludeCodeSnippets    *bool    `json:"includeCodeSnippets,omitempty"`
+	IncludeHowToFix        *bool    `json:"includeHowToFix,omitempty"`
+	MaxSnippetsPerSeverity int      `json:"maxSnippetsPerSeverity,omitempty"`
+	TopIssues              int      `json:"topIssues,omitempty"`
+	Metrics                []string `json:"metrics,omitempty"`
+	Language               string   `json:"language,omitempty"`
+	Timezone               string   `json:"timezone,omitempty"`
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+// builtinProfiles are the profiles every server offers
+var builtinProfiles = []ReportProfile{
+	{
+		Name:                "executive",
+		Description:         "One-pager for management: quality gate, key metrics, top issues and the remediation effort",
+		BuiltIn:             true,
+		Sections:            []string{SectionQualityGate, SectionPolicy, SectionTopIssues, SectionBaseline, SectionMetrics, SectionLanguages, SectionRemediation, SectionSummary},
+		Groupings:           []string{"severity", "type"},
+		Format:              "pdf",
+		IncludeCodeSnippets: boolPtr(false),
+		IncludeHowToFix:     boolPtr(false),
+		TopIssues:           5,
+	},
+	{
+		Name:                   "developer",
+		Description:            "Full backlog for developers: every section and issue with code snippets and how to fix",
+		BuiltIn:                true,
+		Format:                 "md",
+		IncludeCodeSnippets:    boolPtr(true),
+		IncludeHowToFix:        boolPtr(true),
+		MaxSnippetsPerSeverity: 25,
+		TopIssues:              20,
+	},
+	{
+		Name:                "security",
+		Description:         "Security review: vulnerabilities and security hotspots",
+		BuiltIn:             true,
+		Sections:            []string{SectionQualityGate, SectionPolicy, SectionTopIssues, SectionIssues, SectionOwners, SectionIssueDetails, SectionHotspots, SectionSummary, SectionSuppressed},
+		Types:               []string{"VULNERABILITY"},
+		Format:              "pdf",
+		IncludeCodeSnippets: boolPtr(true),
+		IncludeHowToFix:     boolPtr(true),
+	},
+}
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Validate checks the name, format and selections of a profile. Metrics are
+// checked against the server separately.
+func (p *ReportProfile) Validate() error {
+	if !profileNamePattern.MatchString(p.Name) {
+		return errors.New("profile name must be 1-64 lowercase letters, digits, '-' or '_'")
+	}
+	if p.Format != "" {
+		if err := ValidateFormat(p.Format); err != nil {
+			return err
+		}
+	}
+	if err := ValidateSnippetLimit(p.MaxSnippetsPerSeverity); err != nil {
+		return err
+	}
+	if p.TopIssues < 0 {
+		return errors.New("topIssues must not be negative")
+	}
+	if p.Language != "" {
+		if err := ValidateLanguage(p.Language); err != nil {
+			return err
+		}
+	}
+	if p.Timezone != "" {
+		if err := ValidateTimezone(p.Timezone); err != nil {
+			return err
+		}
+	}
+	if len(p.Metrics) > maxSelectedMetrics {
+		return fmt.Errorf("at most %d metrics can be selected", maxSelectedMetrics)
+	}
+	return ValidateSelection(p.Sections, p.Severities, p.Types, p.Groupings)
+}
+
+// ValidateSelection checks the sections, issue filters and remediation plan
+// groupings of a report
+func ValidateSelection(sections, severities, types, groupings []string) error {
+	if err := validateChoices("section", sections, ReportSections); err != nil {
+		return err
+	}
+	if err := validateChoices("severity", severities, IssueSeverities); err != nil {
+		return err
+	}
+	if err := validateChoices("type", types, IssueTypes); err != nil {
+		return err
+	}
+	return validateChoices("grouping", groupings, EffortGroupings)
+}
+
+func validateChoices(kind string, values, allowed []string) error {
+	for _, v := range values {
+		if !containsValue(allowed, v) {
+			return fmt.Errorf("unknown %s %q", kind, v)
+		}
+	}
+	return nil
+}
+
+func containsValue(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Shows reports whether a section is included in the report
+func (d *ReportData) Shows(section string) bool {
+	return len(d.Sections) == 0 || containsValue(d.Sections, section)
+}
+
+// shows reports whether a section is included in the generated report
+func (o GenerateOptions) shows(section string) bool {
+	return len(o.Sections) == 0 || containsValue(o.Sections, section)
+}
+
+// filterIssues keeps the issues of the given severities and types; empty
+// lists keep all
+func filterIssues(issues []sonarqube.Issue, severities, types []string) []sonarqube.Issue {
+	kept := make([]sonarqube.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if len(severities) > 0 && !containsValue(severities, issue.Severity) {
+			continue
+		}
+		if len(types) > 0 && !containsValue(types, issue.Type) {
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept
+}
+
+// keepGroupings clears the remediation plan breakdowns not selected; an
+// empty selection keeps all
+func (s *EffortSummary) keepGroupings(groupings []string) {
+	if len(groupings) == 0 {
+		return
+	}
+	if !containsValue(groupings, "severity") {
+		s.BySeverity = nil
+	}
+	if !containsValue(groupings, "type") {
+		s.ByType = nil
+	}
+	if !containsValue(groupings, "rule") {
+		s.ByRule = nil
+	}
+	if !containsValue(groupings, "file") {
+		s.ByFile = nil
+	}
+	if !containsValue(groupings, "author") {
+		s.ByAuthor = nil
+	}
+}
+
+// ProfileStore keeps the built-in profiles and those created through the
+// API, which are persisted in a file
+type ProfileStore struct {
+	filePath string
+
+	mu       sync.RWMutex
+	profiles map[string]*ReportProfile
+}
+
+// NewProfileStore creates a profile store persisted inside storagePath
+func NewProfileStore(storagePath string) (*ProfileStore, error) {
+	s := &ProfileStore{
+		filePath: filepath.Join(storagePath, profilesFileName),
+		profiles: make(map[string]*ReportProfile),
+	}
+
+	raw, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+
+	var profiles []*ReportProfile
+	if err := json.Unmarshal(raw, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles: %w", err)
+	}
+	for _, p := range profiles {
+		p.BuiltIn = false
+		s.profiles[p.Name] = p
+	}
+
+	return s, nil
+}
+
+func builtinProfile(name string) (*ReportProfile, bool) {
+	for i := range builtinProfiles {
+		if builtinProfiles[i].Name == name {
+			p := builtinProfiles[i]
+			return &p, true
+		}
+	}
+	return nil, false
+}
+
+// Get returns a profile by name
+func (s *ProfileStore) Get(name string) (*ReportProfile, bool) {
+	if p, ok := builtinProfile(name); ok {
+		return p, true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.profiles[name]
+	if !ok {
+		return nil, false
+	}
+	copied := *p
+	return &copied, true
+}
+
+// List returns the built-in profiles followed by the others sorted by name
+func (s *ProfileStore) List() []ReportProfile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	custom := make([]ReportProfile, 0, len(s.profiles))
+	for _, p := range s.profiles {
+		custom = append(custom, *p)
+	}
+	sort.Slice(custom, func(i, j int) bool {
+		return custom[i].Name < custom[j].Name
+	})
+
+	list := make([]ReportProfile, 0, len(builtinProfiles)+len(custom))
+	list = append(list, builtinProfiles...)
+	return append(list, custom...)
+}
+
+// Put validates and stores a profile, replacing the one of the same name.
+// Built-in profiles cannot be replaced.
+func (s *ProfileStore) Put(p *ReportProfile) error {
+	if _, ok := builtinProfile(p.Name); ok {
+		return fmt.Errorf("profile %q is built in and cannot be changed", p.Name)
+	}
+	p.BuiltIn = false
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.profiles[p.Name] = p
+	return s.save()
+}
+
+// Delete removes a profile
+func (s *ProfileStore) Delete(name string) error {
+	if _, ok := builtinProfile(name); ok {
+		return fmt.Errorf("profile %q is built in and cannot be deleted", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.profiles[name]; !ok {
+		return fmt.Errorf("profile not found")
+	}
+	delete(s.profiles, name)
+	return s.save()
+}
+
+func (s *ProfileStore) save() error {
+	profiles := make([]*ReportProfile, 0, len(s.profiles))
+	for _, p := range s.profiles {
+		profiles = append(profiles, p)
+	}
+
+	raw, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode profiles: %w", err)
+	}
+	if err := os.WriteFile(s.filePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write profiles: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package report
+
+import "fmt"
+
+// Generation phases reported through ProgressFunc
+const (
+	PhaseProject     = "project"
+	PhaseQualityGate = "quality_gate"
+	PhaseMeasures    = "measures"
+	PhaseIssues      = "issues"
+	PhaseHotspots    = "hotspots"
+	PhaseSnippets    = "snippets"
+	PhaseRendering   = "rendering"
+	PhaseSaving      = "saving"
+	PhaseQueued      = "queued"
+	PhaseDone        = "done"
+	PhaseFailed      = "failed"
+	PhaseCancelled   = "cancelled"
+)
+
+// Progress describes how far a report generation has come
+type Progress struct {
+	Phase   string `json:"phase"`
+	Done    int    `json:"done"`
+	Total   int    `json:"total"`
+	Message string `json:"message"`
+
+	// Side tells apart the generations of a branch comparison, "base" or
+	// "head"; each side moves through the phases on its own
+	Side string `json:"side,omitempty"`
+}
+
+// IsFinal reports whether no further updates will follow
+func (p Progress) IsFinal() bool {
+	return p.Phase == PhaseDone || p.Phase == PhaseFailed || p.Phase == PhaseCancelled
+}
+
+// ProgressFunc receives progress updates. It may be called from several
+// goroutines at once and must not block for long.
+type ProgressFunc func(Progress)
+
+// phaseMessages are the human-readable labels for each phase
+var phaseMessages = map[string]string{
+	PhaseProject:     "loading project",
+	PhaseQualityGate: "fetching quality gate",
+	PhaseMeasures:    "fetching measures",
+	PhaseIssues:      "fetching issues",
+	PhaseHotspots:    "fetching hotspots",
+	PhaseSnippets:    "snippets",
+	PhaseRendering:   "rendering",
+	PhaseSaving:      "saving report",
+	PhaseQueued:      "waiting in queue",
+	PhaseDone:        "done",
+	PhaseFailed:      "failed",
+	PhaseCancelled:   "cancelled",
+}
+
+// NewProgress builds a progress update with a default message such as
+// "fetching issues 300/1200"
+func NewProgress(phase string, done, total int) Progress {
+	message := phaseMessages[phase]
+	if message == "" {
+		message = phase
+	}
+	if total > 0 {
+		message = fmt.Sprintf("%s %d/%d", message, done, total)
+	}
+	return Progress{Phase: phase, Done: done, Total: total, Message: message}
+}
+
+// Report sends a progress update if a callback is set
+func (f ProgressFunc) Report(phase string, done, total int) {
+	if f != nil {
+		f(NewProgress(phase, done, total))
+	}
+}
+
+// ReportMessage sends a progress update with a custom message if a callback is set
+func (f ProgressFunc) ReportMessage(phase, message string) {
+	if f != nil {
+		f(Progress{Phase: phase, Message: message})
+	}
+}
+
+// ForSide returns a callback for one of generations running side by side,
+// which marks updates with the side and prefixes their messages with it. It
+// returns nil for a nil callback.
+func (f ProgressFunc) ForSide(side string) ProgressFunc {
+	if f == nil {
+		return nil
+	}
+	return func(p Progress) {
+		p.Side = side
+		p.Message = side + ": " + p.Message
+		f(p)
+	}
+}
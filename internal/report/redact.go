@@ -0,0 +1,208 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// RedactionPattern detects one kind of secret. When the expression has a
+// group named "secret" only that group is masked, so that the surrounding
+// code, e.g. the variable name, stays readable.
+type RedactionPattern struct {
+	Name  string `json:"name"`
+	Regex string `json:"regex"`
+
+	re *regexp.Regexp
+}
+
+// defaultRedactionPatterns detect common credentials
+var defaultRedactionPatterns = []RedactionPattern{
+	{Name: "private-key", Regex: `-----BEGIN[A-Z ]*PRIVATE KEY-----(?s:.*?)(?:-----END[A-Z ]*PRIVATE KEY-----|$)`},
+	{Name: "aws-access-key", Regex: `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`},
+	{Name: "github-token", Regex: `\bgh[pousr]_[A-Za-z0-9]{36,}\b`},
+	{Name: "slack-token", Regex: `\bxox[abposr]-[A-Za-z0-9-]{10,}`},
+	{Name: "sonarqube-token", Regex: `\bsq[pua]_[0-9a-f]{40}\b`},
+	{Name: "jwt", Regex: `\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`},
+	{Name: "bearer-token", Regex: `(?i)\bbearer\s+(?P<secret>[A-Za-z0-9._~+/-]{16,}=*)`},
+	{Name: "connection-string", Regex: `(?i)\b[a-z][a-z0-9+.-]*://[^\s:/@]+:(?P<secret>[^\s@/\[]+)@`},
+	// Quoted values, or unquoted ones directly after the sign as in
+	// "token=..." query strings and env files; "password = getPassword()"
+	// is left alone
+	{Name: "credential", Regex: `(?i)[a-z0-9_.-]*(?:password|passwd|pwd|secret|token|api[_-]?key|access[_-]?key|private[_-]?key|credentials?)[a-z0-9_.-]*["']?\s*(?:[:=]|:=|=>)\s*["'](?P<secret>[^\s"'\[][^\s"']{5,})["']`},
+	{Name: "credential", Regex: `(?i)[a-z0-9_.-]*(?:password|passwd|pwd|secret|token|api[_-]?key|access[_-]?key|private[_-]?key|credentials?)[a-z0-9_.-]*[:=](?P<secret>[^\s"'&;,\[][^\s"'&;,]{5,})`},
+}
+
+// RedactionConfig is the file of extra redaction patterns
+type RedactionConfig struct {
+	Patterns []RedactionPattern `json:"patterns"`
+
+	// DisableDefaults uses only the patterns of the file
+	DisableDefaults bool `json:"disableDefaults,omitempty"`
+}
+
+// Redactor masks secrets in the issue messages and code snippets of reports,
+// which may be shared outside the team. A nil Redactor masks nothing.
+type Redactor struct {
+	patterns []RedactionPattern
+}
+
+// RedactionInfo counts the secrets masked in a report
+type RedactionInfo struct {
+	Total     int            `json:"total"`
+	ByPattern map[string]int `json:"byPattern"`
+}
+
+// LoadRedactor builds a redactor from the default patterns and those of a
+// pattern file. It returns nil when redaction is disabled.
+func LoadRedactor(enabled bool, file string) (*Redactor, error) {
+	if !enabled {
+		return nil, nil
+	}
+
+	config := RedactionConfig{}
+	if file != "" {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read redaction file: %w", err)
+		}
+		if err := json.Unmarshal(raw, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse redaction file: %w", err)
+		}
+	}
+
+	r := &Redactor{}
+	if !config.DisableDefaults {
+		r.patterns = append(r.patterns, defaultRedactionPatterns...)
+	}
+	r.patterns = append(r.patterns, config.Patterns...)
+
+	for i := range r.patterns {
+		p := &r.patterns[i]
+		if p.Name == "" || p.Regex == "" {
+			return nil, fmt.Errorf("invalid redaction file: pattern %d needs a name and a regex", i+1)
+		}
+		re, err := regexp.Compile(p.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p.Name, err)
+		}
+		p.re = re
+	}
+
+	return r, nil
+}
+
+// Patterns returns the names of the patterns in use
+func (r *Redactor) Patterns() []string {
+	if r == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, p := range r.patterns {
+		if !seen[p.Name] {
+			seen[p.Name] = true
+			names = append(names, p.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Version identifies the patterns in use, which change what reports mask
+func (r *Redactor) Version() string {
+	if r == nil {
+		return "off"
+	}
+	h := sha256.New()
+	for _, p := range r.patterns {
+		fmt.Fprintf(h, "%s\x00%s\x00", p.Name, p.Regex)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// redaction masks the secrets of one report and counts them
+type redaction struct {
+	redactor *Redactor
+	counts   map[string]int
+}
+
+func (r *Redactor) begin() *redaction {
+	return &redaction{redactor: r, counts: make(map[string]int)}
+}
+
+// text masks the secrets in s
+func (s *redaction) text(v string) string {
+	if s.redactor == nil || v == "" {
+		return v
+	}
+	for _, p := range s.redactor.patterns {
+		v = s.mask(p, v)
+	}
+	return v
+}
+
+// mask replaces the matches of a pattern, or their secret group, with a placeholder
+func (s *redaction) mask(p RedactionPattern, v string) string {
+	matches := p.re.FindAllStringSubmatchIndex(v, -1)
+	if len(matches) == 0 {
+		return v
+	}
+
+	group := p.re.SubexpIndex("secret")
+	placeholder := "[REDACTED:" + p.Name + "]"
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if group > 0 && m[2*group] >= 0 {
+			start, end = m[2*group], m[2*group+1]
+		}
+		b.WriteString(v[last:start])
+		b.WriteString(placeholder)
+		last = end
+		s.counts[p.Name]++
+	}
+	b.WriteString(v[last:])
+	return b.String()
+}
+
+// items masks the messages and code snippets of issue items
+func (s *redaction) items(items []IssueItem) {
+	for i := range items {
+		items[i].Message = s.text(items[i].Message)
+		items[i].CodeSnippet = s.text(items[i].CodeSnippet)
+	}
+}
+
+// flows masks the flow messages of issues
+func (s *redaction) flows(issues []sonarqube.Issue) {
+	for i := range issues {
+		for f := range issues[i].Flows {
+			for l := range issues[i].Flows[f].Locations {
+				loc := &issues[i].Flows[f].Locations[l]
+				loc.Msg = s.text(loc.Msg)
+			}
+		}
+	}
+}
+
+// info returns the redaction counts, nil if nothing was masked
+func (s *redaction) info() *RedactionInfo {
+	if len(s.counts) == 0 {
+		return nil
+	}
+	info := &RedactionInfo{ByPattern: s.counts}
+	for _, n := range s.counts {
+		info.Total += n
+	}
+	return info
+}
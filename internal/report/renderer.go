@@ -0,0 +1,96 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FormatMarkdown is the format reports are rendered in when none is chosen,
+// and the one the preview pages show
+const FormatMarkdown = "md"
+
+// ReportFormat describes a format reports can be rendered in
+type ReportFormat struct {
+	Name        string `json:"name"`  // as chosen in requests, e.g. "pdf"
+	Title       string `json:"title"` // for display, e.g. "PDF"
+	Extension   string `json:"extension"`
+	ContentType string `json:"contentType"`
+
+	// Previewable reports are markdown the preview pages show as they are;
+	// reports in other formats are previewed as markdown rendered from their
+	// data snapshot
+	Previewable bool `json:"previewable"`
+}
+
+// Renderer renders report data and branch comparisons into one format
+type Renderer interface {
+	Format() ReportFormat
+	Generate(data *ReportData) ([]byte, error)
+	GenerateDiff(diff *ReportDiff) ([]byte, error)
+}
+
+// renderers are the formats reports can be rendered in, by name, in the
+// order they were registered
+var renderers = struct {
+	sync.RWMutex
+	byName map[string]Renderer
+	order  []string
+}{byName: make(map[string]Renderer)}
+
+func init() {
+	RegisterRenderer(NewMarkdownGenerator())
+	RegisterRenderer(NewPDFGenerator())
+	RegisterRenderer(NewHTMLGenerator())
+}
+
+// RegisterRenderer makes a format available to reports. It panics when a
+// format of the same name is already registered.
+func RegisterRenderer(r Renderer) {
+	renderers.Lock()
+	defer renderers.Unlock()
+
+	name := r.Format().Name
+	if _, ok := renderers.byName[name]; ok {
+		panic(fmt.Sprintf("report: format %q registered twice", name))
+	}
+	renderers.byName[name] = r
+	renderers.order = append(renderers.order, name)
+}
+
+// RendererFor returns the renderer of a format
+func RendererFor(format string) (Renderer, bool) {
+	renderers.RLock()
+	defer renderers.RUnlock()
+
+	r, ok := renderers.byName[format]
+	return r, ok
+}
+
+// Formats returns the formats reports can be rendered in, markdown first
+func Formats() []ReportFormat {
+	renderers.RLock()
+	defer renderers.RUnlock()
+
+	formats := make([]ReportFormat, 0, len(renderers.order))
+	for _, name := range renderers.order {
+		formats = append(formats, renderers.byName[name].Format())
+	}
+	return formats
+}
+
+// ValidateFormat checks that reports can be rendered in a format
+func ValidateFormat(format string) error {
+	if _, ok := RendererFor(format); !ok {
+		return fmt.Errorf("format must be one of %s", strings.Join(FormatNames(), ", "))
+	}
+	return nil
+}
+
+// FormatNames returns the names of the formats reports can be rendered in
+func FormatNames() []string {
+	renderers.RLock()
+	defer renderers.RUnlock()
+
+	return append([]string(nil), renderers.order...)
+}
@@ -0,0 +1,340 @@
+package report
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+const (
+	ruleCacheFileName    = "rule-cache.json"
+	versionCheckInterval = 15 * time.Minute
+)
+
+// RuleCache is a size-bounded, long-lived cache of rule "how to fix" texts
+// and languages shared by all generations. Entries expire after a TTL and the whole cache
+// is dropped when the SonarQube server version changes.
+type RuleCache struct {
+	client     *sonarqube.Client
+	filePath   string // empty disables persistence
+	maxEntries int
+	ttl        time.Duration
+
+	// saveMu serialises saves, so that an older snapshot never replaces a
+	// newer one on disk
+	saveMu sync.Mutex
+
+	mu               sync.Mutex
+	serverVersion    string
+	lastVersionCheck time.Time
+	order            *list.List // front = most recently used
+	entries          map[string]*list.Element
+	inflight         map[string]chan struct{}
+	warmedProfiles   map[string]time.Time
+	dirty            bool
+}
+
+// ruleCacheEntry is a single cached rule
+type ruleCacheEntry struct {
+	Key       string    `json:"key"`
+	HowToFix  string    `json:"howToFix"`
+	Lang      string    `json:"lang,omitempty"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// ruleCacheFile is the on-disk representation of the cache
+type ruleCacheFile struct {
+	ServerVersion string           `json:"serverVersion"`
+	Entries       []ruleCacheEntry `json:"entries"`
+}
+
+// NewRuleCache creates a rule cache. When storagePath is not empty the cache
+// is loaded from and persisted to a file inside it.
+func NewRuleCache(client *sonarqube.Client, storagePath string, maxEntries int, ttl time.Duration) *RuleCache {
+	c := &RuleCache{
+		client:         client,
+		maxEntries:     maxEntries,
+		ttl:            ttl,
+		order:          list.New(),
+		entries:        make(map[string]*list.Element),
+		inflight:       make(map[string]chan struct{}),
+		warmedProfiles: make(map[string]time.Time),
+	}
+
+	if storagePath != "" {
+		c.filePath = filepath.Join(storagePath, ruleCacheFileName)
+		if err := c.load(); err != nil && !os.IsNotExist(err) {
+			log.Printf("Warning: Failed to load rule cache: %v", err)
+		}
+	}
+
+	return c
+}
+
+// CheckServerVersion drops all entries if the SonarQube version changed.
+// The server is asked at most once per versionCheckInterval.
+func (c *RuleCache) CheckServerVersion() {
+	c.mu.Lock()
+	if time.Since(c.lastVersionCheck) < versionCheckInterval {
+		c.mu.Unlock()
+		return
+	}
+	c.lastVersionCheck = time.Now()
+	c.mu.Unlock()
+
+	version, err := c.client.GetServerVersion()
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.serverVersion != "" && c.serverVersion != version {
+		c.order.Init()
+		c.entries = make(map[string]*list.Element)
+		c.warmedProfiles = make(map[string]time.Time)
+	}
+	if c.serverVersion != version {
+		c.serverVersion = version
+		c.dirty = true
+	}
+}
+
+// Warm loads the active rules of a project's quality profiles in bulk.
+// Profiles warmed within the TTL are skipped.
+func (c *RuleCache) Warm(projectKey string) error {
+	profiles, err := c.client.GetQualityProfiles(projectKey)
+	if err != nil {
+		return fmt.Errorf("failed to get quality profiles: %w", err)
+	}
+
+	for _, profile := range profiles {
+		c.mu.Lock()
+		warmedAt, ok := c.warmedProfiles[profile.Key]
+		c.mu.Unlock()
+		if ok && time.Since(warmedAt) < c.ttl {
+			continue
+		}
+
+		rules, err := c.client.GetActiveRules(profile.Key)
+		if err != nil {
+			return fmt.Errorf("failed to get active rules for profile %s: %w", profile.Key, err)
+		}
+
+		c.mu.Lock()
+		for i := range rules {
+			c.put(rules[i].Key, ruleHowToFix(&rules[i]), rules[i].Lang)
+		}
+		c.warmedProfiles[profile.Key] = time.Now()
+		c.mu.Unlock()
+	}
+
+	return nil
+}
+
+// HowToFix returns the "how to fix" text for a rule, fetching it on a miss.
+// Concurrent misses for the same rule share a single request.
+func (c *RuleCache) HowToFix(ruleKey string) string {
+	for {
+		c.mu.Lock()
+		if howToFix, ok := c.get(ruleKey); ok {
+			c.mu.Unlock()
+			return howToFix
+		}
+		wait, busy := c.inflight[ruleKey]
+		if !busy {
+			c.inflight[ruleKey] = make(chan struct{})
+			c.mu.Unlock()
+			break
+		}
+		c.mu.Unlock()
+		<-wait
+	}
+
+	rule, err := c.client.GetRule(ruleKey)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	close(c.inflight[ruleKey])
+	delete(c.inflight, ruleKey)
+
+	if err != nil {
+		// Do not cache failures so the next generation retries
+		return ""
+	}
+
+	howToFix := ruleHowToFix(rule)
+	c.put(ruleKey, howToFix, rule.Lang)
+	return howToFix
+}
+
+// Language returns the language key of a cached rule, empty if the rule is
+// not cached. It never fetches, so warm the cache first.
+func (c *RuleCache) Language(ruleKey string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[ruleKey]
+	if !ok {
+		return ""
+	}
+	return elem.Value.(*ruleCacheEntry).Lang
+}
+
+// Save persists the cache to disk if it changed since the last save. It is
+// safe to call from concurrent generations.
+func (c *RuleCache) Save() error {
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
+
+	c.mu.Lock()
+	if c.filePath == "" || !c.dirty {
+		c.mu.Unlock()
+		return nil
+	}
+
+	file := ruleCacheFile{ServerVersion: c.serverVersion}
+	for e := c.order.Front(); e != nil; e = e.Next() {
+		file.Entries = append(file.Entries, *e.Value.(*ruleCacheEntry))
+	}
+	c.dirty = false
+	c.mu.Unlock()
+
+	if err := c.write(file); err != nil {
+		// Try again on the next save
+		c.mu.Lock()
+		c.dirty = true
+		c.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// write replaces the cache file through a temporary file of its own, so a
+// crash never leaves a truncated cache
+func (c *RuleCache) write(file ruleCacheFile) error {
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.filePath), ruleCacheFileName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write rule cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write rule cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write rule cache: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write rule cache: %w", err)
+	}
+	return os.Rename(tmp.Name(), c.filePath)
+}
+
+// Len returns the number of cached rules
+func (c *RuleCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// get returns a fresh entry and marks it as recently used. Caller holds mu.
+func (c *RuleCache) get(ruleKey string) (string, bool) {
+	elem, ok := c.entries[ruleKey]
+	if !ok {
+		return "", false
+	}
+
+	entry := elem.Value.(*ruleCacheEntry)
+	if time.Since(entry.FetchedAt) > c.ttl {
+		c.order.Remove(elem)
+		delete(c.entries, ruleKey)
+		c.dirty = true
+		return "", false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.HowToFix, true
+}
+
+// put stores an entry, evicting the least recently used one if full. Caller holds mu.
+func (c *RuleCache) put(ruleKey, howToFix, lang string) {
+	c.dirty = true
+
+	if elem, ok := c.entries[ruleKey]; ok {
+		entry := elem.Value.(*ruleCacheEntry)
+		entry.HowToFix = howToFix
+		entry.Lang = lang
+		entry.FetchedAt = time.Now()
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[ruleKey] = c.order.PushFront(&ruleCacheEntry{
+		Key:       ruleKey,
+		HowToFix:  howToFix,
+		Lang:      lang,
+		FetchedAt: time.Now(),
+	})
+
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*ruleCacheEntry).Key)
+	}
+}
+
+// load reads the persisted cache, skipping expired entries
+func (c *RuleCache) load() error {
+	data, err := os.ReadFile(c.filePath)
+	if err != nil {
+		return err
+	}
+
+	var file ruleCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+
+	c.serverVersion = file.ServerVersion
+	// Entries are stored most recently used first
+	for i := range file.Entries {
+		entry := file.Entries[i]
+		if time.Since(entry.FetchedAt) > c.ttl {
+			continue
+		}
+		if _, ok := c.entries[entry.Key]; ok {
+			continue
+		}
+		c.entries[entry.Key] = c.order.PushBack(&entry)
+		if c.maxEntries > 0 && c.order.Len() >= c.maxEntries {
+			break
+		}
+	}
+
+	return nil
+}
+
+// ruleHowToFix extracts the "how to fix" text from a rule description
+func ruleHowToFix(rule *sonarqube.Rule) string {
+	description := rule.MdDesc
+	if description == "" {
+		description = stripHTML(rule.HtmlDesc)
+	}
+
+	return extractHowToFix(description)
+}
@@ -0,0 +1,134 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRuleCacheLRU(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxEntries int
+		puts       []string
+		gets       []string // used between the puts of the first entries and the last
+		last       string
+		want       []string // cached keys
+		gone       []string
+	}{
+		{name: "unbounded", puts: []string{"a", "b", "c"}, last: "d", want: []string{"a", "b", "c", "d"}},
+		{name: "evicts oldest", maxEntries: 3, puts: []string{"a", "b", "c"}, last: "d", want: []string{"b", "c", "d"}, gone: []string{"a"}},
+		{name: "use keeps entry", maxEntries: 3, puts: []string{"a", "b", "c"}, gets: []string{"a"}, last: "d", want: []string{"a", "c", "d"}, gone: []string{"b"}},
+		{name: "update keeps entry", maxEntries: 2, puts: []string{"a", "b", "a"}, last: "c", want: []string{"a", "c"}, gone: []string{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewRuleCache(nil, "", tt.maxEntries, time.Hour)
+			for _, key := range tt.puts {
+				c.put(key, "fix "+key, "java")
+			}
+			for _, key := range tt.gets {
+				if _, ok := c.get(key); !ok {
+					t.Fatalf("get(%q) missed", key)
+				}
+			}
+			c.put(tt.last, "fix "+tt.last, "java")
+
+			for _, key := range tt.want {
+				if got, ok := c.get(key); !ok || got != "fix "+key {
+					t.Errorf("get(%q) = %q, %v", key, got, ok)
+				}
+			}
+			for _, key := range tt.gone {
+				if _, ok := c.get(key); ok {
+					t.Errorf("get(%q) hit, want evicted", key)
+				}
+			}
+			if c.Len() != len(tt.want) {
+				t.Errorf("Len() = %d, want %d", c.Len(), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestRuleCacheTTL(t *testing.T) {
+	c := NewRuleCache(nil, "", 0, time.Hour)
+	c.put("fresh", "fix", "java")
+	c.put("stale", "fix", "java")
+	c.entries["stale"].Value.(*ruleCacheEntry).FetchedAt = time.Now().Add(-2 * time.Hour)
+
+	if _, ok := c.get("fresh"); !ok {
+		t.Error("fresh entry missed")
+	}
+	if _, ok := c.get("stale"); ok {
+		t.Error("stale entry hit")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want the stale entry dropped", c.Len())
+	}
+}
+
+func TestRuleCachePersistence(t *testing.T) {
+	dir := t.TempDir()
+
+	c := NewRuleCache(nil, dir, 10, time.Hour)
+	c.put("old", "fix old", "java")
+	c.put("stale", "fix stale", "java")
+	c.put("new", "fix new", "go")
+	c.entries["stale"].Value.(*ruleCacheEntry).FetchedAt = time.Now().Add(-2 * time.Hour)
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() = %v", err)
+	}
+
+	loaded := NewRuleCache(nil, dir, 10, time.Hour)
+	if loaded.Len() != 2 {
+		t.Fatalf("loaded %d entries, want 2", loaded.Len())
+	}
+	if got := loaded.Language("new"); got != "go" {
+		t.Errorf("Language(new) = %q, want go", got)
+	}
+	// The most recently used entry stays first
+	if front := loaded.order.Front().Value.(*ruleCacheEntry).Key; front != "new" {
+		t.Errorf("most recently used = %q, want new", front)
+	}
+}
+
+func TestRuleCacheConcurrentSaves(t *testing.T) {
+	dir := t.TempDir()
+	c := NewRuleCache(nil, dir, 0, time.Hour)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.mu.Lock()
+			c.put(fmt.Sprintf("rule-%d", i), "fix", "java")
+			c.mu.Unlock()
+			if err := c.Save(); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Save() = %v", err)
+	}
+
+	loaded := NewRuleCache(nil, dir, 0, time.Hour)
+	if loaded.Len() != 20 {
+		t.Errorf("loaded %d entries, want 20", loaded.Len())
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || entries[0].Name() != ruleCacheFileName {
+		t.Errorf("files left: %v", entries)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ruleCacheFileName)); err != nil {
+		t.Error(err)
+	}
+}
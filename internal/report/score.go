@@ -0,0 +1,353 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// DefaultTopIssues is the size of the "top things to fix" list when not configured
+const DefaultTopIssues = 10
+
+// ScoreWeights weigh the factors of an issue's risk score. Each factor is
+// normalised to 0..1; the score is their weighted average scaled to 0..100.
+type ScoreWeights struct {
+	Severity float64 `json:"severity"` // severity and impact severity
+	Type     float64 `json:"type"`     // vulnerabilities over bugs over code smells
+	Age      float64 `json:"age"`      // older issues, up to 90 days
+	Hotness  float64 `json:"hotness"`  // files with many issues
+	Effort   float64 `json:"effort"`   // quick wins, up to one day of effort
+
+	// LineCoverage favours issues on lines tests do not cover, from the
+	// line and condition hits of the issue's text range
+	LineCoverage float64 `json:"lineCoverage"`
+}
+
+// DefaultScoreWeights returns the weights used when none are configured
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{
+		Severity: 0.35,
+		Type:     0.15,
+		Age:      0.10,
+		Hotness:  0.15,
+		Effort:   0.10,
+
+		LineCoverage: 0.15,
+	}
+}
+
+// ParseScoreWeights parses weights such as "severity=0.5,effort=0". Factors
+// that are not given keep their default weight.
+func ParseScoreWeights(s string) (ScoreWeights, error) {
+	w := DefaultScoreWeights()
+	if strings.TrimSpace(s) == "" {
+		return w, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return w, fmt.Errorf("invalid score weight %q, expected factor=weight", part)
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return w, fmt.Errorf("invalid score weight %q: %w", part, err)
+		}
+		if !validWeight(f) {
+			return w, fmt.Errorf("invalid score weight %q, weights must be finite and not negative", part)
+		}
+		switch strings.TrimSpace(name) {
+		case "severity":
+			w.Severity = f
+		case "type":
+			w.Type = f
+		case "age":
+			w.Age = f
+		case "hotness":
+			w.Hotness = f
+		case "lineCoverage":
+			w.LineCoverage = f
+		case "effort":
+			w.Effort = f
+		default:
+			return w, fmt.Errorf("unknown score factor %q", name)
+		}
+	}
+
+	return w, w.Validate()
+}
+
+// Validate checks that weights are finite, not negative and not all zero
+func (w ScoreWeights) Validate() error {
+	for _, f := range []float64{w.Severity, w.Type, w.Age, w.Hotness, w.LineCoverage, w.Effort} {
+		if !validWeight(f) {
+			return fmt.Errorf("score weights must be finite and not negative")
+		}
+	}
+	if w.total() == 0 {
+		return fmt.Errorf("at least one score weight must be positive")
+	}
+	return nil
+}
+
+func validWeight(f float64) bool {
+	return f >= 0 && !math.IsInf(f, 1)
+}
+
+func (w ScoreWeights) total() float64 {
+	return w.Severity + w.Type + w.Age + w.Hotness + w.LineCoverage + w.Effort
+}
+
+// String describes the weights as shares of the score, e.g. "severity 35%"
+func (w ScoreWeights) String() string {
+	return NewLocale(DefaultLanguage, DefaultTimezone).ScoreWeights(w)
+}
+
+// ScoringInfo records how the issue scores of a report were computed
+type ScoringInfo struct {
+	Weights ScoreWeights `json:"weights"`
+	TopN    int          `json:"topN"`
+
+	// CoverageKnown is false when line coverage was not available and the
+	// coverage factor was neutral for all issues
+	CoverageKnown bool `json:"coverageKnown"`
+}
+
+// issueScorer computes risk scores of the issues of one report
+type issueScorer struct {
+	weights  ScoreWeights
+	now      time.Time
+	coverage map[string]map[int]sonarqube.LineCoverage // component key -> coverage by line
+	perFile  map[string]int
+	maxCount int
+
+	coverageKnown bool // some issue had lines that can be covered
+}
+
+func newIssueScorer(items []IssueItem, coverage map[string]map[int]sonarqube.LineCoverage, weights ScoreWeights, now time.Time) *issueScorer {
+	s := &issueScorer{
+		weights:  weights,
+		now:      now,
+		coverage: coverage,
+		perFile:  make(map[string]int),
+	}
+	for _, item := range items {
+		s.perFile[item.Component]++
+		if s.perFile[item.Component] > s.maxCount {
+			s.maxCount = s.perFile[item.Component]
+		}
+	}
+	return s
+}
+
+// score returns the risk score (0..100) of an issue
+func (s *issueScorer) score(item IssueItem, issue sonarqube.Issue) float64 {
+	w := s.weights
+	sum := w.Severity*severityFactor(issue) +
+		w.Type*typeFactor(issue.Type) +
+		w.Age*s.ageFactor(issue.CreationDate) +
+		w.Hotness*s.hotnessFactor(item.Component) +
+		w.LineCoverage*s.lineCoverageFactor(issue) +
+		w.Effort*effortFactor(issue.Effort)
+	return math.Round(sum/w.total()*1000) / 10
+}
+
+var severityFactors = map[string]float64{
+	"BLOCKER": 1, "CRITICAL": 0.75, "HIGH": 0.75, "MAJOR": 0.5, "MEDIUM": 0.5,
+	"MINOR": 0.25, "LOW": 0.25, "INFO": 0,
+}
+
+// severityFactor takes the highest of the severity and impact severities
+func severityFactor(issue sonarqube.Issue) float64 {
+	f := severityFactors[issue.Severity]
+	for _, impact := range issue.Impacts {
+		if v := severityFactors[impact.Severity]; v > f {
+			f = v
+		}
+	}
+	return f
+}
+
+func typeFactor(issueType string) float64 {
+	switch issueType {
+	case "VULNERABILITY":
+		return 1
+	case "BUG":
+		return 0.75
+	default:
+		return 0.25
+	}
+}
+
+func (s *issueScorer) ageFactor(creationDate sonarqube.DateTime) float64 {
+	if creationDate.IsZero() {
+		return 0
+	}
+	return math.Min(math.Max(s.now.Sub(creationDate.Time).Hours()/24/90, 0), 1)
+}
+
+func (s *issueScorer) hotnessFactor(file string) float64 {
+	if s.maxCount == 0 {
+		return 0
+	}
+	return float64(s.perFile[file]) / float64(s.maxCount)
+}
+
+// lineCoverageFactor is the share of the lines and conditions of the issue's
+// text range that tests do not cover. It is neutral for issues without lines
+// and when none of their lines can be covered.
+func (s *issueScorer) lineCoverageFactor(issue sonarqube.Issue) float64 {
+	from, to := issueLines(issue)
+	lines, ok := s.coverage[issue.Component]
+	if !ok || from == 0 {
+		return 0.5
+	}
+
+	coverable, covered := 0, 0
+	for n := from; n <= to; n++ {
+		l, ok := lines[n]
+		if !ok {
+			continue
+		}
+		if l.LineHits != nil {
+			coverable++
+			if *l.LineHits > 0 {
+				covered++
+			}
+		}
+		coverable += l.Conditions
+		covered += l.CoveredConditions
+	}
+	if coverable == 0 {
+		return 0.5
+	}
+	s.coverageKnown = true
+	return 1 - float64(covered)/float64(coverable)
+}
+
+// issueLines returns the first and last line of an issue's text range, zero
+// for issues on a whole file
+func issueLines(issue sonarqube.Issue) (int, int) {
+	if r := issue.TextRange; r != nil && r.StartLine > 0 {
+		return r.StartLine, max(r.EndLine, r.StartLine)
+	}
+	return issue.Line, issue.Line
+}
+
+func effortFactor(effort string) float64 {
+	if effort == "" {
+		return 0.5
+	}
+	return 1 - math.Min(float64(effortMinutes(effort))/480, 1)
+}
+
+// scoreWeights returns the weights of issue scores to use
+func (o GenerateOptions) scoreWeights() ScoreWeights {
+	if o.ScoreWeights != nil {
+		return *o.ScoreWeights
+	}
+	return DefaultScoreWeights()
+}
+
+// topIssues returns the n highest scored issues
+func topIssues(items []IssueItem, n int) []IssueItem {
+	sorted := make([]IssueItem, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Score != sorted[j].Score {
+			return sorted[i].Score > sorted[j].Score
+		}
+		return SeverityOrder(sorted[i].Severity) < SeverityOrder(sorted[j].Severity)
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// fetchLineCoverage returns the line coverage of the files with issues by
+// component key. Files whose coverage cannot be read are left out.
+func fetchLineCoverage(ctx context.Context, sources *sourceCache, issues []sonarqube.Issue) map[string]map[int]sonarqube.LineCoverage {
+	components := make(chan string)
+	go func() {
+		defer close(components)
+		seen := make(map[string]bool)
+		for _, issue := range issues {
+			if seen[issue.Component] {
+				continue
+			}
+			seen[issue.Component] = true
+			select {
+			case components <- issue.Component:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	const numWorkers = 5 // Limit concurrent API calls
+	coverage := make(map[string]map[int]sonarqube.LineCoverage)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for component := range components {
+				lines, err := sources.lineCoverage(component)
+				if err != nil {
+					log.Printf("Warning: Failed to get line coverage of %s: %v", component, err)
+					continue
+				}
+				mu.Lock()
+				coverage[component] = lines
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return coverage
+}
+
+// scoreIssues sets the risk score of each issue item and lists the top scored
+// issues. Current line coverage does not describe the past, so the coverage
+// factor is neutral in historical reports.
+func (g *Generator) scoreIssues(ctx context.Context, reportData *ReportData, src *reportSource, items []IssueItem, sources *sourceCache, options GenerateOptions) error {
+	weights := options.scoreWeights()
+	topN := options.TopIssues
+	if topN <= 0 {
+		topN = DefaultTopIssues
+	}
+
+	now := time.Now()
+	var coverage map[string]map[int]sonarqube.LineCoverage
+	if !src.asOf.IsZero() {
+		now = src.asOf
+	} else if weights.LineCoverage > 0 && len(items) > 0 {
+		coverage = fetchLineCoverage(ctx, sources, src.issues[:len(items)])
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	scorer := newIssueScorer(items, coverage, weights, now)
+	for i := range items {
+		items[i].Score = scorer.score(items[i], src.issues[i])
+	}
+
+	reportData.Scoring = &ScoringInfo{
+		Weights:       weights,
+		TopN:          topN,
+		CoverageKnown: scorer.coverageKnown,
+	}
+	reportData.TopIssues = topIssues(items, topN)
+	return nil
+}
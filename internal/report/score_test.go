@@ -0,0 +1,135 @@
+package report
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+func TestParseScoreWeights(t *testing.T) {
+	defaults := DefaultScoreWeights()
+	tests := []struct {
+		in      string
+		want    func(w *ScoreWeights)
+		wantErr string
+	}{
+		{in: ""},
+		{in: "  "},
+		{in: "severity=0.5,effort=0", want: func(w *ScoreWeights) { w.Severity, w.Effort = 0.5, 0 }},
+		{in: " age = 1 , hotness=2 ", want: func(w *ScoreWeights) { w.Age, w.Hotness = 1, 2 }},
+		{in: "lineCoverage=0.3", want: func(w *ScoreWeights) { w.LineCoverage = 0.3 }},
+		{in: "coverage=0.3", wantErr: "unknown score factor"},
+		{in: "fileCoverage=0.3", wantErr: "unknown score factor"},
+		{in: "severity", wantErr: "expected factor=weight"},
+		{in: "severity=high", wantErr: "invalid score weight"},
+		{in: "severity=-1", wantErr: "finite and not negative"},
+		{in: "severity=NaN", wantErr: "finite and not negative"},
+		{in: "severity=Inf", wantErr: "finite and not negative"},
+		{in: "severity=-Inf", wantErr: "finite and not negative"},
+		{in: "luck=1", wantErr: "unknown score factor"},
+		{in: "severity=0,type=0,age=0,hotness=0,lineCoverage=0,effort=0", wantErr: "at least one"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseScoreWeights(tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseScoreWeights(%q) = %v, want error containing %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseScoreWeights(%q) = %v", tt.in, err)
+			}
+			want := defaults
+			if tt.want != nil {
+				tt.want(&want)
+			}
+			if got != want {
+				t.Fatalf("ParseScoreWeights(%q) = %+v, want %+v", tt.in, got, want)
+			}
+		})
+	}
+}
+
+func TestScoreWeightsUnmarshal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ScoreWeights
+	}{
+		{in: `{"severity": 1, "lineCoverage": 0.5}`, want: ScoreWeights{Severity: 1, LineCoverage: 0.5}},
+		{in: `{"severity": 1, "coverage": 0.5}`, want: ScoreWeights{Severity: 1}},
+		{in: `{"effort": 1}`, want: ScoreWeights{Effort: 1}},
+	}
+	for _, tt := range tests {
+		var got ScoreWeights
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Fatalf("unmarshal %s: %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("unmarshal %s = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLineCoverageFactor(t *testing.T) {
+	hits := func(n int) *int { return &n }
+	s := &issueScorer{coverage: map[string]map[int]sonarqube.LineCoverage{
+		"p:a.go": {
+			1: {Line: 1},
+			2: {Line: 2, LineHits: hits(3)},
+			3: {Line: 3, LineHits: hits(0)},
+			4: {Line: 4, LineHits: hits(1), Conditions: 4, CoveredConditions: 1},
+			5: {Line: 5},
+		},
+	}}
+	tests := []struct {
+		name  string
+		issue sonarqube.Issue
+		want  float64
+		known bool
+	}{
+		{name: "covered line", issue: sonarqube.Issue{Component: "p:a.go", Line: 2}, want: 0, known: true},
+		{name: "uncovered line", issue: sonarqube.Issue{Component: "p:a.go", Line: 3}, want: 1, known: true},
+		{name: "text range", issue: sonarqube.Issue{Component: "p:a.go", Line: 2, TextRange: &sonarqube.TextRange{StartLine: 2, EndLine: 3}}, want: 0.5, known: true},
+		{name: "conditions", issue: sonarqube.Issue{Component: "p:a.go", Line: 4}, want: 0.6, known: true},
+		{name: "range with conditions", issue: sonarqube.Issue{Component: "p:a.go", TextRange: &sonarqube.TextRange{StartLine: 1, EndLine: 5}}, want: 4.0 / 7, known: true},
+		{name: "line not coverable", issue: sonarqube.Issue{Component: "p:a.go", Line: 1}, want: 0.5},
+		{name: "whole file", issue: sonarqube.Issue{Component: "p:a.go"}, want: 0.5},
+		{name: "no coverage of file", issue: sonarqube.Issue{Component: "p:b.go", Line: 2}, want: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.coverageKnown = false
+			if got := s.lineCoverageFactor(tt.issue); math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("lineCoverageFactor = %v, want %v", got, tt.want)
+			}
+			if s.coverageKnown != tt.known {
+				t.Fatalf("coverageKnown = %v, want %v", s.coverageKnown, tt.known)
+			}
+		})
+	}
+}
+
+func TestScoreWeightsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights ScoreWeights
+		wantErr bool
+	}{
+		{name: "defaults", weights: DefaultScoreWeights()},
+		{name: "one factor", weights: ScoreWeights{Effort: 1}},
+		{name: "all zero", weights: ScoreWeights{}, wantErr: true},
+		{name: "negative", weights: ScoreWeights{Severity: 1, Age: -0.1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.weights.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SnapshotVersion is the current version of the stored ReportData format.
+// Bump it when ReportData changes incompatibly and migrate older snapshots
+// in migrateSnapshot.
+const SnapshotVersion = 1
+
+// Snapshot is the structured data a report was rendered from, stored next to
+// the rendered file so it can be re-rendered, compared and queried later
+type Snapshot struct {
+	Version  int         `json:"version"`
+	ReportID string      `json:"reportId"`
+	SavedAt  time.Time   `json:"savedAt"`
+	Data     *ReportData `json:"data"`
+}
+
+// encodeSnapshot serializes report data as a snapshot of the current version
+func encodeSnapshot(reportID string, data *ReportData) ([]byte, error) {
+	return json.Marshal(Snapshot{
+		Version:  SnapshotVersion,
+		ReportID: reportID,
+		SavedAt:  time.Now(),
+		Data:     data,
+	})
+}
+
+// decodeSnapshot parses a stored snapshot, upgrading older versions
+func decodeSnapshot(raw []byte) (*Snapshot, error) {
+	var snapshot Snapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+
+	if snapshot.Version > SnapshotVersion {
+		return nil, fmt.Errorf("snapshot version %d is newer than supported version %d", snapshot.Version, SnapshotVersion)
+	}
+	if snapshot.Data == nil {
+		return nil, fmt.Errorf("snapshot has no report data")
+	}
+
+	migrateSnapshot(&snapshot)
+	return &snapshot, nil
+}
+
+// migrateSnapshot upgrades a snapshot to SnapshotVersion in place
+func migrateSnapshot(snapshot *Snapshot) {
+	// Version 1 is the first stored format, nothing to migrate yet
+	snapshot.Version = SnapshotVersion
+}
+
+// IssueFilter selects issues from report data. Empty fields match everything.
+type IssueFilter struct {
+	Severity  string
+	Type      string
+	Rule      string
+	Component string // substring of the component key
+}
+
+// FilterIssues returns the issues matching f, ordered by severity
+func (d *ReportData) FilterIssues(f IssueFilter) []IssueItem {
+	result := []IssueItem{}
+	for _, severity := range []string{"BLOCKER", "CRITICAL", "MAJOR", "MINOR", "INFO"} {
+		if f.Severity != "" && !strings.EqualFold(f.Severity, severity) {
+			continue
+		}
+		for _, issue := range d.IssuesBySeverity[severity] {
+			if f.Type != "" && !strings.EqualFold(f.Type, issue.Type) {
+				continue
+			}
+			if f.Rule != "" && f.Rule != issue.Rule {
+				continue
+			}
+			if f.Component != "" && !strings.Contains(issue.Component, f.Component) {
+				continue
+			}
+			result = append(result, issue)
+		}
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		wantMinutes int
+		wantErr     string
+	}{
+		{
+			name:        "current version keeps minutes",
+			raw:         `{"version": 1, "reportId": "r", "data": {"metrics": {"technicalDebt": "1d", "technicalDebtMinutes": 500}}}`,
+			wantMinutes: 500,
+		},
+		{
+			name: "unversioned",
+			raw:  `{"reportId": "r", "data": {"metrics": {}}}`,
+		},
+		{name: "newer version", raw: `{"version": 2, "data": {}}`, wantErr: "newer than supported"},
+		{name: "no data", raw: `{"version": 1}`, wantErr: "no report data"},
+		{name: "not json", raw: `{`, wantErr: "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, err := decodeSnapshot([]byte(tt.raw))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("decodeSnapshot() = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeSnapshot() = %v", err)
+			}
+			if snapshot.Version != SnapshotVersion {
+				t.Errorf("version = %d, want %d", snapshot.Version, SnapshotVersion)
+			}
+			if got := snapshot.Data.Metrics.TechnicalDebtMinutes; got != tt.wantMinutes {
+				t.Errorf("technical debt = %d minutes, want %d", got, tt.wantMinutes)
+			}
+		})
+	}
+}
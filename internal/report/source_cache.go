@@ -0,0 +1,116 @@
+package report
+
+import (
+	"sort"
+	"sync"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// sourceCache keeps whole-file sources and line coverage of one branch for
+// the lifetime of a single generation so each component is fetched from
+// SonarQube at most once
+type sourceCache struct {
+	client   *sonarqube.Client
+	branch   string // branch or pull request the sources are read from
+	mu       sync.Mutex
+	entries  map[string]*sourceEntry
+	coverage map[string]*coverageEntry
+}
+
+// sourceEntry holds the fetch result for one component
+type sourceEntry struct {
+	once  sync.Once
+	lines []sonarqube.SourceLine
+	err   error
+}
+
+// coverageEntry holds the line coverage fetch result for one component
+type coverageEntry struct {
+	once  sync.Once
+	lines map[int]sonarqube.LineCoverage
+	err   error
+}
+
+// newSourceCache creates an empty source cache for a branch
+func newSourceCache(client *sonarqube.Client, branch string) *sourceCache {
+	return &sourceCache{
+		client:   client,
+		branch:   branch,
+		entries:  make(map[string]*sourceEntry),
+		coverage: make(map[string]*coverageEntry),
+	}
+}
+
+// file returns all lines of a component, fetching it on first use.
+// Concurrent callers for the same component wait for a single request.
+func (c *sourceCache) file(component string) ([]sonarqube.SourceLine, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[component]
+	if !ok {
+		entry = &sourceEntry{}
+		c.entries[component] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		lines, err := c.client.GetSourceFile(component, c.branch)
+		if err != nil {
+			entry.err = err
+			return
+		}
+		// Keep lines ordered so ranges can be sliced with a binary search
+		sort.Slice(lines, func(i, j int) bool {
+			return lines[i].Line < lines[j].Line
+		})
+		entry.lines = lines
+	})
+
+	return entry.lines, entry.err
+}
+
+// lines returns the lines of a component between fromLine and toLine (inclusive)
+func (c *sourceCache) lines(component string, fromLine, toLine int) ([]sonarqube.SourceLine, error) {
+	all, err := c.file(component)
+	if err != nil {
+		return nil, err
+	}
+
+	start := sort.Search(len(all), func(i int) bool {
+		return all[i].Line >= fromLine
+	})
+	end := sort.Search(len(all), func(i int) bool {
+		return all[i].Line > toLine
+	})
+	if start >= end {
+		return nil, nil
+	}
+
+	return all[start:end], nil
+}
+
+// lineCoverage returns the coverage of the lines of a component by line
+// number, fetching it on first use
+func (c *sourceCache) lineCoverage(component string) (map[int]sonarqube.LineCoverage, error) {
+	c.mu.Lock()
+	entry, ok := c.coverage[component]
+	if !ok {
+		entry = &coverageEntry{}
+		c.coverage[component] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		lines, err := c.client.GetLineCoverage(component, c.branch)
+		if err != nil {
+			entry.err = err
+			return
+		}
+		entry.lines = make(map[int]sonarqube.LineCoverage, len(lines))
+		for _, l := range lines {
+			entry.lines[l.Line] = l
+		}
+	})
+
+	return entry.lines, entry.err
+}
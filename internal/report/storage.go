@@ -40,23 +40,26 @@ func NewStorage(basePath string) (*Storage, error) {
 	return s, nil
 }
 
+// BasePath returns the directory reports are stored in
+func (s *Storage) BasePath() string {
+	return s.basePath
+}
+
 // Save saves a report and returns the record
-func (s *Storage) Save(data *ReportData, content []byte, format string) (*ReportRecord, error) {
+func (s *Storage) Save(data *ReportData, content []byte, format string, opts SaveOptions) (*ReportRecord, error) {
+	renderer, ok := RendererFor(format)
+	if !ok {
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	id := uuid.New().String()[:8]
 	timestamp := time.Now().Format("20060102-150405")
 
-	var ext string
-	switch format {
-	case "pdf":
-		ext = "pdf"
-	default:
-		ext = "md"
-	}
-
-	fileName := fmt.Sprintf("%s-%s-%s.%s", data.ProjectKey, timestamp, id, ext)
+	baseName := fmt.Sprintf("%s-%s-%s", data.ProjectKey, timestamp, id)
+	fileName := baseName + "." + renderer.Format().Extension
 	filePath := filepath.Join(s.basePath, fileName)
 
 	// Write file
@@ -64,6 +67,35 @@ func (s *Storage) Save(data *ReportData, content []byte, format string) (*Report
 		return nil, fmt.Errorf("failed to write report file: %w", err)
 	}
 
+	// Write the data snapshot next to it
+	snapshot, err := encodeSnapshot(id, data)
+	if err != nil {
+		os.Remove(filePath)
+		return nil, fmt.Errorf("failed to encode report snapshot: %w", err)
+	}
+	snapshotPath := filepath.Join(s.basePath, baseName+".data.json")
+	if err := os.WriteFile(snapshotPath, snapshot, 0644); err != nil {
+		os.Remove(filePath)
+		return nil, fmt.Errorf("failed to write report snapshot: %w", err)
+	}
+
+	// Comparisons also keep the data of the other side
+	var baseSnapshotPath string
+	if opts.CompareWith != nil {
+		baseSnapshot, err := encodeSnapshot(id, opts.CompareWith)
+		if err != nil {
+			os.Remove(filePath)
+			os.Remove(snapshotPath)
+			return nil, fmt.Errorf("failed to encode base snapshot: %w", err)
+		}
+		baseSnapshotPath = filepath.Join(s.basePath, baseName+".base.data.json")
+		if err := os.WriteFile(baseSnapshotPath, baseSnapshot, 0644); err != nil {
+			os.Remove(filePath)
+			os.Remove(snapshotPath)
+			return nil, fmt.Errorf("failed to write base snapshot: %w", err)
+		}
+	}
+
 	// Create record
 	record := ReportRecord{
 		ID:          id,
@@ -75,6 +107,24 @@ func (s *Storage) Save(data *ReportData, content []byte, format string) (*Report
 		FilePath:    filePath,
 		FileSize:    int64(len(content)),
 		GeneratedAt: time.Now(),
+
+		SnapshotPath:  snapshotPath,
+		GenerationKey: opts.GenerationKey,
+		SourceID:      opts.SourceID,
+		Language:      data.Language,
+		Timezone:      data.Timezone,
+	}
+	if data.Activity != nil {
+		record.Kind = ReportKindActivity
+	}
+	if data.Historical != nil {
+		asOf := data.Historical.AsOf
+		record.AsOf = &asOf
+	}
+	if opts.CompareWith != nil {
+		record.Kind = ReportKindComparison
+		record.BaseBranch = opts.CompareWith.Branch
+		record.BaseSnapshotPath = baseSnapshotPath
 	}
 
 	// Add to records
@@ -112,6 +162,65 @@ func (s *Storage) GetRecord(id string) (*ReportRecord, error) {
 	return nil, fmt.Errorf("report not found: %s", id)
 }
 
+// LoadSnapshot returns the report data a stored report was rendered from
+func (s *Storage) LoadSnapshot(id string) (*Snapshot, error) {
+	record, err := s.GetRecord(id)
+	if err != nil {
+		return nil, err
+	}
+	if record.SnapshotPath == "" {
+		return nil, fmt.Errorf("report %s has no stored data", id)
+	}
+
+	raw, err := os.ReadFile(record.SnapshotPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report snapshot: %w", err)
+	}
+
+	return decodeSnapshot(raw)
+}
+
+// LoadBaseSnapshot returns the base branch data of a branch comparison report
+func (s *Storage) LoadBaseSnapshot(id string) (*Snapshot, error) {
+	record, err := s.GetRecord(id)
+	if err != nil {
+		return nil, err
+	}
+	if record.BaseSnapshotPath == "" {
+		return nil, fmt.Errorf("report %s is not a branch comparison", id)
+	}
+
+	raw, err := os.ReadFile(record.BaseSnapshotPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read base snapshot: %w", err)
+	}
+
+	return decodeSnapshot(raw)
+}
+
+// FindByGenerationKey returns the newest report built from the given
+// generation key whose file still exists
+func (s *Storage) FindByGenerationKey(key string) (*ReportRecord, bool) {
+	if key == "" {
+		return nil, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Records are kept sorted newest first
+	for _, r := range s.records {
+		if r.GenerationKey != key {
+			continue
+		}
+		if _, err := os.Stat(r.FilePath); err != nil {
+			continue
+		}
+		return &r, true
+	}
+	return nil, false
+}
+
 // GetFilePath returns the file path for a report
 func (s *Storage) GetFilePath(id string) (string, error) {
 	record, err := s.GetRecord(id)
@@ -128,8 +237,8 @@ func (s *Storage) Delete(id string) error {
 
 	for i, r := range s.records {
 		if r.ID == id {
-			// Delete file
-			os.Remove(r.FilePath)
+			// Delete files
+			removeRecordFiles(r)
 
 			// Remove from records
 			s.records = append(s.records[:i], s.records[i+1:]...)
@@ -147,7 +256,7 @@ func (s *Storage) ClearAll() error {
 	defer s.mu.Unlock()
 
 	for _, r := range s.records {
-		os.Remove(r.FilePath)
+		removeRecordFiles(r)
 	}
 
 	s.records = []ReportRecord{}
@@ -166,7 +275,7 @@ func (s *Storage) CleanOld(days int) error {
 		if r.GeneratedAt.After(cutoff) {
 			newRecords = append(newRecords, r)
 		} else {
-			os.Remove(r.FilePath)
+			removeRecordFiles(r)
 		}
 	}
 
@@ -174,6 +283,17 @@ func (s *Storage) CleanOld(days int) error {
 	return s.saveRecords()
 }
 
+// removeRecordFiles deletes the rendered file and snapshot of a record
+func removeRecordFiles(r ReportRecord) {
+	os.Remove(r.FilePath)
+	if r.SnapshotPath != "" {
+		os.Remove(r.SnapshotPath)
+	}
+	if r.BaseSnapshotPath != "" {
+		os.Remove(r.BaseSnapshotPath)
+	}
+}
+
 func (s *Storage) loadRecords() error {
 	indexPath := filepath.Join(s.basePath, "index.json")
 
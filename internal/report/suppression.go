@@ -0,0 +1,223 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+// SuppressionList is a set of known issues hidden from reports, for teams
+// that cannot change issue statuses on the SonarQube server
+type SuppressionList struct {
+	Suppressions []Suppression `json:"suppressions"`
+}
+
+// AllProjects is the project key of suppressions that apply to every project
+const AllProjects = "*"
+
+// Suppression hides issues of one project, or of all projects with
+// AllProjects, by key, by rule and/or path glob, or by fingerprint. Every
+// suppression needs a reason and expires at the end of its expiry date (UTC).
+type Suppression struct {
+	ProjectKey  string `json:"projectKey"`            // project key, * for all projects
+	Issue       string `json:"issue,omitempty"`       // issue key
+	Rule        string `json:"rule,omitempty"`        // rule key, e.g. go:S1192
+	Path        string `json:"path,omitempty"`        // file path glob, ** matches directories
+	Fingerprint string `json:"fingerprint,omitempty"` // issue fingerprint from report data
+	Reason      string `json:"reason"`
+	Expires     string `json:"expires"` // YYYY-MM-DD
+
+	expiresAt time.Time
+	path      *regexp.Regexp
+}
+
+// SuppressionInfo records what a report hides, so nothing is hidden silently
+type SuppressionInfo struct {
+	Issues []SuppressedIssue `json:"issues"`
+
+	// Expired lists suppressions past their expiry, no longer applied
+	Expired []Suppression `json:"expired,omitempty"`
+}
+
+// SuppressedIssue is an issue left out of a report and why
+type SuppressedIssue struct {
+	IssueItem
+	Reason  string `json:"reason"`
+	Expires string `json:"expires"`
+}
+
+// LoadSuppressions reads a suppression file. An empty file name yields an
+// empty list.
+func LoadSuppressions(file string) (*SuppressionList, error) {
+	list := &SuppressionList{}
+	if file == "" {
+		return list, nil
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suppression file: %w", err)
+	}
+	if err := json.Unmarshal(raw, list); err != nil {
+		return nil, fmt.Errorf("failed to parse suppression file: %w", err)
+	}
+	for i := range list.Suppressions {
+		if err := list.Suppressions[i].compile(); err != nil {
+			return nil, fmt.Errorf("invalid suppression file: suppression %d: %w", i+1, err)
+		}
+	}
+
+	return list, nil
+}
+
+func (s *Suppression) compile() error {
+	if strings.TrimSpace(s.ProjectKey) == "" {
+		return fmt.Errorf("a projectKey is required, %q for all projects", AllProjects)
+	}
+
+	byKey := s.Issue != ""
+	byRule := s.Rule != "" || s.Path != ""
+	byFingerprint := s.Fingerprint != ""
+	switch {
+	case !byKey && !byRule && !byFingerprint:
+		return fmt.Errorf("one of issue, rule/path or fingerprint is required")
+	case byKey && (byRule || byFingerprint), byRule && byFingerprint:
+		return fmt.Errorf("issue, rule/path and fingerprint cannot be combined")
+	}
+
+	if strings.TrimSpace(s.Reason) == "" {
+		return fmt.Errorf("a reason is required")
+	}
+	if s.Expires == "" {
+		return fmt.Errorf("an expiry date is required")
+	}
+	day, err := time.Parse("2006-01-02", s.Expires)
+	if err != nil {
+		return fmt.Errorf("invalid expiry date %q, expected YYYY-MM-DD", s.Expires)
+	}
+	s.expiresAt = day.Add(24*time.Hour - time.Second)
+
+	if s.Path != "" {
+		s.path = globToRegexp(s.Path)
+	}
+	return nil
+}
+
+// Version identifies the suppressions of a project in force at a time, which
+// change as the file is edited and as suppressions expire
+func (l *SuppressionList) Version(projectKey string, now time.Time) string {
+	h := sha256.New()
+	for i := range l.Suppressions {
+		if s := &l.Suppressions[i]; s.AppliesTo(projectKey) && !s.Expired(now) {
+			raw, _ := json.Marshal(s)
+			h.Write(raw)
+			h.Write([]byte{0})
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// AppliesTo reports whether the suppression covers a project
+func (s *Suppression) AppliesTo(projectKey string) bool {
+	return s.ProjectKey == AllProjects || s.ProjectKey == projectKey
+}
+
+// Expired reports whether the suppression is past its expiry date
+func (s *Suppression) Expired(now time.Time) bool {
+	return now.After(s.expiresAt)
+}
+
+// matches reports whether the suppression applies to an issue
+func (s *Suppression) matches(item IssueItem) bool {
+	switch {
+	case s.Issue != "":
+		return item.Key == s.Issue
+	case s.Fingerprint != "":
+		return item.Fingerprint == s.Fingerprint
+	}
+	if s.Rule != "" && item.Rule != s.Rule {
+		return false
+	}
+	return s.path == nil || s.path.MatchString(item.Component)
+}
+
+// apply splits the issues of a project into the ones to report and the
+// suppressed ones
+func (l *SuppressionList) apply(projectKey string, issues []sonarqube.Issue, now time.Time) ([]sonarqube.Issue, *SuppressionInfo) {
+	info := &SuppressionInfo{}
+	var active []*Suppression
+	for i := range l.Suppressions {
+		s := &l.Suppressions[i]
+		if !s.AppliesTo(projectKey) {
+			continue
+		}
+		if s.Expired(now) {
+			info.Expired = append(info.Expired, *s)
+			continue
+		}
+		active = append(active, s)
+	}
+	if len(active) == 0 {
+		return issues, info
+	}
+
+	kept := make([]sonarqube.Issue, 0, len(issues))
+	for _, issue := range issues {
+		item := newIssueItem(issue)
+		suppressed := false
+		for _, s := range active {
+			if s.matches(item) {
+				info.Issues = append(info.Issues, SuppressedIssue{IssueItem: item, Reason: s.Reason, Expires: s.Expires})
+				suppressed = true
+				break
+			}
+		}
+		if !suppressed {
+			kept = append(kept, issue)
+		}
+	}
+	sort.SliceStable(info.Issues, func(i, j int) bool {
+		return SeverityOrder(info.Issues[i].Severity) < SeverityOrder(info.Issues[j].Severity)
+	})
+	return kept, info
+}
+
+// globToRegexp converts a path glob into a regular expression: * and ?
+// match within a directory, ** across directories
+func globToRegexp(glob string) *regexp.Regexp {
+	return regexp.MustCompile("^" + globPattern(glob) + "$")
+}
+
+// globPattern converts a path glob into an unanchored regular expression
+func globPattern(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					b.WriteString("(.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"sonarqube-report-generator/internal/sonarqube"
+)
+
+func TestGlobPattern(t *testing.T) {
+	tests := []struct {
+		glob  string
+		path  string
+		match bool
+	}{
+		{glob: "src/*.go", path: "src/main.go", match: true},
+		{glob: "src/*.go", path: "src/cmd/main.go"},
+		{glob: "src/**", path: "src/cmd/main.go", match: true},
+		{glob: "src/**/*.go", path: "src/main.go", match: true},
+		{glob: "src/**/*.go", path: "src/a/b/main.go", match: true},
+		{glob: "src/**/*.go", path: "src/a/b/main.java"},
+		{glob: "**/test/*", path: "test/a.go", match: true},
+		{glob: "**/test/*", path: "pkg/test/a.go", match: true},
+		{glob: "file?.txt", path: "file1.txt", match: true},
+		{glob: "file?.txt", path: "file/.txt"},
+		{glob: "a.b", path: "axb"},
+		{glob: "a+(b)", path: "a+(b)", match: true},
+	}
+	for _, tt := range tests {
+		if got := globToRegexp(tt.glob).MatchString(tt.path); got != tt.match {
+			t.Errorf("glob %q on %q = %v, want %v", tt.glob, tt.path, got, tt.match)
+		}
+	}
+}
+
+func TestSuppressionCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       Suppression
+		wantErr string
+	}{
+		{name: "by key", s: Suppression{ProjectKey: "p", Issue: "AX", Reason: "r", Expires: "2026-12-31"}},
+		{name: "all projects", s: Suppression{ProjectKey: AllProjects, Rule: "go:S1", Reason: "r", Expires: "2026-12-31"}},
+		{name: "no project", s: Suppression{Issue: "AX", Reason: "r", Expires: "2026-12-31"}, wantErr: "projectKey is required"},
+		{name: "no target", s: Suppression{ProjectKey: "p", Reason: "r", Expires: "2026-12-31"}, wantErr: "is required"},
+		{name: "combined", s: Suppression{ProjectKey: "p", Issue: "AX", Rule: "go:S1", Reason: "r", Expires: "2026-12-31"}, wantErr: "cannot be combined"},
+		{name: "no reason", s: Suppression{ProjectKey: "p", Issue: "AX", Reason: " ", Expires: "2026-12-31"}, wantErr: "reason"},
+		{name: "no expiry", s: Suppression{ProjectKey: "p", Issue: "AX", Reason: "r"}, wantErr: "expiry date is required"},
+		{name: "bad expiry", s: Suppression{ProjectKey: "p", Issue: "AX", Reason: "r", Expires: "31/12/2026"}, wantErr: "invalid expiry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.s.compile()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("compile() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("compile() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSuppressionApply(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	list := &SuppressionList{Suppressions: []Suppression{
+		{ProjectKey: "pay", Issue: "k1", Reason: "accepted", Expires: "2026-12-31"},
+		{ProjectKey: "other", Rule: "go:S2", Reason: "other project", Expires: "2026-12-31"},
+		{ProjectKey: AllProjects, Path: "legacy/**", Reason: "legacy", Expires: "2026-12-31"},
+		{ProjectKey: "pay", Rule: "go:S3", Reason: "expired", Expires: "2026-09-30"},
+	}}
+	for i := range list.Suppressions {
+		if err := list.Suppressions[i].compile(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	issues := []sonarqube.Issue{
+		{Key: "k1", Rule: "go:S1", Component: "pay:main.go"},
+		{Key: "k2", Rule: "go:S2", Component: "pay:main.go"},
+		{Key: "k3", Rule: "go:S1", Component: "pay:legacy/old.go"},
+		{Key: "k4", Rule: "go:S3", Component: "pay:main.go"},
+	}
+
+	kept, info := list.apply("pay", issues, now)
+
+	var keys []string
+	for _, issue := range kept {
+		keys = append(keys, issue.Key)
+	}
+	if strings.Join(keys, ",") != "k2,k4" {
+		t.Errorf("kept %v, want k2 and k4", keys)
+	}
+	if len(info.Issues) != 2 {
+		t.Errorf("suppressed %d issues, want 2", len(info.Issues))
+	}
+	if len(info.Expired) != 1 || info.Expired[0].Reason != "expired" {
+		t.Errorf("expired = %+v, want the project's expired entry only", info.Expired)
+	}
+}
+
+func TestSuppressionListVersion(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	list := func(suppressions ...Suppression) *SuppressionList {
+		l := &SuppressionList{Suppressions: suppressions}
+		for i := range l.Suppressions {
+			if err := l.Suppressions[i].compile(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return l
+	}
+	pay := Suppression{ProjectKey: "pay", Issue: "k1", Reason: "r", Expires: "2026-12-31"}
+	other := Suppression{ProjectKey: "other", Issue: "k2", Reason: "r", Expires: "2026-12-31"}
+	global := Suppression{ProjectKey: AllProjects, Issue: "k3", Reason: "r", Expires: "2026-12-31"}
+	expired := Suppression{ProjectKey: "pay", Issue: "k4", Reason: "r", Expires: "2026-09-30"}
+
+	base := list(pay).Version("pay", now)
+	tests := []struct {
+		name string
+		list *SuppressionList
+		same bool
+	}{
+		{name: "other project's entry", list: list(pay, other), same: true},
+		{name: "expired entry", list: list(pay, expired), same: true},
+		{name: "global entry", list: list(pay, global)},
+		{name: "changed reason", list: list(Suppression{ProjectKey: "pay", Issue: "k1", Reason: "changed", Expires: "2026-12-31"})},
+		{name: "no entries", list: list()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.list.Version("pay", now) == base; got != tt.same {
+				t.Fatalf("same version = %v, want %v", got, tt.same)
+			}
+		})
+	}
+}
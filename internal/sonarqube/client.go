@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -123,13 +124,44 @@ func (c *Client) GetBranches(projectKey string) ([]Branch, error) {
 	return resp.Branches, nil
 }
 
+// PullRequestPrefix marks a branch argument that refers to a pull request,
+// e.g. "pr:42". Git branch names cannot contain a colon.
+const PullRequestPrefix = "pr:"
+
+// setBranch adds the branch or pull request parameter for a branch argument
+func setBranch(params url.Values, branch string) {
+	switch {
+	case branch == "":
+	case strings.HasPrefix(branch, PullRequestPrefix):
+		params.Set("pullRequest", strings.TrimPrefix(branch, PullRequestPrefix))
+	default:
+		params.Set("branch", branch)
+	}
+}
+
+// GetPullRequests returns all analysed pull requests for a project
+func (c *Client) GetPullRequests(projectKey string) ([]PullRequest, error) {
+	params := url.Values{}
+	params.Set("project", projectKey)
+
+	body, err := c.doRequest("GET", "/api/project_pull_requests/list", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp PullRequestsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse pull requests response: %w", err)
+	}
+
+	return resp.PullRequests, nil
+}
+
 // GetQualityGateStatus returns the quality gate status for a project
 func (c *Client) GetQualityGateStatus(projectKey, branch string) (*QualityGateStatus, error) {
 	params := url.Values{}
 	params.Set("projectKey", projectKey)
-	if branch != "" {
-		params.Set("branch", branch)
-	}
+	setBranch(params, branch)
 
 	body, err := c.doRequest("GET", "/api/qualitygates/project_status", params)
 	if err != nil {
@@ -149,9 +181,7 @@ func (c *Client) GetMeasures(projectKey, branch string, metricKeys []string) ([]
 	params := url.Values{}
 	params.Set("component", projectKey)
 	params.Set("metricKeys", strings.Join(metricKeys, ","))
-	if branch != "" {
-		params.Set("branch", branch)
-	}
+	setBranch(params, branch)
 
 	body, err := c.doRequest("GET", "/api/measures/component", params)
 	if err != nil {
@@ -166,8 +196,84 @@ func (c *Client) GetMeasures(projectKey, branch string, metricKeys []string) ([]
 	return resp.Component.Measures, nil
 }
 
+// GetFileMeasures returns measures of every file of a project
+func (c *Client) GetFileMeasures(projectKey, branch string, metricKeys []string) ([]ComponentMeasures, error) {
+	var files []ComponentMeasures
+	page := 1
+	pageSize := 500
+
+	for {
+		params := url.Values{}
+		params.Set("component", projectKey)
+		params.Set("metricKeys", strings.Join(metricKeys, ","))
+		params.Set("qualifiers", "FIL,UTS")
+		params.Set("ps", fmt.Sprintf("%d", pageSize))
+		params.Set("p", fmt.Sprintf("%d", page))
+		setBranch(params, branch)
+
+		body, err := c.doRequest("GET", "/api/measures/component_tree", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp ComponentTreeResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse component tree response: %w", err)
+		}
+
+		files = append(files, resp.Components...)
+
+		if len(resp.Components) < pageSize || page*pageSize >= resp.Paging.Total {
+			break
+		}
+		page++
+	}
+
+	return files, nil
+}
+
+// PageFunc is called after each page of a paginated fetch
+type PageFunc func(fetched, total int)
+
 // GetIssues returns issues for a project
 func (c *Client) GetIssues(projectKey, branch string, maxResults int) ([]Issue, int, error) {
+	return c.GetIssuesWithProgress(projectKey, branch, maxResults, nil)
+}
+
+// GetIssuesWithProgress returns unresolved issues for a project, calling onPage after each page
+func (c *Client) GetIssuesWithProgress(projectKey, branch string, maxResults int, onPage PageFunc) ([]Issue, int, error) {
+	resolved := false
+	return c.SearchIssues(IssueQuery{ProjectKey: projectKey, Branch: branch, Resolved: &resolved}, maxResults, onPage)
+}
+
+// Issue search sort fields
+const (
+	IssueSortCreationDate = "CREATION_DATE"
+	IssueSortUpdateDate   = "UPDATE_DATE"
+)
+
+// IssueQuery filters an issue search. Zero values do not filter.
+type IssueQuery struct {
+	ProjectKey string
+	Branch     string
+	Resolved   *bool // nil returns both open and resolved issues
+
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// Sort orders issues by a field such as IssueSortUpdateDate, newest
+	// first when Descending is set; empty keeps SonarQube's order
+	Sort       string
+	Descending bool
+
+	// Until stops the search after the page holding the first issue it
+	// returns true for, e.g. the first one updated before a date when
+	// sorted by update date
+	Until func(Issue) bool
+}
+
+// SearchIssues returns issues matching query, calling onPage after each page
+func (c *Client) SearchIssues(query IssueQuery, maxResults int, onPage PageFunc) ([]Issue, int, error) {
 	var allIssues []Issue
 	page := 1
 	pageSize := 100
@@ -175,15 +281,25 @@ func (c *Client) GetIssues(projectKey, branch string, maxResults int) ([]Issue,
 
 	for {
 		params := url.Values{}
-		params.Set("componentKeys", projectKey)
+		params.Set("componentKeys", query.ProjectKey)
 		params.Set("ps", fmt.Sprintf("%d", pageSize))
 		params.Set("p", fmt.Sprintf("%d", page))
-		params.Set("resolved", "false")
+		if query.Resolved != nil {
+			params.Set("resolved", strconv.FormatBool(*query.Resolved))
+		}
+		if !query.CreatedAfter.IsZero() {
+			params.Set("createdAfter", query.CreatedAfter.Format(DateTimeFormat))
+		}
+		if !query.CreatedBefore.IsZero() {
+			params.Set("createdBefore", query.CreatedBefore.Format(DateTimeFormat))
+		}
+		if query.Sort != "" {
+			params.Set("s", query.Sort)
+			params.Set("asc", strconv.FormatBool(!query.Descending))
+		}
 		// Request additional fields for more accurate location info
 		params.Set("additionalFields", "_all")
-		if branch != "" {
-			params.Set("branch", branch)
-		}
+		setBranch(params, query.Branch)
 
 		body, err := c.doRequest("GET", "/api/issues/search", params)
 		if err != nil {
@@ -198,7 +314,19 @@ func (c *Client) GetIssues(projectKey, branch string, maxResults int) ([]Issue,
 		total = resp.Total
 		allIssues = append(allIssues, resp.Issues...)
 
-		if len(allIssues) >= resp.Paging.Total || len(allIssues) >= maxResults {
+		if onPage != nil {
+			expected := total
+			if expected > maxResults {
+				expected = maxResults
+			}
+			fetched := len(allIssues)
+			if fetched > expected {
+				fetched = expected
+			}
+			onPage(fetched, expected)
+		}
+
+		if len(allIssues) >= resp.Paging.Total || len(allIssues) >= maxResults || query.reachedEnd(resp.Issues) {
 			break
 		}
 		page++
@@ -212,6 +340,19 @@ func (c *Client) GetIssues(projectKey, branch string, maxResults int) ([]Issue,
 	return allIssues, total, nil
 }
 
+// reachedEnd reports whether a page holds an issue the query stops at
+func (q IssueQuery) reachedEnd(issues []Issue) bool {
+	if q.Until == nil {
+		return false
+	}
+	for _, issue := range issues {
+		if q.Until(issue) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetHotspots returns security hotspots for a project
 func (c *Client) GetHotspots(projectKey, branch string, maxResults int) ([]Hotspot, int, error) {
 	var allHotspots []Hotspot
@@ -224,9 +365,7 @@ func (c *Client) GetHotspots(projectKey, branch string, maxResults int) ([]Hotsp
 		params.Set("projectKey", projectKey)
 		params.Set("ps", fmt.Sprintf("%d", pageSize))
 		params.Set("p", fmt.Sprintf("%d", page))
-		if branch != "" {
-			params.Set("branch", branch)
-		}
+		setBranch(params, branch)
 
 		body, err := c.doRequest("GET", "/api/hotspots/search", params)
 		if err != nil {
@@ -260,10 +399,29 @@ func (c *Client) GetAnalyses(projectKey, branch string, limit int) ([]Analysis,
 	params := url.Values{}
 	params.Set("project", projectKey)
 	params.Set("ps", fmt.Sprintf("%d", limit))
-	if branch != "" {
-		params.Set("branch", branch)
+	setBranch(params, branch)
+
+	body, err := c.doRequest("GET", "/api/project_analyses/search", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp AnalysesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse analyses response: %w", err)
 	}
 
+	return resp.Analyses, nil
+}
+
+// GetAnalysesUntil returns the latest analyses at or before the given time, newest first
+func (c *Client) GetAnalysesUntil(projectKey, branch string, to time.Time, limit int) ([]Analysis, error) {
+	params := url.Values{}
+	params.Set("project", projectKey)
+	params.Set("ps", fmt.Sprintf("%d", limit))
+	params.Set("to", to.Format(DateTimeFormat))
+	setBranch(params, branch)
+
 	body, err := c.doRequest("GET", "/api/project_analyses/search", params)
 	if err != nil {
 		return nil, err
@@ -277,16 +435,101 @@ func (c *Client) GetAnalyses(projectKey, branch string, limit int) ([]Analysis,
 	return resp.Analyses, nil
 }
 
+// FindAnalysis looks up an analysis of a project by key
+func (c *Client) FindAnalysis(projectKey, branch, analysisKey string) (*Analysis, error) {
+	page := 1
+	pageSize := 100
+
+	for {
+		params := url.Values{}
+		params.Set("project", projectKey)
+		params.Set("ps", fmt.Sprintf("%d", pageSize))
+		params.Set("p", fmt.Sprintf("%d", page))
+		setBranch(params, branch)
+
+		body, err := c.doRequest("GET", "/api/project_analyses/search", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp AnalysesResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse analyses response: %w", err)
+		}
+
+		for _, a := range resp.Analyses {
+			if a.Key == analysisKey {
+				return &a, nil
+			}
+		}
+
+		if len(resp.Analyses) < pageSize || page*pageSize >= resp.Paging.Total {
+			break
+		}
+		page++
+	}
+
+	return nil, fmt.Errorf("analysis %s not found", analysisKey)
+}
+
+// GetMeasuresHistory returns the history of metrics up to the given time
+func (c *Client) GetMeasuresHistory(projectKey, branch string, metricKeys []string, to time.Time) ([]MeasureHistory, error) {
+	var result []MeasureHistory
+	page := 1
+	pageSize := 1000
+
+	for {
+		params := url.Values{}
+		params.Set("component", projectKey)
+		params.Set("metrics", strings.Join(metricKeys, ","))
+		params.Set("to", to.Format(DateTimeFormat))
+		params.Set("ps", fmt.Sprintf("%d", pageSize))
+		params.Set("p", fmt.Sprintf("%d", page))
+		setBranch(params, branch)
+
+		body, err := c.doRequest("GET", "/api/measures/search_history", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp MeasuresHistoryResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse measures history response: %w", err)
+		}
+
+		if page == 1 {
+			result = resp.Measures
+		} else {
+			// Later pages continue the history of the same metrics
+			for i := range result {
+				for _, m := range resp.Measures {
+					if m.Metric == result[i].Metric {
+						result[i].History = append(result[i].History, m.History...)
+					}
+				}
+			}
+		}
+
+		if page*pageSize >= resp.Paging.Total {
+			break
+		}
+		page++
+	}
+
+	return result, nil
+}
+
 // Validate checks if the client can connect to SonarQube
 func (c *Client) Validate() error {
 	_, err := c.doRequest("GET", "/api/system/status", nil)
 	return err
 }
 
-// GetSourceCode returns source code lines for a component
-func (c *Client) GetSourceCode(componentKey string, fromLine, toLine int) ([]SourceLine, error) {
+// GetSourceCode returns source code lines for a component of a branch or
+// pull request. A fromLine of 0 returns the whole file.
+func (c *Client) GetSourceCode(componentKey, branch string, fromLine, toLine int) ([]SourceLine, error) {
 	// Use /api/sources/show first as it returns explicit line numbers
-	sourceLines, err := c.getSourceCodeFromShow(componentKey, fromLine, toLine)
+	sourceLines, err := c.getSourceCodeFromShow(componentKey, branch, fromLine, toLine)
 	if err == nil && len(sourceLines) > 0 {
 		return sourceLines, nil
 	}
@@ -294,8 +537,13 @@ func (c *Client) GetSourceCode(componentKey string, fromLine, toLine int) ([]Sou
 	// Fallback to /api/sources/raw
 	params := url.Values{}
 	params.Set("key", componentKey)
-	params.Set("from", fmt.Sprintf("%d", fromLine))
-	params.Set("to", fmt.Sprintf("%d", toLine))
+	setBranch(params, branch)
+	if fromLine > 0 {
+		params.Set("from", fmt.Sprintf("%d", fromLine))
+		params.Set("to", fmt.Sprintf("%d", toLine))
+	} else {
+		fromLine = 1
+	}
 
 	body, err := c.doRequest("GET", "/api/sources/raw", params)
 	if err != nil {
@@ -303,11 +551,11 @@ func (c *Client) GetSourceCode(componentKey string, fromLine, toLine int) ([]Sou
 	}
 
 	// /api/sources/raw returns plain text, split by lines
-	lines := strings.Split(string(body), "\n")
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
 	var result []SourceLine
 	for i, line := range lines {
 		lineNum := fromLine + i
-		if lineNum <= toLine {
+		if toLine == 0 || lineNum <= toLine {
 			result = append(result, SourceLine{
 				Line: lineNum,
 				Code: line,
@@ -318,12 +566,40 @@ func (c *Client) GetSourceCode(componentKey string, fromLine, toLine int) ([]Sou
 	return result, nil
 }
 
+// GetSourceFile returns all source code lines for a component of a branch or
+// pull request
+func (c *Client) GetSourceFile(componentKey, branch string) ([]SourceLine, error) {
+	return c.GetSourceCode(componentKey, branch, 0, 0)
+}
+
+// GetLineCoverage returns the test coverage of each line of a component of a
+// branch or pull request from /api/sources/lines
+func (c *Client) GetLineCoverage(componentKey, branch string) ([]LineCoverage, error) {
+	params := url.Values{}
+	params.Set("key", componentKey)
+	setBranch(params, branch)
+
+	body, err := c.doRequest("GET", "/api/sources/lines", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp SourceLinesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse source lines response: %w", err)
+	}
+	return resp.Sources, nil
+}
+
 // getSourceCodeFromShow uses /api/sources/show which returns explicit line numbers
-func (c *Client) getSourceCodeFromShow(componentKey string, fromLine, toLine int) ([]SourceLine, error) {
+func (c *Client) getSourceCodeFromShow(componentKey, branch string, fromLine, toLine int) ([]SourceLine, error) {
 	params := url.Values{}
 	params.Set("key", componentKey)
-	params.Set("from", fmt.Sprintf("%d", fromLine))
-	params.Set("to", fmt.Sprintf("%d", toLine))
+	setBranch(params, branch)
+	if fromLine > 0 {
+		params.Set("from", fmt.Sprintf("%d", fromLine))
+		params.Set("to", fmt.Sprintf("%d", toLine))
+	}
 
 	body, err := c.doRequest("GET", "/api/sources/show", params)
 	if err != nil {
@@ -375,6 +651,119 @@ func (c *Client) GetRule(ruleKey string) (*Rule, error) {
 	return &resp.Rule, nil
 }
 
+// GetMetrics returns the definitions of all metrics of the server
+func (c *Client) GetMetrics() ([]Metric, error) {
+	var allMetrics []Metric
+	page := 1
+	pageSize := 500
+
+	for {
+		params := url.Values{}
+		params.Set("ps", fmt.Sprintf("%d", pageSize))
+		params.Set("p", fmt.Sprintf("%d", page))
+
+		body, err := c.doRequest("GET", "/api/metrics/search", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp MetricsResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse metrics response: %w", err)
+		}
+
+		allMetrics = append(allMetrics, resp.Metrics...)
+
+		if len(resp.Metrics) == 0 || len(allMetrics) >= resp.Total {
+			break
+		}
+		page++
+	}
+
+	return allMetrics, nil
+}
+
+// GetLanguages returns the languages supported by the server
+func (c *Client) GetLanguages() ([]Language, error) {
+	params := url.Values{}
+	params.Set("ps", "0")
+
+	body, err := c.doRequest("GET", "/api/languages/list", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp LanguagesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse languages response: %w", err)
+	}
+
+	return resp.Languages, nil
+}
+
+// GetServerVersion returns the SonarQube server version
+func (c *Client) GetServerVersion() (string, error) {
+	body, err := c.doRequest("GET", "/api/server/version", nil)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+// GetQualityProfiles returns the quality profiles used by a project
+func (c *Client) GetQualityProfiles(projectKey string) ([]QualityProfile, error) {
+	params := url.Values{}
+	params.Set("project", projectKey)
+
+	body, err := c.doRequest("GET", "/api/qualityprofiles/search", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp QualityProfilesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse quality profiles response: %w", err)
+	}
+
+	return resp.Profiles, nil
+}
+
+// GetActiveRules returns all rules activated in a quality profile
+func (c *Client) GetActiveRules(profileKey string) ([]Rule, error) {
+	var allRules []Rule
+	page := 1
+	pageSize := 500
+
+	for {
+		params := url.Values{}
+		params.Set("qprofile", profileKey)
+		params.Set("activation", "true")
+		params.Set("f", "name,mdDesc,htmlDesc,severity,lang,langName")
+		params.Set("ps", fmt.Sprintf("%d", pageSize))
+		params.Set("p", fmt.Sprintf("%d", page))
+
+		body, err := c.doRequest("GET", "/api/rules/search", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp RulesResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse rules response: %w", err)
+		}
+
+		allRules = append(allRules, resp.Rules...)
+
+		if len(resp.Rules) == 0 || len(allRules) >= resp.Total {
+			break
+		}
+		page++
+	}
+
+	return allRules, nil
+}
+
 // DefaultMetricKeys returns the default metric keys to fetch
 func DefaultMetricKeys() []string {
 	return []string{
@@ -384,6 +773,7 @@ func DefaultMetricKeys() []string {
 		"coverage",
 		"duplicated_lines_density",
 		"ncloc",
+		"ncloc_language_distribution",
 		"sqale_index",
 		"sqale_rating",
 		"reliability_rating",
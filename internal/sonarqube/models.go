@@ -1,11 +1,18 @@
 package sonarqube
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
 // Project represents a SonarQube project
 type Project struct {
-	Key          string `json:"key"`
-	Name         string `json:"name"`
-	Qualifier    string `json:"qualifier"`
-	LastAnalysis string `json:"lastAnalysisDate,omitempty"`
+	Key          string   `json:"key"`
+	Name         string   `json:"name"`
+	Qualifier    string   `json:"qualifier"`
+	LastAnalysis DateTime `json:"lastAnalysisDate,omitempty"`
 }
 
 // ProjectsResponse from /api/projects/search
@@ -20,10 +27,10 @@ type ProjectsResponse struct {
 
 // Branch represents a project branch
 type Branch struct {
-	Name         string `json:"name"`
-	IsMain       bool   `json:"isMain"`
-	Type         string `json:"type"`
-	AnalysisDate string `json:"analysisDate,omitempty"`
+	Name         string   `json:"name"`
+	IsMain       bool     `json:"isMain"`
+	Type         string   `json:"type"`
+	AnalysisDate DateTime `json:"analysisDate,omitempty"`
 }
 
 // BranchesResponse from /api/project_branches/list
@@ -31,6 +38,20 @@ type BranchesResponse struct {
 	Branches []Branch `json:"branches"`
 }
 
+// PullRequest represents an analysed pull request
+type PullRequest struct {
+	Key          string   `json:"key"`
+	Title        string   `json:"title"`
+	Branch       string   `json:"branch"`
+	Base         string   `json:"base"`
+	AnalysisDate DateTime `json:"analysisDate,omitempty"`
+}
+
+// PullRequestsResponse from /api/project_pull_requests/list
+type PullRequestsResponse struct {
+	PullRequests []PullRequest `json:"pullRequests"`
+}
+
 // QualityGateStatus represents quality gate status
 type QualityGateStatus struct {
 	Status     string      `json:"status"` // OK, WARN, ERROR
@@ -59,6 +80,87 @@ type Measure struct {
 	Period    *Period `json:"period,omitempty"`
 }
 
+// ComponentMeasures are the measures of one component of a project
+type ComponentMeasures struct {
+	Key      string    `json:"key"`
+	Path     string    `json:"path,omitempty"`
+	Language string    `json:"language,omitempty"` // language key of files
+	Measures []Measure `json:"measures"`
+}
+
+// ComponentTreeResponse from /api/measures/component_tree
+type ComponentTreeResponse struct {
+	Paging     Paging              `json:"paging"`
+	Components []ComponentMeasures `json:"components"`
+}
+
+// MeasureHistory is the history of one metric from /api/measures/search_history
+type MeasureHistory struct {
+	Metric  string         `json:"metric"`
+	History []HistoryValue `json:"history"`
+}
+
+// HistoryValue is the value of a metric at one analysis
+type HistoryValue struct {
+	Date  DateTime `json:"date"`
+	Value string   `json:"value,omitempty"`
+}
+
+// MeasuresHistoryResponse from /api/measures/search_history
+type MeasuresHistoryResponse struct {
+	Paging   Paging           `json:"paging"`
+	Measures []MeasureHistory `json:"measures"`
+}
+
+// DateTimeFormat is the date-time format used by the SonarQube web API
+const DateTimeFormat = "2006-01-02T15:04:05-0700"
+
+// ParseDateTime parses a SonarQube date-time such as 2026-10-01T10:00:00+0000,
+// or an RFC 3339 one
+func ParseDateTime(s string) (time.Time, error) {
+	if t, err := time.Parse(DateTimeFormat, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// DateTime is a date-time returned by SonarQube. It is encoded as RFC 3339,
+// and as null when absent.
+type DateTime struct {
+	time.Time
+}
+
+// UnmarshalJSON parses a SonarQube or RFC 3339 date-time; null and empty
+// strings leave the date absent
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		d.Time = time.Time{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		d.Time = time.Time{}
+		return nil
+	}
+	t, err := ParseDateTime(s)
+	if err != nil {
+		return fmt.Errorf("invalid date-time %q", s)
+	}
+	d.Time = t
+	return nil
+}
+
+// MarshalJSON encodes the date-time as RFC 3339, or null when absent
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Format(time.RFC3339))
+}
+
 // Period represents a period for measures
 type Period struct {
 	Value     string `json:"value"`
@@ -106,13 +208,25 @@ type Issue struct {
 	Project      string     `json:"project"`
 	Line         int        `json:"line,omitempty"`
 	TextRange    *TextRange `json:"textRange,omitempty"`
+	Hash         string     `json:"hash,omitempty"` // checksum of the flagged code with whitespace removed
 	Message      string     `json:"message"`
 	Type         string     `json:"type"` // BUG, VULNERABILITY, CODE_SMELL
 	Effort       string     `json:"effort,omitempty"`
-	CreationDate string     `json:"creationDate"`
+	CreationDate DateTime   `json:"creationDate"`
+	UpdateDate   DateTime   `json:"updateDate,omitempty"`
+	CloseDate    DateTime   `json:"closeDate,omitempty"`
 	Status       string     `json:"status"`
+	Resolution   string     `json:"resolution,omitempty"` // FIXED, FALSE-POSITIVE, WONTFIX, REMOVED
+	Author       string     `json:"author,omitempty"`
 	Tags         []string   `json:"tags,omitempty"`
 	Flows        []Flow     `json:"flows,omitempty"` // Additional location info
+	Impacts      []Impact   `json:"impacts,omitempty"`
+}
+
+// Impact is the effect of an issue on a software quality (SonarQube 10.2+)
+type Impact struct {
+	SoftwareQuality string `json:"softwareQuality"` // SECURITY, RELIABILITY, MAINTAINABILITY
+	Severity        string `json:"severity"`        // BLOCKER, HIGH, MEDIUM, LOW, INFO
 }
 
 // IssuesResponse from /api/issues/search
@@ -131,15 +245,15 @@ type Paging struct {
 
 // Hotspot represents a security hotspot
 type Hotspot struct {
-	Key                      string `json:"key"`
-	Component                string `json:"component"`
-	Project                  string `json:"project"`
-	SecurityCategory         string `json:"securityCategory"`
-	VulnerabilityProbability string `json:"vulnerabilityProbability"` // HIGH, MEDIUM, LOW
-	Status                   string `json:"status"`
-	Line                     int    `json:"line,omitempty"`
-	Message                  string `json:"message"`
-	CreationDate             string `json:"creationDate"`
+	Key                      string   `json:"key"`
+	Component                string   `json:"component"`
+	Project                  string   `json:"project"`
+	SecurityCategory         string   `json:"securityCategory"`
+	VulnerabilityProbability string   `json:"vulnerabilityProbability"` // HIGH, MEDIUM, LOW
+	Status                   string   `json:"status"`
+	Line                     int      `json:"line,omitempty"`
+	Message                  string   `json:"message"`
+	CreationDate             DateTime `json:"creationDate"`
 }
 
 // HotspotsResponse from /api/hotspots/search
@@ -150,9 +264,9 @@ type HotspotsResponse struct {
 
 // Analysis represents a project analysis
 type Analysis struct {
-	Key            string `json:"key"`
-	Date           string `json:"date"`
-	ProjectVersion string `json:"projectVersion,omitempty"`
+	Key            string   `json:"key"`
+	Date           DateTime `json:"date"`
+	ProjectVersion string   `json:"projectVersion,omitempty"`
 }
 
 // AnalysesResponse from /api/project_analyses/search
@@ -167,6 +281,19 @@ type SourceLine struct {
 	Code string `json:"code"`
 }
 
+// LineCoverage is the test coverage of one source line
+type LineCoverage struct {
+	Line              int  `json:"line"`
+	LineHits          *int `json:"lineHits,omitempty"` // nil when the line cannot be covered
+	Conditions        int  `json:"conditions,omitempty"`
+	CoveredConditions int  `json:"coveredConditions,omitempty"`
+}
+
+// SourceLinesResponse from /api/sources/lines
+type SourceLinesResponse struct {
+	Sources []LineCoverage `json:"sources"`
+}
+
 // SourceResponse from /api/sources/show
 type SourceResponse struct {
 	Sources [][]interface{} `json:"sources"`
@@ -188,3 +315,56 @@ type Rule struct {
 type RuleResponse struct {
 	Rule Rule `json:"rule"`
 }
+
+// RulesResponse from /api/rules/search
+type RulesResponse struct {
+	Total int    `json:"total"`
+	P     int    `json:"p"`
+	Ps    int    `json:"ps"`
+	Rules []Rule `json:"rules"`
+}
+
+// Metric is the definition of a metric
+type Metric struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Domain      string `json:"domain,omitempty"`
+	Type        string `json:"type"`      // INT, FLOAT, PERCENT, RATING, WORK_DUR, MILLISEC, BOOL, LEVEL, STRING, DATA, DISTRIB
+	Direction   int    `json:"direction"` // 1 if higher is better, -1 if lower is better, 0 if neither
+	Hidden      bool   `json:"hidden,omitempty"`
+	Custom      bool   `json:"custom,omitempty"`
+}
+
+// MetricsResponse from /api/metrics/search
+type MetricsResponse struct {
+	Total   int      `json:"total"`
+	P       int      `json:"p"`
+	Ps      int      `json:"ps"`
+	Metrics []Metric `json:"metrics"`
+}
+
+// Language is a language supported by the server
+type Language struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// LanguagesResponse from /api/languages/list
+type LanguagesResponse struct {
+	Languages []Language `json:"languages"`
+}
+
+// QualityProfile represents a quality profile
+type QualityProfile struct {
+	Key          string `json:"key"`
+	Name         string `json:"name"`
+	Language     string `json:"language"`
+	LanguageName string `json:"languageName"`
+	RulesUpdated string `json:"rulesUpdatedAt,omitempty"`
+}
+
+// QualityProfilesResponse from /api/qualityprofiles/search
+type QualityProfilesResponse struct {
+	Profiles []QualityProfile `json:"profiles"`
+}